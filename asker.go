@@ -1,10 +1,113 @@
 package locdoc
 
-import "context"
+import (
+	"context"
+	"regexp"
+	"strings"
+)
 
 // Asker provides natural language question answering over documentation.
 type Asker interface {
 	// Ask answers a natural language question about a project's documentation.
 	// Returns ENOTFOUND if the project does not exist.
-	Ask(ctx context.Context, projectID string, question string) (string, error)
+	Ask(ctx context.Context, projectID string, question string) (*AskResult, error)
+}
+
+// AskResult is the answer to a question along with the documents it cites.
+type AskResult struct {
+	Answer string `json:"answer"`
+
+	// CitedDocuments lists the SourceURL of each document referenced in
+	// Answer, in order of first citation.
+	CitedDocuments []string `json:"citedDocuments"`
+
+	// Sources lists each distinct document or section referenced in
+	// Answer, in order of first citation.
+	Sources []SourceRef `json:"sources"`
+}
+
+// SourceRef is a reference from an answer to a stored document, or to one
+// of its sections when Anchor is set.
+type SourceRef struct {
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Anchor string `json:"anchor,omitempty"`
+}
+
+// SourceRefs returns a SourceRef for each document in docs that is referenced
+// by a URL in answer. URLs are found anywhere in the text, which covers bare
+// URLs, markdown links and footnote definitions alike. A URL#anchor reference
+// yields a SourceRef for that section, provided the document has a heading
+// with that anchor. References are returned in order of first appearance.
+func SourceRefs(answer string, docs []*Document) []SourceRef {
+	bySource := make(map[string]*Document, len(docs))
+	for _, doc := range docs {
+		bySource[strings.TrimSuffix(doc.SourceURL, "/")] = doc
+	}
+
+	// Compiled once per answer; the pattern is only used here
+	urlRe := regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+
+	// Section anchors of each cited document, extracted on first use
+	anchors := make(map[*Document]map[string]bool)
+
+	seen := make(map[SourceRef]bool)
+	var refs []SourceRef
+	for _, raw := range urlRe.FindAllString(answer, -1) {
+		raw = strings.TrimRight(raw, ".,;:!?*_")
+		source, anchor, _ := strings.Cut(raw, "#")
+
+		doc, ok := bySource[strings.TrimSuffix(source, "/")]
+		if !ok {
+			continue
+		}
+		if anchor != "" {
+			if _, ok := anchors[doc]; !ok {
+				anchors[doc] = sectionAnchors(doc)
+			}
+			if !anchors[doc][anchor] {
+				continue
+			}
+		}
+
+		ref := SourceRef{Title: doc.Title, URL: doc.SourceURL, Anchor: anchor}
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// CitedDocuments returns the SourceURL of each document in docs that is
+// referenced by a URL in answer, either directly or as URL#anchor for one of
+// its sections. URLs are returned in order of first appearance in answer.
+// Callers that also need the SourceRefs should call SourceRefs once and pass
+// the result to CitedURLs instead.
+func CitedDocuments(answer string, docs []*Document) []string {
+	return CitedURLs(SourceRefs(answer, docs))
+}
+
+// CitedURLs returns the distinct URLs of refs, in order of first appearance.
+func CitedURLs(refs []SourceRef) []string {
+	seen := make(map[string]bool)
+	var cited []string
+	for _, ref := range refs {
+		if seen[ref.URL] {
+			continue
+		}
+		seen[ref.URL] = true
+		cited = append(cited, ref.URL)
+	}
+	return cited
+}
+
+// sectionAnchors returns the set of heading anchors in the document.
+func sectionAnchors(doc *Document) map[string]bool {
+	anchors := make(map[string]bool)
+	for _, sec := range ExtractSections(doc.Content) {
+		anchors[sec.Anchor] = true
+	}
+	return anchors
 }
@@ -11,10 +11,10 @@ import (
 
 // mockAsker verifies Asker interface can be implemented.
 type mockAsker struct {
-	AskFn func(ctx context.Context, projectID, question string) (string, error)
+	AskFn func(ctx context.Context, projectID, question string) (*locdoc.AskResult, error)
 }
 
-func (m *mockAsker) Ask(ctx context.Context, projectID, question string) (string, error) {
+func (m *mockAsker) Ask(ctx context.Context, projectID, question string) (*locdoc.AskResult, error) {
 	return m.AskFn(ctx, projectID, question)
 }
 
@@ -25,13 +25,125 @@ func TestAsker_CanBeImplemented(t *testing.T) {
 	t.Parallel()
 
 	asker := &mockAsker{
-		AskFn: func(_ context.Context, projectID, question string) (string, error) {
-			return "answer to " + question, nil
+		AskFn: func(_ context.Context, projectID, question string) (*locdoc.AskResult, error) {
+			return &locdoc.AskResult{Answer: "answer to " + question}, nil
 		},
 	}
 
-	answer, err := asker.Ask(context.Background(), "proj-1", "what is this?")
+	result, err := asker.Ask(context.Background(), "proj-1", "what is this?")
 
 	require.NoError(t, err)
-	assert.Equal(t, "answer to what is this?", answer)
+	assert.Equal(t, "answer to what is this?", result.Answer)
+}
+
+func TestCitedDocuments(t *testing.T) {
+	t.Parallel()
+
+	docs := []*locdoc.Document{
+		{SourceURL: "https://example.com/docs/intro", Content: "# Intro\n\n## Install\n\nRun it."},
+		{SourceURL: "https://example.com/docs/api/", Content: "# API"},
+		{SourceURL: "https://example.com/docs/faq", Content: "# FAQ"},
+	}
+
+	t.Run("returns cited source URLs in order of first appearance", func(t *testing.T) {
+		t.Parallel()
+
+		answer := "According to [DOC: API] (https://example.com/docs/api/), see also https://example.com/docs/intro.\n\n" +
+			"Sources:\n- https://example.com/docs/api/\n- https://example.com/docs/intro"
+
+		cited := locdoc.CitedDocuments(answer, docs)
+
+		assert.Equal(t, []string{"https://example.com/docs/api/", "https://example.com/docs/intro"}, cited)
+	})
+
+	t.Run("matches section anchors", func(t *testing.T) {
+		t.Parallel()
+
+		cited := locdoc.CitedDocuments("See https://example.com/docs/intro#install for details.", docs)
+
+		assert.Equal(t, []string{"https://example.com/docs/intro"}, cited)
+	})
+
+	t.Run("ignores anchors that are not sections of the document", func(t *testing.T) {
+		t.Parallel()
+
+		cited := locdoc.CitedDocuments("See https://example.com/docs/faq#missing.", docs)
+
+		assert.Empty(t, cited)
+	})
+
+	t.Run("ignores URLs that are not stored documents", func(t *testing.T) {
+		t.Parallel()
+
+		cited := locdoc.CitedDocuments("See https://example.com/docs/intro/extra and https://other.com/docs/faq.", docs)
+
+		assert.Empty(t, cited)
+	})
+
+	t.Run("counts a document once when several of its sections are cited", func(t *testing.T) {
+		t.Parallel()
+
+		cited := locdoc.CitedDocuments("See https://example.com/docs/intro#install and https://example.com/docs/intro.", docs)
+
+		assert.Equal(t, []string{"https://example.com/docs/intro"}, cited)
+	})
+
+	t.Run("tolerates trailing slash differences", func(t *testing.T) {
+		t.Parallel()
+
+		cited := locdoc.CitedDocuments("See https://example.com/docs/api and https://example.com/docs/faq/.", docs)
+
+		assert.Equal(t, []string{"https://example.com/docs/api/", "https://example.com/docs/faq"}, cited)
+	})
+}
+
+func TestCitedURLs(t *testing.T) {
+	t.Parallel()
+
+	refs := []locdoc.SourceRef{
+		{URL: "https://example.com/docs/intro", Anchor: "install"},
+		{URL: "https://example.com/docs/api/"},
+		{URL: "https://example.com/docs/intro"},
+	}
+
+	assert.Equal(t, []string{"https://example.com/docs/intro", "https://example.com/docs/api/"}, locdoc.CitedURLs(refs))
+}
+
+func TestSourceRefs(t *testing.T) {
+	t.Parallel()
+
+	docs := []*locdoc.Document{
+		{Title: "Intro", SourceURL: "https://example.com/docs/intro", Content: "# Intro\n\n## Install\n\nRun it."},
+		{Title: "FAQ", SourceURL: "https://example.com/docs/faq", Content: "# FAQ"},
+	}
+
+	t.Run("returns markdown link targets with their section anchors", func(t *testing.T) {
+		t.Parallel()
+
+		refs := locdoc.SourceRefs("First [install it](https://example.com/docs/intro#install), then read [the FAQ](https://example.com/docs/faq).", docs)
+
+		assert.Equal(t, []locdoc.SourceRef{
+			{Title: "Intro", URL: "https://example.com/docs/intro", Anchor: "install"},
+			{Title: "FAQ", URL: "https://example.com/docs/faq"},
+		}, refs)
+	})
+
+	t.Run("resolves footnote definitions", func(t *testing.T) {
+		t.Parallel()
+
+		refs := locdoc.SourceRefs("Run the installer.[^1]\n\n[^1]: https://example.com/docs/intro", docs)
+
+		assert.Equal(t, []locdoc.SourceRef{{Title: "Intro", URL: "https://example.com/docs/intro"}}, refs)
+	})
+
+	t.Run("keeps document and section references apart but drops repeats", func(t *testing.T) {
+		t.Parallel()
+
+		refs := locdoc.SourceRefs("https://example.com/docs/intro https://example.com/docs/intro#install https://example.com/docs/intro", docs)
+
+		assert.Equal(t, []locdoc.SourceRef{
+			{Title: "Intro", URL: "https://example.com/docs/intro"},
+			{Title: "Intro", URL: "https://example.com/docs/intro", Anchor: "install"},
+		}, refs)
+	})
 }
@@ -174,15 +174,10 @@ func TestFetch_CommitsStoreOnSuccess(t *testing.T) {
 		},
 	}
 
-	// Given: store tracks commit calls
-	var committed bool
 	store := &mock.PageStore{
-		SaveFn: func(_ context.Context, _ *locdoc.Page) error { return nil },
-		CommitFn: func() error {
-			committed = true
-			return nil
-		},
-		AbortFn: func() error { return nil },
+		SaveFn:   func(_ context.Context, _ *locdoc.Page) error { return nil },
+		CommitFn: func() error { return nil },
+		AbortFn:  func() error { return nil },
 	}
 
 	deps := &main.Dependencies{
@@ -204,7 +199,8 @@ func TestFetch_CommitsStoreOnSuccess(t *testing.T) {
 
 	// Then: store is committed
 	require.NoError(t, err)
-	assert.True(t, committed, "store should be committed on success")
+	assert.Equal(t, 1, store.CallCount("Commit"), "store should be committed on success")
+	assert.Equal(t, 0, store.CallCount("Abort"))
 }
 
 func TestFetch_AbortsStoreWhenNoPagesSaved(t *testing.T) {
@@ -235,18 +231,10 @@ func TestFetch_AbortsStoreWhenNoPagesSaved(t *testing.T) {
 		},
 	}
 
-	// Given: store tracks commit and abort calls
-	var committed, aborted bool
 	store := &mock.PageStore{
-		SaveFn: func(_ context.Context, _ *locdoc.Page) error { return nil },
-		CommitFn: func() error {
-			committed = true
-			return nil
-		},
-		AbortFn: func() error {
-			aborted = true
-			return nil
-		},
+		SaveFn:   func(_ context.Context, _ *locdoc.Page) error { return nil },
+		CommitFn: func() error { return nil },
+		AbortFn:  func() error { return nil },
 	}
 
 	deps := &main.Dependencies{
@@ -268,8 +256,9 @@ func TestFetch_AbortsStoreWhenNoPagesSaved(t *testing.T) {
 
 	// Then: store is aborted, not committed
 	require.NoError(t, err) // Command succeeds even if no pages saved
-	assert.False(t, committed, "store should not be committed when no pages saved")
-	assert.True(t, aborted, "store should be aborted when no pages saved")
+	assert.Equal(t, 0, store.CallCount("Commit"), "store should not be committed when no pages saved")
+	assert.Equal(t, 1, store.CallCount("Abort"), "store should be aborted when no pages saved")
+	assert.Equal(t, 0, store.CallCount("Save"))
 }
 
 func TestFetch_ContinuesOnPageFailures(t *testing.T) {
@@ -85,13 +85,6 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 		timeout = 10 * time.Second
 	}
 
-	rodFetcher, err := rod.NewFetcher(rod.WithFetchTimeout(timeout))
-	if err != nil {
-		fmt.Fprintln(stderr, "Hint: Chrome or Chromium must be installed")
-		return fmt.Errorf("failed to start browser: %w", err)
-	}
-	defer rodFetcher.Close()
-
 	httpFetcher := lochttp.NewFetcher(lochttp.WithTimeout(timeout))
 
 	// Create detector/prober for framework detection
@@ -106,19 +99,33 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 		concurrency = 3
 	}
 
+	rodFetcher, err := rod.NewFetcher(
+		rod.WithFetchTimeout(timeout),
+		rod.WithBrowserPoolSize(concurrency),
+	)
+	if err != nil {
+		fmt.Fprintln(stderr, "Hint: Chrome or Chromium must be installed")
+		return fmt.Errorf("failed to start browser: %w", err)
+	}
+	defer rodFetcher.Close()
+
 	// Probe to select the appropriate fetcher based on framework requirements
 	fetcher := ProbeFetcher(ctx, cli.URL, httpFetcher, rodFetcher, detector, extractor)
 
 	// Create link selector registry for recursive crawling fallback
-	fallbackSelector := goquery.NewGenericSelector()
+	var fallbackSelector locdoc.LinkSelector = goquery.NewGenericSelector()
+	if goquery.IsPaginatedURL(cli.URL) {
+		// Seed URL is one page of a sequence; follow next-page links
+		fallbackSelector = goquery.NewPaginatedSelector()
+	}
 	linkSelectors := goquery.NewRegistry(detector, fallbackSelector)
 	registerFrameworkSelectors(linkSelectors)
 
 	// Create rate limiter for recursive crawling (1 request per second per domain)
 	rateLimiter := crawl.NewDomainLimiter(1.0)
 
-	// Create Discoverer for recursive URL discovery fallback
-	discoverer := &crawl.Discoverer{
+	// Create a sitemap-less Crawler for recursive URL discovery fallback
+	discoverer := &crawl.Crawler{
 		HTTPFetcher:   httpFetcher,
 		RodFetcher:    rodFetcher,
 		Prober:        detector,
@@ -132,7 +139,7 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 	sitemapService := lochttp.NewSitemapService(nil)
 
 	// Wire the 3-interface architecture
-	deps.Source = NewCompositeSource(sitemapService, &DiscovererAdapter{Discoverer: discoverer})
+	deps.Source = crawl.NewCompositeURLSource(sitemapService, &crawl.DiscovererAdapter{Crawler: discoverer})
 	deps.Fetcher = NewConcurrentFetcher(fetcher, extractor, converter)
 	deps.Store = fs.NewFileStore(cli.Path, cli.Name)
 
@@ -166,4 +173,6 @@ func registerFrameworkSelectors(registry *goquery.Registry) {
 	registry.Register(locdoc.FrameworkVuePress, goquery.NewVuePressSelector())
 	registry.Register(locdoc.FrameworkGitBook, goquery.NewGitBookSelector())
 	registry.Register(locdoc.FrameworkNextra, goquery.NewNextraSelector())
+	registry.Register(locdoc.FrameworkAntora, goquery.NewAntoraSelector())
+	registry.Register(locdoc.FrameworkAntoraDynamic, goquery.NewAntoraSelector())
 }
@@ -22,7 +22,7 @@ type RenderDelayConfigurer interface {
 //   - Known JS-required framework (GitBook, zeroheight) → Use Rod with framework-specific delay
 //   - Known HTTP-only framework (Sphinx, MkDocs, etc.) → Use HTTP
 //   - Unknown framework → Fetch with both, compare content
-//   - HTTP fetch fails → Fall back to Rod
+//   - HTTP fetch fails or returns a challenge, login or empty page → Fall back to Rod
 //
 // Always returns a valid fetcher; never fails.
 func ProbeFetcher(
@@ -39,6 +39,10 @@ func ProbeFetcher(
 		// HTTP failed, fall back to Rod
 		return rodFetcher
 	}
+	if !prober.IsUsableContent(httpHTML) {
+		// Challenge, login or empty page: let the browser try
+		return rodFetcher
+	}
 
 	// Detect the framework
 	framework := prober.Detect(httpHTML)
@@ -51,7 +55,7 @@ func ProbeFetcher(
 	}
 
 	// Check if the framework requires JavaScript
-	requiresJS, known := prober.RequiresJS(framework)
+	requiresJS, known := prober.RequiresJSForFramework(framework)
 
 	if known {
 		if requiresJS {
@@ -47,7 +47,7 @@ func TestProbeFetcher(t *testing.T) {
 			DetectFn: func(html string) locdoc.Framework {
 				return locdoc.FrameworkMkDocs
 			},
-			RequiresJSFn: func(framework locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(framework locdoc.Framework) (bool, bool) {
 				return false, true // MkDocs doesn't require JS
 			},
 		}
@@ -91,7 +91,7 @@ func TestProbeFetcher(t *testing.T) {
 			DetectFn: func(html string) locdoc.Framework {
 				return locdoc.FrameworkGitBook
 			},
-			RequiresJSFn: func(framework locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(framework locdoc.Framework) (bool, bool) {
 				return true, true // GitBook requires JS
 			},
 		}
@@ -135,7 +135,7 @@ func TestProbeFetcher(t *testing.T) {
 			DetectFn: func(html string) locdoc.Framework {
 				return locdoc.FrameworkUnknown
 			},
-			RequiresJSFn: func(framework locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(framework locdoc.Framework) (bool, bool) {
 				return false, false // Unknown framework
 			},
 		}
@@ -189,7 +189,7 @@ func TestProbeFetcher(t *testing.T) {
 			DetectFn: func(html string) locdoc.Framework {
 				return locdoc.FrameworkUnknown
 			},
-			RequiresJSFn: func(framework locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(framework locdoc.Framework) (bool, bool) {
 				return false, false // Unknown framework
 			},
 		}
@@ -271,7 +271,7 @@ func TestProbeFetcher(t *testing.T) {
 			DetectFn: func(html string) locdoc.Framework {
 				return locdoc.FrameworkUnknown
 			},
-			RequiresJSFn: func(framework locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(framework locdoc.Framework) (bool, bool) {
 				return false, false // Unknown framework
 			},
 		}
@@ -1,68 +1,84 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"regexp"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/crawl"
+	lochttp "github.com/fwojciec/locdoc/http"
 )
 
 // Run executes the add command.
 func (c *AddCmd) Run(deps *Dependencies) error {
-	// Compile filters to URLFilter (validates regex patterns early)
-	var urlFilter *locdoc.URLFilter
-	if len(c.Filter) > 0 {
-		urlFilter = &locdoc.URLFilter{}
-		for _, pattern := range c.Filter {
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				fmt.Fprintf(deps.Stderr, "error: invalid regex filter pattern %q: %v\n", pattern, err)
-				fmt.Fprintln(deps.Stderr, "Filter patterns use Go regex syntax. Example patterns:")
-				fmt.Fprintln(deps.Stderr, "  /api/       - match URLs containing '/api/'")
-				fmt.Fprintln(deps.Stderr, "  ^https://   - match URLs starting with 'https://'")
-				fmt.Fprintln(deps.Stderr, "  \\.md$       - match URLs ending with '.md'")
-				return err
-			}
-			urlFilter.Include = append(urlFilter.Include, re)
+	// Compile filters to URLFilter (validates regex patterns early).
+	// Patterns from --filter-file come first, then --filter and --exclude.
+	urlFilter, err := c.URLFilter()
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		if locdoc.ErrorCode(err) == locdoc.EINVALID {
+			fmt.Fprintln(deps.Stderr, "Filter patterns use Go regex syntax. Example patterns:")
+			fmt.Fprintln(deps.Stderr, "  /api/       - match URLs containing '/api/'")
+			fmt.Fprintln(deps.Stderr, "  ^https://   - match URLs starting with 'https://'")
+			fmt.Fprintln(deps.Stderr, "  \\.md$       - match URLs ending with '.md'")
 		}
+		return err
 	}
 
 	// Preview mode: show URLs without creating project
 	if c.Preview {
-		urls, err := deps.Sitemaps.DiscoverURLs(deps.Ctx, c.URL, urlFilter)
+		if c.Depth > 0 {
+			deps.Crawler.MaxDepth = c.Depth
+		}
+
+		// URLs are printed as they are discovered, whether they come
+		// from the sitemap or from the recursive fallback, up to
+		// --preview-limit. The rest are only counted.
+		found := 0
+		_, err := deps.Crawler.DiscoverURLs(deps.Ctx, &locdoc.Project{SourceURL: c.URL}, urlFilter,
+			crawl.WithConcurrency(c.Concurrency),
+			crawl.WithOnURL(func(url string) {
+				found++
+				if c.PreviewLimit == 0 || found <= c.PreviewLimit {
+					fmt.Fprintln(deps.Stdout, url)
+				}
+			}))
 		if err != nil {
 			fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
 			return err
 		}
 
-		// Sitemap discovery returns URLs all at once, print them
-		if len(urls) > 0 {
-			for _, u := range urls {
-				fmt.Fprintln(deps.Stdout, u)
-			}
-			return nil
+		if c.PreviewLimit > 0 && found > c.PreviewLimit {
+			fmt.Fprintf(deps.Stdout, "Showing first %d of %d URLs (use --preview-limit 0 to show all)\n", c.PreviewLimit, found)
 		}
+		fmt.Fprintf(deps.Stdout, "Found %d URLs to crawl\n", found)
+		return nil
+	}
 
-		// Fall back to recursive discovery if sitemap returns no URLs
-		// Use streaming callback to print URLs as they're discovered
-		if deps.Discoverer != nil {
-			_, err = deps.Discoverer.DiscoverURLs(deps.Ctx, c.URL, urlFilter,
-				crawl.WithConcurrency(c.Concurrency),
-				crawl.WithOnURL(func(url string) {
-					fmt.Fprintln(deps.Stdout, url)
-				}))
-			if err != nil {
-				fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
-				return err
-			}
-		}
+	// Dry-run mode: crawl without creating the project or saving pages
+	if c.DryRun {
+		return c.dryRun(deps, urlFilter)
+	}
 
-		return nil
+	// Embeddings are only computed for saved pages, so previews and dry
+	// runs don't need an encoder
+	if deps.Crawler != nil && (c.Embed || c.EmbedModel != "") {
+		encoder, err := newVectorEncoder(deps.Ctx, deps.Stderr, c.Embed, c.EmbedModel, c.OllamaURL)
+		if err != nil {
+			return err
+		}
+		deps.Crawler.VectorEncoder = encoder
 	}
 
-	// Force mode: delete existing project first
+	// Force mode: delete existing project first, keeping its cached
+	// fetcher type for the same URL unless re-probing was requested
+	var fetcherType locdoc.FetcherType
 	if c.Force {
 		existing, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &c.Name})
 		if err != nil {
@@ -70,6 +86,9 @@ func (c *AddCmd) Run(deps *Dependencies) error {
 			return err
 		}
 		if len(existing) > 0 {
+			if !c.ReProbe && existing[0].SourceURL == c.URL {
+				fetcherType = existing[0].FetcherType
+			}
 			if err := deps.Projects.DeleteProject(deps.Ctx, existing[0].ID); err != nil {
 				fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
 				return err
@@ -77,70 +96,461 @@ func (c *AddCmd) Run(deps *Dependencies) error {
 		}
 	}
 
-	// Create project
-	project := &locdoc.Project{
-		Name:      c.Name,
-		SourceURL: c.URL,
-		Filter:    strings.Join(c.Filter, "\n"),
+	// Refuse to index the same URL under a second name, which would store
+	// every document twice
+	if !c.Force {
+		existing, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{SourceURL: &c.URL})
+		if err != nil {
+			fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+			return err
+		}
+		for _, p := range existing {
+			if p.Name != c.Name {
+				fmt.Fprintf(deps.Stderr, "Warning: project '%s' already indexes this URL. Use --force to proceed anyway.\n", p.Name)
+				return locdoc.Errorf(locdoc.ECONFLICT, "project %q already indexes %s", p.Name, c.URL)
+			}
+		}
 	}
 
-	if err := deps.Projects.CreateProject(deps.Ctx, project); err != nil {
-		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
-		return err
+	// Incremental mode: crawl into the existing project of the same name
+	var project *locdoc.Project
+	if c.SkipExisting {
+		existing, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &c.Name})
+		if err != nil {
+			fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+			return err
+		}
+		if len(existing) > 0 {
+			project = existing[0]
+			if project.SourceURL != c.URL {
+				fmt.Fprintf(deps.Stderr, "error: project %q indexes %s, not %s\n", c.Name, project.SourceURL, c.URL)
+				return locdoc.Errorf(locdoc.ECONFLICT, "project %q indexes %s", c.Name, project.SourceURL)
+			}
+			// The stored depth and webhook apply unless --depth or
+			// --webhook override them, and --tag adds to the stored tags
+			var upd locdoc.ProjectUpdate
+			if c.Depth > 0 && c.Depth != project.CrawlDepth {
+				upd.CrawlDepth = &c.Depth
+			}
+			if c.Webhook != "" && c.Webhook != project.WebhookURL {
+				upd.WebhookURL = &c.Webhook
+			}
+			if tags := mergeTags(project.Tags, c.Tag); len(tags) != len(project.Tags) {
+				upd.Tags = &tags
+			}
+			if upd.CrawlDepth != nil || upd.WebhookURL != nil || upd.Tags != nil {
+				updated, err := deps.Projects.UpdateProject(deps.Ctx, project.ID, upd)
+				if err != nil {
+					fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+					return err
+				}
+				project = updated
+			}
+		}
 	}
 
-	fmt.Fprintf(deps.Stdout, "Added project %q (%s)\n", c.Name, project.ID)
+	added := project == nil
+	if added {
+		project = &locdoc.Project{
+			Name:        c.Name,
+			SourceURL:   c.URL,
+			Filter:      urlFilter.StoredString(),
+			FetcherType: fetcherType,
+			CrawlDepth:  c.Depth,
+			WebhookURL:  c.Webhook,
+			Tags:        mergeTags(nil, c.Tag),
+		}
+
+		if err := deps.Projects.CreateProject(deps.Ctx, project); err != nil {
+			fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+			return err
+		}
+	}
+
+	// JSON-lines progress keeps stdout machine-readable, so human-readable
+	// messages go to stderr instead
+	jsonLines := c.ProgressJSON || !isTerminal(deps.Stdout)
+	out := deps.Stdout
+	if jsonLines {
+		out = deps.Stderr
+	}
+
+	if added {
+		fmt.Fprintf(out, "Added project %q (%s)\n", c.Name, project.ID)
+	} else {
+		fmt.Fprintf(out, "Updating project %q (%s)\n", c.Name, project.ID)
+	}
 
 	// Crawl documents if Crawler is provided
 	if deps.Crawler != nil {
-		// Apply user-specified concurrency
-		if c.Concurrency > 0 {
-			deps.Crawler.Concurrency = c.Concurrency
-		}
-
-		var total int
-
-		progress := func(event crawl.ProgressEvent) {
-			switch event.Type {
-			case crawl.ProgressStarted:
-				total = event.Total
-				fmt.Fprintf(deps.Stdout, "  Found %d URLs\n", event.Total)
-			case crawl.ProgressCompleted:
-				// Update progress line in place
-				// Show [N/M] when total is known, [N] when total is unknown (recursive crawl)
-				if total > 0 {
-					fmt.Fprintf(deps.Stdout, "\r  [%d/%d] %s",
-						event.Completed, total, crawl.TruncateURL(event.URL, 40))
-				} else {
-					fmt.Fprintf(deps.Stdout, "\r  [%d] %s",
-						event.Completed, crawl.TruncateURL(event.URL, 40))
-				}
-			case crawl.ProgressFailed:
-				// Print failure on its own line (persists in scroll history)
-				fmt.Fprintf(deps.Stderr, "  skip %s: %v\n", event.URL, event.Error)
-				// Update progress line after failure message
-				if total > 0 {
-					fmt.Fprintf(deps.Stdout, "\r  [%d/%d] %s",
-						event.Completed, total, crawl.TruncateURL(event.URL, 40))
-				} else {
-					fmt.Fprintf(deps.Stdout, "\r  [%d] %s",
-						event.Completed, crawl.TruncateURL(event.URL, 40))
-				}
-			case crawl.ProgressFinished:
-				// Clear progress line
-				fmt.Fprintf(deps.Stdout, "\r%s\r", strings.Repeat(" ", 80))
+		c.configureCrawler(deps)
+		if project.CrawlDepth > 0 {
+			deps.Crawler.MaxDepth = project.CrawlDepth
+		}
+
+		// Expose metrics for the duration of the crawl, plus --metrics-linger
+		// so a scraper polling at its usual interval sees the final totals
+		serveMetrics := deps.Metrics != nil && c.MetricsAddr != ""
+		if serveMetrics {
+			srv := lochttp.NewMetricsServer(c.MetricsAddr, deps.Metrics)
+			if err := srv.Open(); err != nil {
+				fmt.Fprintf(deps.Stderr, "error: %v\n", err)
+				return err
+			}
+			defer srv.Close()
+			fmt.Fprintf(out, "  Serving metrics at http://%s/metrics\n", srv.Addr())
+		}
+
+		result, err := c.crawl(deps.Ctx, deps, project, out, jsonLines)
+		if err != nil {
+			return err
+		}
+
+		if serveMetrics && !c.Watch && c.MetricsLinger > 0 {
+			c.lingerMetrics(deps, out)
+		}
+
+		if c.Watch {
+			// Re-crawls reuse the fetcher chosen by the initial crawl
+			watched := *project
+			if result.FetcherType != "" {
+				watched.FetcherType = result.FetcherType
 			}
+			return c.watch(deps, &watched, out, jsonLines)
 		}
+	}
+
+	return nil
+}
+
+// lingerMetrics keeps the process, and with it the metrics server, alive
+// for --metrics-linger after a crawl. It returns early when the context is
+// canceled.
+func (c *AddCmd) lingerMetrics(deps *Dependencies, out io.Writer) {
+	after := deps.After
+	if after == nil {
+		after = time.After
+	}
+
+	fmt.Fprintf(out, "  Serving final metrics for %s\n", c.MetricsLinger)
+	select {
+	case <-deps.Ctx.Done():
+	case <-after(c.MetricsLinger):
+	}
+}
+
+// configureCrawler applies the crawl flags to deps.Crawler.
+func (c *AddCmd) configureCrawler(deps *Dependencies) {
+	// Apply user-specified concurrency
+	if c.Concurrency > 0 {
+		deps.Crawler.Concurrency = c.Concurrency
+	}
+	deps.Crawler.MinContentLength = c.MinContent
+	deps.Crawler.MinQualityScore = c.MinQuality
+	if c.MinQuality == 0 {
+		deps.Crawler.ContentScorer = nil
+	}
+	deps.Crawler.RetryWithAlternate = c.RetryBrowser
+	deps.Crawler.AllowedDomains = c.AllowDomain
+	deps.Crawler.BlockedDomains = c.BlockDomain
+	deps.Crawler.Language = c.Language
+	deps.Crawler.MaxBytes = int64(c.MaxBytes)
+	deps.Crawler.StoreExtractedHTML = c.StoreExtracted
+	if c.SkipExisting {
+		deps.Crawler.Existing = deps.Documents
+		deps.Crawler.UseUpsert = true
+	}
+}
+
+// dryRun crawls the site without writing to the database and lists the
+// pages that would be saved. With --skip-existing, pages are compared
+// against the existing project of the same name, so unchanged pages are
+// left out of the list.
+func (c *AddCmd) dryRun(deps *Dependencies, urlFilter *locdoc.URLFilter) error {
+	project := &locdoc.Project{
+		Name:       c.Name,
+		SourceURL:  c.URL,
+		Filter:     urlFilter.StoredString(),
+		CrawlDepth: c.Depth,
+	}
 
-		result, err := deps.Crawler.CrawlProject(deps.Ctx, project, progress)
+	if c.SkipExisting {
+		existing, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &c.Name})
 		if err != nil {
-			fmt.Fprintf(deps.Stderr, "error crawling: %v\n", err)
+			fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
 			return err
 		}
+		if len(existing) > 0 && existing[0].SourceURL == c.URL {
+			project.ID = existing[0].ID
+			if !c.ReProbe {
+				project.FetcherType = existing[0].FetcherType
+			}
+			if c.Depth == 0 {
+				project.CrawlDepth = existing[0].CrawlDepth
+			}
+		}
+	}
+
+	if deps.Crawler == nil {
+		return nil
+	}
+	c.configureCrawler(deps)
+	if project.CrawlDepth > 0 {
+		deps.Crawler.MaxDepth = project.CrawlDepth
+	}
+	deps.Crawler.DryRun = true
 
-		fmt.Fprintf(deps.Stdout, "  Saved %d pages (%s, %s)\n",
-			result.Saved, crawl.FormatBytes(result.Bytes), crawl.FormatTokens(result.Tokens))
+	result, err := deps.Crawler.CrawlProject(deps.Ctx, project, nil)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error crawling: %v\n", err)
+		return err
+	}
+
+	for _, doc := range result.WouldSave {
+		fmt.Fprintf(deps.Stdout, "  %s  %q (%s, %s)\n",
+			doc.URL, doc.Title, crawl.FormatBytes(doc.Bytes), crawl.FormatTokens(doc.Tokens))
+	}
+	fmt.Fprintf(deps.Stdout, "  Would save %d pages (%s, %s) (%s transferred)\n",
+		len(result.WouldSave), crawl.FormatBytes(result.Bytes), crawl.FormatTokens(result.Tokens),
+		crawl.FormatBytes(result.TransferBytes))
+	for _, e := range result.Errors {
+		fmt.Fprintf(deps.Stderr, "  FAIL %s: %v\n", e.URL, e.Err)
+	}
+	if len(result.Unvisited) > 0 {
+		fmt.Fprintf(deps.Stderr, "warning: %d discovered URLs were not crawled (%s)\n", len(result.Unvisited), result.StopReason)
 	}
 
 	return nil
 }
+
+// crawl crawls the project once, reporting progress and the result.
+func (c *AddCmd) crawl(ctx context.Context, deps *Dependencies, project *locdoc.Project, out io.Writer, jsonLines bool) (*crawl.Result, error) {
+	var total int
+	var jsonProg *jsonProgress
+	if jsonLines {
+		jsonProg = newJSONProgress(deps.Stdout)
+	}
+
+	progress := func(event crawl.ProgressEvent) {
+		if jsonProg != nil {
+			jsonProg.handle(event)
+			return
+		}
+
+		switch event.Type {
+		case crawl.ProgressStarted:
+			total = event.Total
+			fmt.Fprintf(deps.Stdout, "  Found %d URLs\n", event.Total)
+		case crawl.ProgressCompleted:
+			// Update progress line in place
+			// Show [N/M] when total is known, [N] when total is unknown (recursive crawl)
+			if total > 0 {
+				fmt.Fprintf(deps.Stdout, "\r  [%d/%d] %s",
+					event.Completed, total, crawl.TruncateURL(event.URL, 40))
+			} else {
+				fmt.Fprintf(deps.Stdout, "\r  [%d] %s",
+					event.Completed, crawl.TruncateURL(event.URL, 40))
+			}
+		case crawl.ProgressFailed:
+			// Failures are listed after the crawl; just advance the progress line
+			if total > 0 {
+				fmt.Fprintf(deps.Stdout, "\r  [%d/%d] %s",
+					event.Completed, total, crawl.TruncateURL(event.URL, 40))
+			} else {
+				fmt.Fprintf(deps.Stdout, "\r  [%d] %s",
+					event.Completed, crawl.TruncateURL(event.URL, 40))
+			}
+		case crawl.ProgressSkipped:
+			fmt.Fprintf(deps.Stderr, "  skip %s: %s\n", event.URL, event.Reason)
+		case crawl.ProgressRetryAlternate:
+			fmt.Fprintf(deps.Stderr, "  retrying %s with browser: %s\n", event.URL, event.Reason)
+		case crawl.ProgressLimitReached:
+			fmt.Fprintf(deps.Stderr, "  stopped: %s reached\n", event.Reason)
+		case crawl.ProgressFinished:
+			// Clear progress line
+			fmt.Fprintf(deps.Stdout, "\r%s\r", strings.Repeat(" ", 80))
+		}
+	}
+
+	start := time.Now()
+	result, err := deps.Crawler.CrawlProject(ctx, project, progress)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error crawling: %v\n", err)
+		return nil, err
+	}
+
+	// Record the crawl time and cache the probe result so later crawls can
+	// skip probing
+	crawledAt := time.Now()
+	upd := locdoc.ProjectUpdate{LastCrawledAt: &crawledAt}
+	if result.FetcherType != "" && result.FetcherType != project.FetcherType {
+		upd.FetcherType = &result.FetcherType
+	}
+	if _, err := deps.Projects.UpdateProject(deps.Ctx, project.ID, upd); err != nil {
+		fmt.Fprintf(deps.Stderr, "warning: failed to update project: %s\n", locdoc.ErrorMessage(err))
+	}
+
+	if deps.Metrics != nil {
+		recordMetrics(deps, c.Name, project.ID, result, time.Since(start))
+	}
+
+	if jsonProg != nil {
+		jsonProg.finish(result)
+	}
+
+	fmt.Fprintf(out, "  Saved %d pages (%s, %s) (%s transferred)\n",
+		result.Saved, crawl.FormatBytes(result.Bytes), crawl.FormatTokens(result.Tokens),
+		crawl.FormatBytes(result.TransferBytes))
+	for _, e := range result.Errors {
+		fmt.Fprintf(deps.Stderr, "  FAIL %s: %v\n", e.URL, e.Err)
+	}
+	if len(result.Unvisited) > 0 {
+		fmt.Fprintf(deps.Stderr, "warning: %d discovered URLs were not crawled (%s)\n", len(result.Unvisited), result.StopReason)
+	}
+
+	if c.OnComplete != "" {
+		runOnComplete(deps, c.OnComplete, c.Name, result)
+	}
+	if project.WebhookURL != "" {
+		notifyWebhook(deps, project.WebhookURL, c.Name, result, time.Since(start))
+	}
+
+	return result, nil
+}
+
+// watch re-crawls the project every --interval until the context is
+// canceled. Each re-crawl is bounded by the interval and replaces the
+// project's documents only if it succeeds; a failed re-crawl keeps the
+// previous documents and watching continues.
+func (c *AddCmd) watch(deps *Dependencies, project *locdoc.Project, out io.Writer, jsonLines bool) error {
+	after := deps.After
+	if after == nil {
+		after = time.After
+	}
+
+	for {
+		fmt.Fprintf(out, "  Next crawl at %s\n", time.Now().Add(c.Interval).Format(time.TimeOnly))
+
+		select {
+		case <-deps.Ctx.Done():
+			fmt.Fprintf(out, "Stopped watching %q\n", c.Name)
+			return nil
+		case <-after(c.Interval):
+		}
+
+		if err := c.recrawl(deps, project, out, jsonLines); err != nil {
+			fmt.Fprintf(deps.Stderr, "warning: re-crawl failed, keeping previous documents: %s\n", locdoc.ErrorMessage(err))
+		}
+	}
+}
+
+// recrawl crawls the project again and then removes the documents saved by
+// earlier crawls. If the crawl fails or saves nothing (e.g. the site is
+// down), the documents it saved are removed instead so the project is left
+// as it was.
+func (c *AddCmd) recrawl(deps *Dependencies, project *locdoc.Project, out io.Writer, jsonLines bool) error {
+	previous, err := deps.Documents.FindDocuments(deps.Ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+	if err != nil {
+		return err
+	}
+	previousIDs := make(map[string]bool, len(previous))
+	for _, doc := range previous {
+		previousIDs[doc.ID] = true
+	}
+
+	ctx, cancel := context.WithTimeout(deps.Ctx, c.Interval)
+	defer cancel()
+
+	result, crawlErr := c.crawl(ctx, deps, project, out, jsonLines)
+	if crawlErr == nil && result.Saved == 0 {
+		crawlErr = locdoc.Errorf(locdoc.EINTERNAL, "no pages saved")
+	}
+
+	current, err := deps.Documents.FindDocuments(deps.Ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for _, doc := range current {
+		if previousIDs[doc.ID] == (crawlErr == nil) {
+			stale = append(stale, doc.ID)
+		}
+	}
+	if err := deps.Documents.BulkDeleteDocuments(deps.Ctx, stale); err != nil {
+		return err
+	}
+
+	return crawlErr
+}
+
+// runOnComplete runs the user's post-crawl hook through the shell with the
+// crawl result exposed as LOCDOC_* environment variables. A failing hook
+// only produces a warning; the crawl itself already succeeded.
+func runOnComplete(deps *Dependencies, command, name string, result *crawl.Result) {
+	cmd := exec.CommandContext(deps.Ctx, "sh", "-c", command)
+	cmd.Stdout = deps.Stdout
+	cmd.Stderr = deps.Stderr
+	cmd.Env = append(os.Environ(),
+		"LOCDOC_PROJECT_NAME="+name,
+		"LOCDOC_PAGES_SAVED="+strconv.Itoa(result.Saved),
+		"LOCDOC_PAGES_FAILED="+strconv.Itoa(result.Failed),
+		"LOCDOC_BYTES="+strconv.Itoa(result.Bytes),
+		"LOCDOC_TOKENS="+strconv.Itoa(result.Tokens),
+	)
+
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(deps.Stderr, "warning: on-complete command failed: %v\n", err)
+	}
+}
+
+// webhookTimeout bounds the crawl-completion webhook request.
+const webhookTimeout = 5 * time.Second
+
+// webhookPayload is the JSON body POSTed to a project's webhook.
+type webhookPayload struct {
+	Project    string `json:"project"`
+	Saved      int    `json:"saved"`
+	Failed     int    `json:"failed"`
+	Bytes      int    `json:"bytes"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// notifyWebhook POSTs a summary of the crawl to the project's webhook. Like
+// the on-complete hook, a failure only produces a warning.
+func notifyWebhook(deps *Dependencies, webhookURL, name string, result *crawl.Result, elapsed time.Duration) {
+	ctx, cancel := context.WithTimeout(deps.Ctx, webhookTimeout)
+	defer cancel()
+
+	payload := webhookPayload{
+		Project:    name,
+		Saved:      result.Saved,
+		Failed:     result.Failed,
+		Bytes:      result.Bytes,
+		DurationMS: elapsed.Milliseconds(),
+	}
+	if err := lochttp.NewFetcher().PostJSON(ctx, webhookURL, payload); err != nil {
+		fmt.Fprintf(deps.Stderr, "warning: webhook failed: %v\n", err)
+	}
+}
+
+// recordMetrics records the outcome of a crawl of project. Each page is
+// counted once, under the outcome in result. The documents gauge counts the
+// project's stored documents, which a crawl that skips unchanged pages or
+// repeats under --watch does not save again.
+func recordMetrics(deps *Dependencies, project, projectID string, result *crawl.Result, d time.Duration) {
+	deps.Metrics.AddPages(project, "saved", result.Saved)
+	deps.Metrics.AddPages(project, "failed", result.Failed)
+	deps.Metrics.AddPages(project, "skipped", result.Skipped)
+	deps.Metrics.AddBytes(project, result.Bytes)
+	deps.Metrics.ObserveDuration(project, d)
+
+	if deps.Documents == nil {
+		return
+	}
+	docs, err := deps.Documents.FindDocuments(deps.Ctx, locdoc.DocumentFilter{ProjectID: &projectID})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "warning: failed to count documents: %s\n", locdoc.ErrorMessage(err))
+		return
+	}
+	deps.Metrics.SetDocuments(project, len(docs))
+}
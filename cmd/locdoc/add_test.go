@@ -3,7 +3,16 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -11,8 +20,10 @@ import (
 	"github.com/fwojciec/locdoc"
 	main "github.com/fwojciec/locdoc/cmd/locdoc"
 	"github.com/fwojciec/locdoc/crawl"
+	lochttp "github.com/fwojciec/locdoc/http"
 	"github.com/fwojciec/locdoc/mock"
 	locslog "github.com/fwojciec/locdoc/slog"
+	"github.com/fwojciec/locdoc/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,11 +38,17 @@ func TestAddCmd_Run(t *testing.T) {
 		var savedDoc *locdoc.Document
 
 		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return nil, nil
+			},
 			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
 				p.ID = "proj-123"
 				createdProject = p
 				return nil
 			},
+			UpdateProjectFn: func(_ context.Context, _ string, _ locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				return &locdoc.Project{}, nil
+			},
 		}
 
 		sitemaps := &mock.SitemapService{
@@ -40,7 +57,7 @@ func TestAddCmd_Run(t *testing.T) {
 			},
 		}
 
-		documents := &mock.DocumentService{
+		documents := &mock.DocumentWriter{
 			CreateDocumentFn: func(_ context.Context, doc *locdoc.Document) error {
 				savedDoc = doc
 				return nil
@@ -78,20 +95,18 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
 
 		crawler := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher: fetcher,
-				RodFetcher:  fetcher,
-				Prober:      prober,
-				Extractor:   extractor,
-				Concurrency: 1,
-				RetryDelays: []time.Duration{0},
-			},
+			HTTPFetcher:  fetcher,
+			RodFetcher:   fetcher,
+			Prober:       prober,
+			Extractor:    extractor,
+			Concurrency:  1,
+			RetryDelays:  []time.Duration{0},
 			Sitemaps:     sitemaps,
 			Converter:    converter,
 			Documents:    documents,
@@ -151,7 +166,7 @@ func TestAddCmd_Run(t *testing.T) {
 			Stdout:   stdout,
 			Stderr:   stderr,
 			Projects: projects,
-			Sitemaps: sitemaps,
+			Crawler:  &crawl.Crawler{Sitemaps: sitemaps},
 		}
 
 		cmd := &main.AddCmd{
@@ -167,6 +182,77 @@ func TestAddCmd_Run(t *testing.T) {
 		assert.Contains(t, stdout.String(), "https://example.com/docs/page1")
 	})
 
+	t.Run("preview mode truncates long URL lists and prints the total", func(t *testing.T) {
+		t.Parallel()
+
+		urls := make([]string, 150)
+		for i := range urls {
+			urls[i] = fmt.Sprintf("https://example.com/docs/page%d", i)
+		}
+		sitemaps := &mock.SitemapService{
+			DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+				return urls, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:     context.Background(),
+			Stdout:  stdout,
+			Stderr:  &bytes.Buffer{},
+			Crawler: &crawl.Crawler{Sitemaps: sitemaps},
+		}
+
+		cmd := &main.AddCmd{
+			Name:         "testdocs",
+			URL:          "https://example.com/docs",
+			Preview:      true,
+			PreviewLimit: 100,
+		}
+
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, 100, strings.Count(stdout.String(), "https://example.com/docs/page"))
+		assert.Contains(t, stdout.String(), "Showing first 100 of 150 URLs (use --preview-limit 0 to show all)")
+		assert.Contains(t, stdout.String(), "Found 150 URLs to crawl")
+	})
+
+	t.Run("preview mode lists all URLs when the limit is zero", func(t *testing.T) {
+		t.Parallel()
+
+		urls := make([]string, 150)
+		for i := range urls {
+			urls[i] = fmt.Sprintf("https://example.com/docs/page%d", i)
+		}
+		sitemaps := &mock.SitemapService{
+			DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+				return urls, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:     context.Background(),
+			Stdout:  stdout,
+			Stderr:  &bytes.Buffer{},
+			Crawler: &crawl.Crawler{Sitemaps: sitemaps},
+		}
+
+		cmd := &main.AddCmd{
+			Name:    "testdocs",
+			URL:     "https://example.com/docs",
+			Preview: true,
+		}
+
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, 150, strings.Count(stdout.String(), "https://example.com/docs/page"))
+		assert.NotContains(t, stdout.String(), "Showing first")
+		assert.Contains(t, stdout.String(), "Found 150 URLs to crawl")
+	})
+
 	t.Run("invalid filter pattern shows helpful error", func(t *testing.T) {
 		t.Parallel()
 
@@ -197,10 +283,16 @@ func TestAddCmd_Run(t *testing.T) {
 		t.Parallel()
 
 		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return nil, nil
+			},
 			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
 				p.ID = "proj-123"
 				return nil
 			},
+			UpdateProjectFn: func(_ context.Context, _ string, _ locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				return &locdoc.Project{}, nil
+			},
 		}
 
 		sitemaps := &mock.SitemapService{
@@ -213,7 +305,7 @@ func TestAddCmd_Run(t *testing.T) {
 			},
 		}
 
-		documents := &mock.DocumentService{
+		documents := &mock.DocumentWriter{
 			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
 				return nil
 			},
@@ -241,23 +333,21 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
 
 		crawler := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher: fetcher,
-				RodFetcher:  fetcher,
-				Prober:      prober,
-				Extractor:   extractor,
-				Concurrency: 1,
-				RetryDelays: []time.Duration{0},
-			},
-			Sitemaps:  sitemaps,
-			Converter: converter,
-			Documents: documents,
+			HTTPFetcher: fetcher,
+			RodFetcher:  fetcher,
+			Prober:      prober,
+			Extractor:   extractor,
+			Concurrency: 1,
+			RetryDelays: []time.Duration{0},
+			Sitemaps:    sitemaps,
+			Converter:   converter,
+			Documents:   documents,
 		}
 
 		stdout := &bytes.Buffer{}
@@ -292,10 +382,16 @@ func TestAddCmd_Run(t *testing.T) {
 		t.Parallel()
 
 		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return nil, nil
+			},
 			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
 				p.ID = "proj-123"
 				return nil
 			},
+			UpdateProjectFn: func(_ context.Context, _ string, _ locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				return &locdoc.Project{}, nil
+			},
 		}
 
 		sitemaps := &mock.SitemapService{
@@ -304,7 +400,7 @@ func TestAddCmd_Run(t *testing.T) {
 			},
 		}
 
-		documents := &mock.DocumentService{
+		documents := &mock.DocumentWriter{
 			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
 				return nil
 			},
@@ -334,7 +430,7 @@ func TestAddCmd_Run(t *testing.T) {
 		linkSelectors := &mock.LinkSelectorRegistry{
 			GetForHTMLFn: func(html string) locdoc.LinkSelector {
 				return &mock.LinkSelector{
-					ExtractLinksFn: func(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+					ExtractLinksFn: func(html string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 						if baseURL == "https://example.com/docs/" {
 							return []locdoc.DiscoveredLink{
 								{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -357,25 +453,23 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
 
 		crawler := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher:   fetcher,
-				RodFetcher:    fetcher,
-				Prober:        prober,
-				Extractor:     extractor,
-				LinkSelectors: linkSelectors,
-				RateLimiter:   rateLimiter,
-				Concurrency:   1,
-				RetryDelays:   []time.Duration{0},
-			},
-			Sitemaps:  sitemaps,
-			Converter: converter,
-			Documents: documents,
+			HTTPFetcher:   fetcher,
+			RodFetcher:    fetcher,
+			Prober:        prober,
+			Extractor:     extractor,
+			LinkSelectors: linkSelectors,
+			RateLimiter:   rateLimiter,
+			Concurrency:   1,
+			RetryDelays:   []time.Duration{0},
+			Sitemaps:      sitemaps,
+			Converter:     converter,
+			Documents:     documents,
 		}
 
 		stdout := &bytes.Buffer{}
@@ -438,7 +532,7 @@ func TestAddCmd_Run(t *testing.T) {
 		linkSelectors := &mock.LinkSelectorRegistry{
 			GetForHTMLFn: func(html string) locdoc.LinkSelector {
 				return &mock.LinkSelector{
-					ExtractLinksFn: func(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+					ExtractLinksFn: func(html string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 						if baseURL == "https://example.com/docs/" {
 							return []locdoc.DiscoveredLink{
 								{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -470,7 +564,7 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
@@ -486,8 +580,8 @@ func TestAddCmd_Run(t *testing.T) {
 			Stdout:   stdout,
 			Stderr:   stderr,
 			Projects: projects,
-			Sitemaps: sitemaps,
-			Discoverer: &crawl.Discoverer{
+			Crawler: &crawl.Crawler{
+				Sitemaps:      sitemaps,
 				LinkSelectors: linkSelectors,
 				RateLimiter:   rateLimiter,
 				HTTPFetcher:   fetcher,
@@ -552,7 +646,7 @@ func TestAddCmd_Run(t *testing.T) {
 		linkSelectors := &mock.LinkSelectorRegistry{
 			GetForHTMLFn: func(html string) locdoc.LinkSelector {
 				return &mock.LinkSelector{
-					ExtractLinksFn: func(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+					ExtractLinksFn: func(html string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 						if baseURL == "https://example.com/docs/" {
 							return []locdoc.DiscoveredLink{
 								{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -576,7 +670,7 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
@@ -588,11 +682,11 @@ func TestAddCmd_Run(t *testing.T) {
 		}
 
 		deps := &main.Dependencies{
-			Ctx:      context.Background(),
-			Stdout:   stdout,
-			Stderr:   &bytes.Buffer{},
-			Sitemaps: sitemaps,
-			Discoverer: &crawl.Discoverer{
+			Ctx:    context.Background(),
+			Stdout: stdout,
+			Stderr: &bytes.Buffer{},
+			Crawler: &crawl.Crawler{
+				Sitemaps:      sitemaps,
 				LinkSelectors: linkSelectors,
 				RateLimiter:   rateLimiter,
 				HTTPFetcher:   fetcher,
@@ -647,7 +741,7 @@ func TestAddCmd_Run(t *testing.T) {
 		linkSelectors := &mock.LinkSelectorRegistry{
 			GetForHTMLFn: func(html string) locdoc.LinkSelector {
 				return &mock.LinkSelector{
-					ExtractLinksFn: func(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+					ExtractLinksFn: func(html string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 						return nil, nil // No links to follow
 					},
 					NameFn: func() string { return "test" },
@@ -676,7 +770,7 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
@@ -688,11 +782,11 @@ func TestAddCmd_Run(t *testing.T) {
 		}
 
 		deps := &main.Dependencies{
-			Ctx:      context.Background(),
-			Stdout:   stdout,
-			Stderr:   stderr,
-			Sitemaps: loggingSitemaps,
-			Discoverer: &crawl.Discoverer{
+			Ctx:    context.Background(),
+			Stdout: stdout,
+			Stderr: stderr,
+			Crawler: &crawl.Crawler{
+				Sitemaps:      loggingSitemaps,
 				LinkSelectors: loggingRegistry,
 				RateLimiter:   rateLimiter,
 				HTTPFetcher:   loggingFetcher,
@@ -734,10 +828,10 @@ func TestAddCmd_Run(t *testing.T) {
 
 		// No logging decorators - simulating Debug=false
 		deps := &main.Dependencies{
-			Ctx:      context.Background(),
-			Stdout:   stdout,
-			Stderr:   stderr,
-			Sitemaps: sitemaps,
+			Ctx:     context.Background(),
+			Stdout:  stdout,
+			Stderr:  stderr,
+			Crawler: &crawl.Crawler{Sitemaps: sitemaps},
 		}
 
 		cmd := &main.AddCmd{
@@ -758,10 +852,16 @@ func TestAddCmd_Run(t *testing.T) {
 		t.Parallel()
 
 		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return nil, nil
+			},
 			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
 				p.ID = "proj-123"
 				return nil
 			},
+			UpdateProjectFn: func(_ context.Context, _ string, _ locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				return &locdoc.Project{}, nil
+			},
 		}
 
 		sitemaps := &mock.SitemapService{
@@ -774,7 +874,7 @@ func TestAddCmd_Run(t *testing.T) {
 			},
 		}
 
-		documents := &mock.DocumentService{
+		documents := &mock.DocumentWriter{
 			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
 				return nil
 			},
@@ -783,7 +883,7 @@ func TestAddCmd_Run(t *testing.T) {
 		fetcher := &mock.Fetcher{
 			FetchFn: func(_ context.Context, url string) (string, error) {
 				if url == "https://example.com/docs/failing" {
-					return "", locdoc.Errorf(locdoc.ENOTFOUND, "connection timeout")
+					return "", errors.New("connection timeout")
 				}
 				return "<html><body>Test</body></html>", nil
 			},
@@ -805,23 +905,21 @@ func TestAddCmd_Run(t *testing.T) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkSphinx
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, true
 			},
 		}
 
 		crawler := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher: fetcher,
-				RodFetcher:  fetcher,
-				Prober:      prober,
-				Extractor:   extractor,
-				Concurrency: 1,
-				RetryDelays: []time.Duration{0},
-			},
-			Sitemaps:  sitemaps,
-			Converter: converter,
-			Documents: documents,
+			HTTPFetcher: fetcher,
+			RodFetcher:  fetcher,
+			Prober:      prober,
+			Extractor:   extractor,
+			Concurrency: 1,
+			RetryDelays: []time.Duration{0},
+			Sitemaps:    sitemaps,
+			Converter:   converter,
+			Documents:   documents,
 		}
 
 		stdout := &bytes.Buffer{}
@@ -847,12 +945,12 @@ func TestAddCmd_Run(t *testing.T) {
 
 		// Failures should print to stderr on separate lines
 		stderrOutput := stderr.String()
-		assert.Contains(t, stderrOutput, "failing", "stderr should contain the failing URL")
-		assert.Contains(t, stderrOutput, "\n", "failures should be on separate lines")
+		assert.Contains(t, stderrOutput, "  FAIL https://example.com/docs/failing: connection timeout\n")
 
 		// Summary should show correct count (2 saved, not 3)
 		stdoutOutput := stdout.String()
 		assert.Contains(t, stdoutOutput, "Saved 2 pages", "summary should show 2 saved pages")
+		assert.Contains(t, stdoutOutput, "transferred)", "summary should show transferred bytes")
 	})
 }
 
@@ -865,3 +963,984 @@ func (s *streamCapture) Write(p []byte) (n int, err error) {
 	s.onWrite(string(p))
 	return len(p), nil
 }
+
+// newTestSitemapCrawler returns a Crawler that discovers urls via sitemap
+// and saves each page through documents. Fetches go through fetcher so
+// callers can wrap it with decorators.
+func newTestSitemapCrawler(urls []string, fetcher locdoc.Fetcher, documents locdoc.DocumentWriter) *crawl.Crawler {
+	sitemaps := &mock.SitemapService{
+		DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return urls, nil
+		},
+	}
+	if fetcher == nil {
+		fetcher = &mock.Fetcher{
+			FetchFn: func(_ context.Context, _ string) (string, error) {
+				return "<html><body>Test content</body></html>", nil
+			},
+		}
+	}
+
+	return &crawl.Crawler{
+		HTTPFetcher: fetcher,
+		RodFetcher:  fetcher,
+		Prober: &mock.Prober{
+			DetectFn:                 func(_ string) locdoc.Framework { return locdoc.FrameworkSphinx },
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) { return false, true },
+		},
+		Extractor: &mock.Extractor{
+			ExtractFn: func(_ string) (*locdoc.ExtractResult, error) {
+				return &locdoc.ExtractResult{Title: "Test", ContentHTML: "<p>Test content</p>"}, nil
+			},
+		},
+		Concurrency: 1,
+		RetryDelays: []time.Duration{0},
+		Sitemaps:    sitemaps,
+		Converter: &mock.Converter{
+			ConvertFn: func(_ string) (string, error) { return "Test content", nil },
+		},
+		Documents: documents,
+		TokenCounter: &mock.TokenCounter{
+			CountTokensFn: func(_ context.Context, _ string) (int, error) { return 1, nil },
+		},
+	}
+}
+
+// newTestAddDeps returns Dependencies for running AddCmd against crawler.
+func newTestAddDeps(crawler *crawl.Crawler, stdout, stderr io.Writer) *main.Dependencies {
+	return &main.Dependencies{
+		Ctx:    context.Background(),
+		Stdout: stdout,
+		Stderr: stderr,
+		Projects: &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return nil, nil
+			},
+			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
+				p.ID = "proj-123"
+				return nil
+			},
+			UpdateProjectFn: func(_ context.Context, _ string, _ locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				return &locdoc.Project{}, nil
+			},
+		},
+		Sitemaps: crawler.Sitemaps,
+		Crawler:  crawler,
+	}
+}
+
+func TestAddCmd_Run_Metrics(t *testing.T) {
+	t.Parallel()
+
+	var savedCount int
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1", "https://example.com/docs/page2", "https://example.com/docs/broken"},
+		nil,
+		&mock.DocumentWriter{
+			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
+				savedCount++
+				return nil
+			},
+		},
+	)
+
+	metrics := lochttp.NewMetrics()
+	srv := httptest.NewServer(metrics)
+	defer srv.Close()
+
+	crawler.HTTPFetcher = &mock.Fetcher{
+		FetchFn: func(_ context.Context, url string) (string, error) {
+			if url == "https://example.com/docs/broken" {
+				return "", errors.New("connection reset")
+			}
+			return "<html><body>Test content</body></html>", nil
+		},
+	}
+	crawler.RetryDelays = []time.Duration{}
+
+	deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+	deps.Metrics = metrics
+	// The project also holds pages stored by earlier crawls
+	deps.Documents = &mock.DocumentService{
+		FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			assert.Equal(t, "proj-123", *filter.ProjectID)
+			return make([]*locdoc.Document, 5), nil
+		},
+	}
+
+	cmd := &main.AddCmd{
+		Name:        "testdocs",
+		URL:         "https://example.com/docs",
+		Concurrency: 1,
+	}
+	require.NoError(t, cmd.Run(deps))
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, savedCount)
+	assert.Contains(t, string(body), `locdoc_crawl_pages_total{project="testdocs",status="saved"} 2`)
+	assert.Contains(t, string(body), `locdoc_crawl_pages_total{project="testdocs",status="failed"} 1`)
+	assert.NotContains(t, string(body), `status="completed"`)
+	assert.Contains(t, string(body), `locdoc_documents_total{project="testdocs"} 5`)
+	assert.Contains(t, string(body), `locdoc_crawl_duration_seconds_count{project="testdocs"} 1`)
+}
+
+func TestAddCmd_Run_MetricsLinger(t *testing.T) {
+	t.Parallel()
+
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1"},
+		nil,
+		&mock.DocumentWriter{
+			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
+				return nil
+			},
+		},
+	)
+
+	stdout := &bytes.Buffer{}
+	deps := newTestAddDeps(crawler, stdout, &bytes.Buffer{})
+	deps.Metrics = lochttp.NewMetrics()
+
+	release := make(chan time.Time)
+	lingering := make(chan time.Duration, 1)
+	deps.After = func(d time.Duration) <-chan time.Time {
+		lingering <- d
+		return release
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		cmd := &main.AddCmd{
+			Name:          "testdocs",
+			URL:           "https://example.com/docs",
+			Concurrency:   1,
+			MetricsAddr:   "127.0.0.1:0",
+			MetricsLinger: time.Minute,
+		}
+		done <- cmd.Run(deps)
+	}()
+
+	// The crawl has finished once the command starts lingering
+	assert.Equal(t, time.Minute, <-lingering)
+	_, addr, ok := strings.Cut(stdout.String(), "Serving metrics at ")
+	require.True(t, ok)
+	addr, _, _ = strings.Cut(addr, "\n")
+
+	resp, err := http.Get(addr)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.Contains(t, string(body), `locdoc_crawl_pages_total{project="testdocs",status="saved"} 1`)
+	assert.Contains(t, stdout.String(), "Serving final metrics for 1m0s")
+}
+
+func TestAddCmd_Run_OnComplete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs command with crawl result in environment", func(t *testing.T) {
+		t.Parallel()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1", "https://example.com/docs/page2"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		stdout := &bytes.Buffer{}
+		deps := newTestAddDeps(crawler, stdout, &bytes.Buffer{})
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			OnComplete:  `echo "hook $LOCDOC_PROJECT_NAME saved=$LOCDOC_PAGES_SAVED failed=$LOCDOC_PAGES_FAILED"`,
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		assert.Contains(t, stdout.String(), "hook testdocs saved=2 failed=0")
+	})
+
+	t.Run("warns but succeeds when command fails", func(t *testing.T) {
+		t.Parallel()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		stderr := &bytes.Buffer{}
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, stderr)
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			OnComplete:  "exit 3",
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		assert.Contains(t, stderr.String(), "warning: on-complete command failed")
+	})
+}
+
+func TestAddCmd_Run_Tags(t *testing.T) {
+	t.Parallel()
+
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1"},
+		nil,
+		&mock.DocumentWriter{
+			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+		},
+	)
+	deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+	var created *locdoc.Project
+	deps.Projects.(*mock.ProjectService).CreateProjectFn = func(_ context.Context, p *locdoc.Project) error {
+		p.ID = "proj-123"
+		created = p
+		return nil
+	}
+
+	cmd := &main.AddCmd{
+		Name:        "testdocs",
+		URL:         "https://example.com/docs",
+		Concurrency: 1,
+		Tag:         []string{"go", "backend", "go"},
+	}
+	require.NoError(t, cmd.Run(deps))
+
+	require.NotNil(t, created)
+	assert.Equal(t, []string{"go", "backend"}, created.Tags)
+}
+
+func TestAddCmd_Run_MinQuality(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, minQuality float64) int {
+		t.Helper()
+
+		var saved int
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
+					saved++
+					return nil
+				},
+			},
+		)
+		crawler.ContentScorer = &mock.ContentScorer{
+			ScoreFn: func(_ string) float64 { return 0.05 },
+		}
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			MinQuality:  minQuality,
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		return saved
+	}
+
+	t.Run("skips pages scoring below --min-quality", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Zero(t, run(t, 0.1))
+	})
+
+	t.Run("saves every page when --min-quality is 0", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, 1, run(t, 0))
+	})
+}
+
+func TestAddCmd_Run_Webhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("posts crawl summary and stores the webhook on the project", func(t *testing.T) {
+		t.Parallel()
+
+		payloads := make(chan map[string]any, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			payloads <- payload
+		}))
+		defer srv.Close()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1", "https://example.com/docs/page2"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+		var created *locdoc.Project
+		deps.Projects.(*mock.ProjectService).CreateProjectFn = func(_ context.Context, p *locdoc.Project) error {
+			p.ID = "proj-123"
+			created = p
+			return nil
+		}
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			Webhook:     srv.URL,
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		require.NotNil(t, created)
+		assert.Equal(t, srv.URL, created.WebhookURL)
+		payload := <-payloads
+		assert.Equal(t, "testdocs", payload["project"])
+		assert.Equal(t, float64(2), payload["saved"])
+		assert.Equal(t, float64(0), payload["failed"])
+		assert.Contains(t, payload, "bytes")
+		assert.Contains(t, payload, "duration_ms")
+	})
+
+	t.Run("warns but succeeds when the webhook fails", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		stderr := &bytes.Buffer{}
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, stderr)
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			Webhook:     srv.URL,
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		assert.Contains(t, stderr.String(), "warning: webhook failed")
+	})
+}
+
+func TestAddCmd_Run_DuplicateSourceURL(t *testing.T) {
+	t.Parallel()
+
+	newProjects := func(created *bool) *mock.ProjectService {
+		return &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, filter locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				if filter.SourceURL != nil && *filter.SourceURL == "https://example.com/docs" {
+					return []*locdoc.Project{{ID: "proj-1", Name: "other-name", SourceURL: "https://example.com/docs"}}, nil
+				}
+				return nil, nil
+			},
+			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
+				*created = true
+				p.ID = "proj-2"
+				return nil
+			},
+		}
+	}
+
+	t.Run("refuses a second project for the same URL", func(t *testing.T) {
+		t.Parallel()
+
+		var created bool
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   stderr,
+			Projects: newProjects(&created),
+		}
+
+		cmd := &main.AddCmd{Name: "my-docs", URL: "https://example.com/docs"}
+		err := cmd.Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ECONFLICT, locdoc.ErrorCode(err))
+		assert.Contains(t, stderr.String(),
+			"Warning: project 'other-name' already indexes this URL. Use --force to proceed anyway.")
+		assert.False(t, created)
+	})
+
+	t.Run("allows a different URL", func(t *testing.T) {
+		t.Parallel()
+
+		var created bool
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: newProjects(&created),
+		}
+
+		cmd := &main.AddCmd{Name: "my-docs", URL: "https://example.com/other"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+
+	t.Run("proceeds with --force", func(t *testing.T) {
+		t.Parallel()
+
+		var created bool
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: newProjects(&created),
+		}
+
+		cmd := &main.AddCmd{Name: "my-docs", URL: "https://example.com/docs", Force: true}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+}
+
+func TestAddCmd_Run_SkipExisting(t *testing.T) {
+	t.Parallel()
+
+	var upserted []string
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1", "https://example.com/docs/page2"},
+		nil,
+		&mock.DocumentWriter{
+			UpsertDocumentFn: func(_ context.Context, doc *locdoc.Document) error {
+				assert.Equal(t, "proj-1", doc.ProjectID)
+				upserted = append(upserted, doc.SourceURL)
+				return nil
+			},
+		},
+	)
+	stdout := &bytes.Buffer{}
+	deps := newTestAddDeps(crawler, stdout, &bytes.Buffer{})
+	deps.Projects.(*mock.ProjectService).FindProjectsFn = func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+		return []*locdoc.Project{{ID: "proj-1", Name: "testdocs", SourceURL: "https://example.com/docs"}}, nil
+	}
+	deps.Projects.(*mock.ProjectService).CreateProjectFn = func(_ context.Context, _ *locdoc.Project) error {
+		t.Error("existing project should be reused")
+		return nil
+	}
+	deps.Documents = &mock.DocumentService{
+		FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			if *filter.SourceURL == "https://example.com/docs/page1" {
+				return []*locdoc.Document{{ID: "doc-1", ContentHash: crawl.ComputeHash("Test content")}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	cmd := &main.AddCmd{
+		Name:         "testdocs",
+		URL:          "https://example.com/docs",
+		Concurrency:  1,
+		SkipExisting: true,
+	}
+	require.NoError(t, cmd.Run(deps))
+
+	assert.Equal(t, []string{"https://example.com/docs/page2"}, upserted)
+	assert.Contains(t, stdout.String(), `Updating project "testdocs" (proj-1)`)
+}
+
+func TestAddCmd_Run_SkipExisting_SQLite(t *testing.T) {
+	t.Parallel()
+
+	db := sqlite.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, db.Open())
+	t.Cleanup(func() { _ = db.Close() })
+	projects := sqlite.NewProjectService(db)
+	documents := sqlite.NewDocumentService(db)
+
+	content := map[string]string{
+		"https://example.com/docs/page1": "Page one",
+		"https://example.com/docs/page2": "Page two",
+	}
+	run := func(skipExisting bool) {
+		t.Helper()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1", "https://example.com/docs/page2"},
+			&mock.Fetcher{
+				FetchFn: func(_ context.Context, url string) (string, error) {
+					return content[url], nil
+				},
+			},
+			documents,
+		)
+		crawler.Extractor = &mock.Extractor{
+			ExtractFn: func(html string) (*locdoc.ExtractResult, error) {
+				return &locdoc.ExtractResult{Title: "Test", ContentHTML: html}, nil
+			},
+		}
+		crawler.Converter = &mock.Converter{
+			ConvertFn: func(html string) (string, error) { return html, nil },
+		}
+
+		// A crawl blocked on the database fails at the deadline instead of
+		// hanging the test
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		deps := &main.Dependencies{
+			Ctx:       ctx,
+			Stdout:    &bytes.Buffer{},
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+			Sitemaps:  crawler.Sitemaps,
+			Crawler:   crawler,
+		}
+
+		cmd := &main.AddCmd{
+			Name:         "testdocs",
+			URL:          "https://example.com/docs",
+			Concurrency:  1,
+			SkipExisting: skipExisting,
+		}
+		require.NoError(t, cmd.Run(deps))
+	}
+
+	run(false)
+	list, err := documents.FindDocuments(context.Background(), locdoc.DocumentFilter{SortBy: locdoc.SortByPosition})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	unchangedID := list[0].ID
+
+	content["https://example.com/docs/page2"] = "Page two, revised"
+	run(true)
+
+	list, err = documents.FindDocuments(context.Background(), locdoc.DocumentFilter{SortBy: locdoc.SortByPosition})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, unchangedID, list[0].ID)
+	assert.Equal(t, "Page one", list[0].Content)
+	assert.Equal(t, "Page two, revised", list[1].Content)
+}
+
+func TestAddCmd_Run_Depth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores --depth on a new project", func(t *testing.T) {
+		t.Parallel()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+		var created *locdoc.Project
+		deps.Projects.(*mock.ProjectService).CreateProjectFn = func(_ context.Context, p *locdoc.Project) error {
+			p.ID = "proj-123"
+			created = p
+			return nil
+		}
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			Depth:       3,
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		require.NotNil(t, created)
+		assert.Equal(t, 3, created.CrawlDepth)
+		assert.Equal(t, 3, crawler.MaxDepth)
+	})
+
+	t.Run("applies the stored depth when updating a project", func(t *testing.T) {
+		t.Parallel()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			nil,
+			&mock.DocumentWriter{
+				UpsertDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+		deps.Projects.(*mock.ProjectService).FindProjectsFn = func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+			return []*locdoc.Project{{ID: "proj-1", Name: "testdocs", SourceURL: "https://example.com/docs", CrawlDepth: 2}}, nil
+		}
+		deps.Documents = &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return nil, nil
+			},
+		}
+
+		cmd := &main.AddCmd{
+			Name:         "testdocs",
+			URL:          "https://example.com/docs",
+			Concurrency:  1,
+			SkipExisting: true,
+		}
+		require.NoError(t, cmd.Run(deps))
+
+		assert.Equal(t, 2, crawler.MaxDepth)
+	})
+}
+
+func TestAddCmd_Run_DryRun(t *testing.T) {
+	t.Parallel()
+
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1", "https://example.com/docs/page2"},
+		nil,
+		&mock.DocumentWriter{
+			CreateDocumentFn: func(_ context.Context, doc *locdoc.Document) error {
+				t.Errorf("CreateDocument called for %s in dry run", doc.SourceURL)
+				return nil
+			},
+		},
+	)
+	stdout := &bytes.Buffer{}
+	deps := newTestAddDeps(crawler, stdout, &bytes.Buffer{})
+	deps.Projects.(*mock.ProjectService).CreateProjectFn = func(_ context.Context, _ *locdoc.Project) error {
+		t.Error("dry run should not create a project")
+		return nil
+	}
+	deps.Projects.(*mock.ProjectService).UpdateProjectFn = func(_ context.Context, _ string, _ locdoc.ProjectUpdate) (*locdoc.Project, error) {
+		t.Error("dry run should not update the project")
+		return nil, nil
+	}
+
+	cmd := &main.AddCmd{
+		Name:        "testdocs",
+		URL:         "https://example.com/docs",
+		Concurrency: 1,
+		DryRun:      true,
+	}
+	require.NoError(t, cmd.Run(deps))
+
+	assert.Contains(t, stdout.String(), `https://example.com/docs/page1  "Test"`)
+	assert.Contains(t, stdout.String(), `https://example.com/docs/page2  "Test"`)
+	assert.Contains(t, stdout.String(), "Would save 2 pages")
+}
+
+func TestAddCmd_Run_RecordsLastCrawledAt(t *testing.T) {
+	t.Parallel()
+
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1"},
+		nil,
+		&mock.DocumentWriter{
+			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+		},
+	)
+	deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+
+	var updates []locdoc.ProjectUpdate
+	deps.Projects.(*mock.ProjectService).UpdateProjectFn = func(_ context.Context, id string, upd locdoc.ProjectUpdate) (*locdoc.Project, error) {
+		assert.Equal(t, "proj-123", id)
+		updates = append(updates, upd)
+		return &locdoc.Project{}, nil
+	}
+
+	before := time.Now()
+	cmd := &main.AddCmd{Name: "testdocs", URL: "https://example.com/docs", Concurrency: 1}
+	require.NoError(t, cmd.Run(deps))
+
+	require.Len(t, updates, 1)
+	require.NotNil(t, updates[0].LastCrawledAt)
+	assert.False(t, updates[0].LastCrawledAt.Before(before))
+}
+
+func TestAddCmd_Run_FetcherTypeCache(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, reProbe bool) (created *locdoc.Project, saved *locdoc.FetcherType) {
+		t.Helper()
+
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			nil,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+		deps.Projects = &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{
+					ID:          "old-id",
+					Name:        "testdocs",
+					SourceURL:   "https://example.com/docs",
+					FetcherType: locdoc.FetcherTypeRod,
+				}}, nil
+			},
+			DeleteProjectFn: func(_ context.Context, _ string) error { return nil },
+			CreateProjectFn: func(_ context.Context, p *locdoc.Project) error {
+				p.ID = "proj-123"
+				created = p
+				return nil
+			},
+			UpdateProjectFn: func(_ context.Context, _ string, upd locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				saved = upd.FetcherType
+				return &locdoc.Project{}, nil
+			},
+		}
+
+		cmd := &main.AddCmd{
+			Name:        "testdocs",
+			URL:         "https://example.com/docs",
+			Concurrency: 1,
+			Force:       true,
+			ReProbe:     reProbe,
+		}
+		require.NoError(t, cmd.Run(deps))
+		return created, saved
+	}
+
+	t.Run("force keeps cached fetcher type", func(t *testing.T) {
+		t.Parallel()
+
+		created, saved := run(t, false)
+
+		require.NotNil(t, created)
+		assert.Equal(t, locdoc.FetcherTypeRod, created.FetcherType)
+		assert.Nil(t, saved, "unchanged fetcher type should not be saved again")
+	})
+
+	t.Run("re-probe discards cached fetcher type and saves probe result", func(t *testing.T) {
+		t.Parallel()
+
+		created, saved := run(t, true)
+
+		require.NotNil(t, created)
+		assert.Empty(t, created.FetcherType)
+		require.NotNil(t, saved)
+		assert.Equal(t, locdoc.FetcherTypeHTTP, *saved)
+	})
+}
+
+func TestAddCmd_Run_ProgressJSON(t *testing.T) {
+	t.Parallel()
+
+	crawler := newTestSitemapCrawler(
+		[]string{"https://example.com/docs/page1", "https://example.com/docs/page2"},
+		nil,
+		&mock.DocumentWriter{
+			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+		},
+	)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	deps := newTestAddDeps(crawler, stdout, stderr)
+
+	cmd := &main.AddCmd{
+		Name:         "testdocs",
+		URL:          "https://example.com/docs",
+		Concurrency:  1,
+		ProgressJSON: true,
+	}
+	require.NoError(t, cmd.Run(deps))
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 3, "expected two completed lines and a finished line")
+
+	var events []map[string]any
+	for _, line := range lines {
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &event), "line should be valid JSON: %s", line)
+		events = append(events, event)
+	}
+
+	for i, event := range events[:2] {
+		assert.Equal(t, "completed", event["event"])
+		assert.Contains(t, event["url"], "https://example.com/docs/page")
+		assert.InDelta(t, float64(i+1), event["position"], 0)
+		assert.InDelta(t, float64(i+1), event["saved"], 0)
+		assert.InDelta(t, 2, event["total"], 0)
+	}
+
+	finished := events[2]
+	assert.Equal(t, "finished", finished["event"])
+	assert.InDelta(t, 2, finished["saved"], 0)
+	assert.Contains(t, finished, "bytes")
+
+	assert.Contains(t, stderr.String(), "Added project", "human-readable output should move to stderr")
+}
+
+// watchStore is an in-memory document store shared by the crawler's
+// DocumentWriter and the command's DocumentService in watch tests.
+type watchStore struct {
+	mu   sync.Mutex
+	next int
+	docs map[string]*locdoc.Document
+}
+
+func newWatchStore() *watchStore {
+	return &watchStore{docs: make(map[string]*locdoc.Document)}
+}
+
+func (s *watchStore) writer() *mock.DocumentWriter {
+	return &mock.DocumentWriter{
+		CreateDocumentFn: func(_ context.Context, doc *locdoc.Document) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.next++
+			doc.ID = fmt.Sprintf("doc-%d", s.next)
+			s.docs[doc.ID] = doc
+			return nil
+		},
+	}
+}
+
+func (s *watchStore) service() *mock.DocumentService {
+	return &mock.DocumentService{
+		FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			docs := make([]*locdoc.Document, 0, len(s.docs))
+			for _, doc := range s.docs {
+				docs = append(docs, doc)
+			}
+			return docs, nil
+		},
+		BulkDeleteDocumentsFn: func(_ context.Context, ids []string) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, id := range ids {
+				delete(s.docs, id)
+			}
+			return nil
+		},
+	}
+}
+
+func (s *watchStore) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.docs))
+	for id := range s.docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestAddCmd_Run_Watch(t *testing.T) {
+	t.Parallel()
+
+	// runWatch runs the command with a fake timer, delivers the given number
+	// of ticks, and cancels once the last re-crawl has finished.
+	runWatch := func(t *testing.T, deps *main.Dependencies, ticks int) error {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		deps.Ctx = ctx
+
+		tick := make(chan time.Time)
+		waiting := make(chan struct{}, ticks+1)
+		deps.After = func(time.Duration) <-chan time.Time {
+			waiting <- struct{}{}
+			return tick
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			cmd := &main.AddCmd{
+				Name:        "test",
+				URL:         "https://example.com/docs",
+				Concurrency: 1,
+				Watch:       true,
+				Interval:    time.Hour,
+			}
+			done <- cmd.Run(deps)
+		}()
+
+		for range ticks {
+			<-waiting
+			tick <- time.Now()
+		}
+		<-waiting
+		cancel()
+		return <-done
+	}
+
+	t.Run("re-crawls once per tick until canceled", func(t *testing.T) {
+		t.Parallel()
+
+		store := newWatchStore()
+		var crawls int
+		crawler := newTestSitemapCrawler(nil, nil, store.writer())
+		crawler.Sitemaps = &mock.SitemapService{
+			DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+				crawls++
+				return []string{"https://example.com/docs/page1", "https://example.com/docs/page2"}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := newTestAddDeps(crawler, stdout, &bytes.Buffer{})
+		deps.Documents = store.service()
+
+		err := runWatch(t, deps, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, crawls, "initial crawl plus one per tick")
+		assert.Equal(t, []string{"doc-7", "doc-8"}, store.ids(), "only the latest crawl's documents remain")
+		assert.Equal(t, 4, strings.Count(stdout.String(), "Next crawl at"))
+		assert.Contains(t, stdout.String(), `Stopped watching "test"`)
+	})
+
+	t.Run("keeps previous documents when a re-crawl saves nothing", func(t *testing.T) {
+		t.Parallel()
+
+		store := newWatchStore()
+		var fetches int
+		fetcher := &mock.Fetcher{
+			FetchFn: func(_ context.Context, _ string) (string, error) {
+				// The initial crawl probes the page and then fetches it
+				fetches++
+				if fetches > 2 {
+					return "", locdoc.Errorf(locdoc.EINTERNAL, "site down")
+				}
+				return "<html><body>Test content</body></html>", nil
+			},
+		}
+		crawler := newTestSitemapCrawler([]string{"https://example.com/docs/page1"}, fetcher, store.writer())
+
+		stderr := &bytes.Buffer{}
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, stderr)
+		deps.Documents = store.service()
+
+		err := runWatch(t, deps, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"doc-1"}, store.ids())
+		assert.Contains(t, stderr.String(), "re-crawl failed, keeping previous documents")
+	})
+}
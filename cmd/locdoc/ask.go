@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"strings"
 
 	"github.com/fwojciec/locdoc"
 )
@@ -21,12 +23,152 @@ func (c *AskCmd) Run(deps *Dependencies) error {
 
 	project := projects[0]
 
-	answer, err := deps.Asker.Ask(deps.Ctx, project.ID, c.Question)
+	session, err := c.session(deps, project)
 	if err != nil {
 		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
 		return err
 	}
 
-	fmt.Fprintln(deps.Stdout, answer)
+	var history []locdoc.Turn
+	if session != nil {
+		history = session.Turns
+	}
+
+	if c.Interactive || c.Question == "" {
+		return c.repl(deps, project, session, history)
+	}
+
+	if _, err := c.answer(deps, project, session, history, c.Question); err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
 	return nil
 }
+
+// repl reads questions from stdin one line at a time and answers each with
+// the earlier turns of the conversation as context. It stops at end of input
+// or when the user types "exit". Failed questions are reported and skipped.
+// The prompt is only shown when stdin is a terminal.
+func (c *AskCmd) repl(deps *Dependencies, project *locdoc.Project, session *locdoc.Session, history []locdoc.Turn) error {
+	prompt := isTerminal(deps.Stdin)
+	scanner := bufio.NewScanner(deps.Stdin)
+
+	for {
+		if prompt {
+			fmt.Fprint(deps.Stdout, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+
+		question := strings.TrimSpace(scanner.Text())
+		if question == "" {
+			continue
+		}
+		if question == "exit" {
+			return nil
+		}
+
+		turn, err := c.answer(deps, project, session, history, question)
+		if err != nil {
+			fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+			continue
+		}
+		history = append(history, turn)
+	}
+
+	if prompt {
+		fmt.Fprintln(deps.Stdout)
+	}
+	return scanner.Err()
+}
+
+// answer asks question with history as context, records the turn in session
+// when there is one, and prints the answer.
+func (c *AskCmd) answer(deps *Dependencies, project *locdoc.Project, session *locdoc.Session, history []locdoc.Turn, question string) (locdoc.Turn, error) {
+	result, err := deps.Asker.Ask(deps.Ctx, project.ID, locdoc.QuestionWithHistory(history, question))
+	if err != nil {
+		// A project crawled without embeddings has nothing to search
+		if flag := c.embedFlag(); flag != "" && locdoc.ErrorCode(err) == locdoc.ENOTFOUND {
+			return locdoc.Turn{}, locdoc.Errorf(locdoc.ENOTFOUND, "%s; re-crawl it with 'locdoc add %s' to store embeddings",
+				locdoc.ErrorMessage(err), flag)
+		}
+		return locdoc.Turn{}, err
+	}
+
+	turn := locdoc.Turn{Question: question, Answer: result.Answer}
+	if session != nil {
+		if err := deps.Sessions.AppendTurn(deps.Ctx, session.ID, turn); err != nil {
+			return locdoc.Turn{}, err
+		}
+	}
+
+	c.printAnswer(deps, result)
+	return turn, nil
+}
+
+// embedFlag returns the add flag that stores the embeddings searched by
+// this command, or "" when vector search is off.
+func (c *AskCmd) embedFlag() string {
+	switch {
+	case c.Embed:
+		return "--embed"
+	case c.EmbedModel != "":
+		return "--embed-model " + c.EmbedModel
+	default:
+		return ""
+	}
+}
+
+// printAnswer writes an answer and, depending on the flags, its sources.
+func (c *AskCmd) printAnswer(deps *Dependencies, result *locdoc.AskResult) {
+	fmt.Fprintln(deps.Stdout, result.Answer)
+
+	if c.ShowSources && len(result.CitedDocuments) > 0 {
+		fmt.Fprintln(deps.Stdout, "\nSources:")
+		for _, source := range result.CitedDocuments {
+			fmt.Fprintf(deps.Stdout, "  %s\n", source)
+		}
+	}
+
+	if c.Format == "sources" && len(result.Sources) > 0 {
+		fmt.Fprintln(deps.Stdout, "\nSources:")
+		for i, ref := range result.Sources {
+			url := ref.URL
+			if ref.Anchor != "" {
+				url += "#" + ref.Anchor
+			}
+			title := ref.Title
+			if title == "" {
+				title = url
+			}
+			fmt.Fprintf(deps.Stdout, "  %d. %s\n     %s\n", i+1, title, url)
+		}
+	}
+}
+
+// session returns the session the question belongs to, creating one when
+// --new-session is set. Returns nil when the question is asked on its own.
+func (c *AskCmd) session(deps *Dependencies, project *locdoc.Project) (*locdoc.Session, error) {
+	if c.NewSession {
+		session := &locdoc.Session{ProjectID: project.ID}
+		if err := deps.Sessions.CreateSession(deps.Ctx, session); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(deps.Stderr, "Session: %s\n", session.ID)
+		return session, nil
+	}
+
+	if c.Session == "" {
+		return nil, nil
+	}
+
+	session, err := deps.Sessions.FindSessionByID(deps.Ctx, c.Session)
+	if err != nil {
+		return nil, err
+	}
+	if session.ProjectID != project.ID {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "session %q belongs to a different project", c.Session)
+	}
+	return session, nil
+}
@@ -3,11 +3,17 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/fwojciec/locdoc"
 	main "github.com/fwojciec/locdoc/cmd/locdoc"
 	"github.com/fwojciec/locdoc/mock"
+	"github.com/fwojciec/locdoc/ollama"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,11 +34,11 @@ func TestAskCmd_Run(t *testing.T) {
 		}
 
 		asker := &mock.Asker{
-			AskFn: func(_ context.Context, projectID, question string) (string, error) {
+			AskFn: func(_ context.Context, projectID, question string) (*locdoc.AskResult, error) {
 				if projectID == "proj-123" && question == "What is useState?" {
-					return "useState is a React Hook.", nil
+					return &locdoc.AskResult{Answer: "useState is a React Hook."}, nil
 				}
-				return "", nil
+				return &locdoc.AskResult{}, nil
 			},
 		}
 
@@ -51,4 +57,504 @@ func TestAskCmd_Run(t *testing.T) {
 		require.NoError(t, err)
 		assert.Contains(t, stdout.String(), "useState is a React Hook.")
 	})
+
+	t.Run("prints cited sources with --show-sources", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, _ string) (*locdoc.AskResult, error) {
+				return &locdoc.AskResult{
+					Answer:         "useState is a React Hook.",
+					CitedDocuments: []string{"https://react.dev/reference/react/useState"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "What is useState?", ShowSources: true}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "useState is a React Hook.\n\nSources:\n  https://react.dev/reference/react/useState\n")
+	})
+
+	t.Run("prints numbered sources with --format sources", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, _ string) (*locdoc.AskResult, error) {
+				return &locdoc.AskResult{
+					Answer: "useState is a React Hook.",
+					Sources: []locdoc.SourceRef{
+						{Title: "useState", URL: "https://react.dev/reference/react/useState", Anchor: "usage"},
+						{Title: "Hooks", URL: "https://react.dev/reference/react/hooks"},
+					},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "What is useState?", Format: "sources"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "useState is a React Hook.\n\nSources:\n"+
+			"  1. useState\n     https://react.dev/reference/react/useState#usage\n"+
+			"  2. Hooks\n     https://react.dev/reference/react/hooks\n")
+	})
+
+	t.Run("omits sources without --show-sources", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, _ string) (*locdoc.AskResult, error) {
+				return &locdoc.AskResult{
+					Answer:         "useState is a React Hook.",
+					CitedDocuments: []string{"https://react.dev/reference/react/useState"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "What is useState?"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.NotContains(t, stdout.String(), "Sources:")
+	})
+
+	t.Run("suggests re-crawling when the project has no embeddings", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, _ string) (*locdoc.AskResult, error) {
+				return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents with embeddings found for project %q", "proj-123")
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   stderr,
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "What is useState?", EmbedModel: "nomic-embed-text"}
+		err := cmd.Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, stderr.String(), "re-crawl it with 'locdoc add --embed-model nomic-embed-text'")
+	})
+}
+
+func TestAskCmd_Run_Session(t *testing.T) {
+	t.Parallel()
+
+	projects := &mock.ProjectService{
+		FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+			return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+		},
+	}
+
+	t.Run("starts a new session with --new-session", func(t *testing.T) {
+		t.Parallel()
+
+		var appended []locdoc.Turn
+		sessions := &mock.SessionService{
+			CreateSessionFn: func(_ context.Context, session *locdoc.Session) error {
+				session.ID = "sess-1"
+				return nil
+			},
+			AppendTurnFn: func(_ context.Context, id string, turn locdoc.Turn) error {
+				assert.Equal(t, "sess-1", id)
+				appended = append(appended, turn)
+				return nil
+			},
+		}
+
+		var asked string
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, question string) (*locdoc.AskResult, error) {
+				asked = question
+				return &locdoc.AskResult{Answer: "useState is a React Hook."}, nil
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   stderr,
+			Projects: projects,
+			Sessions: sessions,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "What is useState?", NewSession: true}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "Session: sess-1")
+		assert.Equal(t, "What is useState?", asked)
+		assert.Equal(t, []locdoc.Turn{{Question: "What is useState?", Answer: "useState is a React Hook."}}, appended)
+	})
+
+	t.Run("includes earlier turns with --session", func(t *testing.T) {
+		t.Parallel()
+
+		var appended []locdoc.Turn
+		sessions := &mock.SessionService{
+			FindSessionByIDFn: func(_ context.Context, id string) (*locdoc.Session, error) {
+				return &locdoc.Session{
+					ID:        id,
+					ProjectID: "proj-123",
+					Turns:     []locdoc.Turn{{Question: "What is useState?", Answer: "useState is a React Hook."}},
+				}, nil
+			},
+			AppendTurnFn: func(_ context.Context, _ string, turn locdoc.Turn) error {
+				appended = append(appended, turn)
+				return nil
+			},
+		}
+
+		var asked string
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, question string) (*locdoc.AskResult, error) {
+				asked = question
+				return &locdoc.AskResult{Answer: "Pass the initial state."}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Sessions: sessions,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "How do I initialize it?", Session: "sess-1"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, asked, "useState is a React Hook.")
+		assert.Contains(t, asked, "How do I initialize it?")
+		assert.Equal(t, []locdoc.Turn{{Question: "How do I initialize it?", Answer: "Pass the initial state."}}, appended)
+	})
+
+	t.Run("rejects a session from another project", func(t *testing.T) {
+		t.Parallel()
+
+		sessions := &mock.SessionService{
+			FindSessionByIDFn: func(_ context.Context, id string) (*locdoc.Session, error) {
+				return &locdoc.Session{ID: id, ProjectID: "proj-other"}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Sessions: sessions,
+			Asker:    &mock.Asker{},
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Question: "q", Session: "sess-1"}
+		err := cmd.Run(deps)
+
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+}
+
+func TestAskCmd_Run_Interactive(t *testing.T) {
+	t.Parallel()
+
+	projects := &mock.ProjectService{
+		FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+			return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+		},
+	}
+
+	t.Run("answers each line with earlier turns as context", func(t *testing.T) {
+		t.Parallel()
+
+		var asked []string
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, question string) (*locdoc.AskResult, error) {
+				asked = append(asked, question)
+				return &locdoc.AskResult{Answer: fmt.Sprintf("answer %d", len(asked))}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdin:    bytes.NewBufferString("What is useState?\n\nHow do I initialize it?\n"),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		require.Len(t, asked, 2, "blank lines should be skipped")
+		assert.Equal(t, "What is useState?", asked[0])
+		assert.Contains(t, asked[1], "Question: What is useState?\nAnswer: answer 1")
+		assert.Contains(t, asked[1], "Follow-up question: How do I initialize it?")
+		assert.Equal(t, "> answer 1\n> > answer 2\n> \n", stdout.String())
+	})
+
+	t.Run("stops at exit", func(t *testing.T) {
+		t.Parallel()
+
+		var asked []string
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, question string) (*locdoc.AskResult, error) {
+				asked = append(asked, question)
+				return &locdoc.AskResult{Answer: "answer"}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdin:    bytes.NewBufferString("first\nexit\nsecond\n"),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Interactive: true}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first"}, asked)
+	})
+
+	t.Run("reports failed questions and keeps going", func(t *testing.T) {
+		t.Parallel()
+
+		var asked []string
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, question string) (*locdoc.AskResult, error) {
+				asked = append(asked, question)
+				if question == "bad" {
+					return nil, locdoc.Errorf(locdoc.EINTERNAL, "model unavailable")
+				}
+				return &locdoc.AskResult{Answer: "answer"}, nil
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdin:    bytes.NewBufferString("bad\ngood\n"),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   stderr,
+			Projects: projects,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bad", "good"}, asked, "failed turn should not become history")
+		assert.Contains(t, stderr.String(), "error: model unavailable")
+	})
+
+	t.Run("records each turn in the session", func(t *testing.T) {
+		t.Parallel()
+
+		var appended []locdoc.Turn
+		sessions := &mock.SessionService{
+			FindSessionByIDFn: func(_ context.Context, id string) (*locdoc.Session, error) {
+				return &locdoc.Session{ID: id, ProjectID: "proj-123"}, nil
+			},
+			AppendTurnFn: func(_ context.Context, _ string, turn locdoc.Turn) error {
+				appended = append(appended, turn)
+				return nil
+			},
+		}
+		asker := &mock.Asker{
+			AskFn: func(_ context.Context, _, _ string) (*locdoc.AskResult, error) {
+				return &locdoc.AskResult{Answer: "answer"}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdin:    bytes.NewBufferString("first\nsecond\n"),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Sessions: sessions,
+			Asker:    asker,
+		}
+
+		cmd := &main.AskCmd{Name: "react-docs", Session: "sess-1"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, []locdoc.Turn{
+			{Question: "first", Answer: "answer"},
+			{Question: "second", Answer: "answer"},
+		}, appended)
+	})
+}
+
+func TestAskCmd_NewAsker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates an Ollama asker for the ollama provider", func(t *testing.T) {
+		t.Parallel()
+
+		var model string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/chat", r.URL.Path)
+			var body struct {
+				Model string `json:"model"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			model = body.Model
+			_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"Hello from Ollama."},"done":true}`))
+		}))
+		defer srv.Close()
+
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{{SourceURL: "https://example.com/intro", Content: "Hello."}}, nil
+			},
+		}
+		cmd := &main.AskCmd{Provider: "ollama", Model: "qwen3", OllamaURL: srv.URL}
+
+		asker, err := cmd.NewAsker(context.Background(), docs, &bytes.Buffer{})
+
+		require.NoError(t, err)
+		require.IsType(t, &ollama.Asker{}, asker)
+		result, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello from Ollama.", result.Answer)
+		assert.Equal(t, "qwen3", model)
+	})
+
+	t.Run("accepts an Ollama host without a scheme", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"ok"},"done":true}`))
+		}))
+		defer srv.Close()
+
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{{SourceURL: "https://example.com/intro", Content: "Hello."}}, nil
+			},
+		}
+		cmd := &main.AskCmd{Provider: "ollama", OllamaURL: strings.TrimPrefix(srv.URL, "http://")}
+
+		asker, err := cmd.NewAsker(context.Background(), docs, &bytes.Buffer{})
+
+		require.NoError(t, err)
+		_, err = asker.Ask(context.Background(), "proj-1", "q")
+		require.NoError(t, err)
+	})
+
+	t.Run("selects nearest documents with an embedding model", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/embed" {
+				_, _ = w.Write([]byte(`{"embeddings":[[0.5,0.5]]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"ok"},"done":true}`))
+		}))
+		defer srv.Close()
+
+		var filter locdoc.DocumentFilter
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, f locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				filter = f
+				return []*locdoc.Document{{SourceURL: "https://example.com/intro", Content: "Hello."}}, nil
+			},
+		}
+		cmd := &main.AskCmd{Provider: "ollama", OllamaURL: srv.URL, EmbedModel: "nomic-embed-text", Nearest: 3}
+
+		asker, err := cmd.NewAsker(context.Background(), docs, &bytes.Buffer{})
+
+		require.NoError(t, err)
+		_, err = asker.Ask(context.Background(), "proj-1", "q")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{0.5, 0.5}, filter.NearVector)
+		assert.Equal(t, 3, filter.Limit)
+	})
+
+	t.Run("rejects an unknown provider", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AskCmd{Provider: "claude"}
+
+		_, err := cmd.NewAsker(context.Background(), &mock.DocumentService{}, &bytes.Buffer{})
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), `unknown provider "claude"`)
+	})
 }
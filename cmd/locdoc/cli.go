@@ -2,51 +2,317 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/andybalholm/cascadia"
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/crawl"
+	lochttp "github.com/fwojciec/locdoc/http"
 	"github.com/fwojciec/locdoc/sqlite"
 )
 
 // Dependencies holds all services and configuration for command execution.
 type Dependencies struct {
-	Ctx        context.Context
-	Stdout     io.Writer
-	Stderr     io.Writer
-	DB         *sqlite.DB
-	Projects   locdoc.ProjectService
-	Documents  locdoc.DocumentService
-	Sitemaps   locdoc.SitemapService
-	Crawler    *crawl.Crawler
-	Discoverer *crawl.Discoverer
-	Asker      locdoc.Asker
+	Ctx       context.Context
+	Stdin     io.Reader
+	Stdout    io.Writer
+	Stderr    io.Writer
+	DB        *sqlite.DB
+	Projects  locdoc.ProjectService
+	Documents locdoc.DocumentService
+	Sessions  locdoc.SessionService
+	Sitemaps  locdoc.SitemapService
+	Crawler   *crawl.Crawler
+	Asker     locdoc.Asker
+	Metrics   *lochttp.Metrics
+
+	// After waits between crawls in watch mode and while metrics linger after
+	// a crawl. Defaults to time.After.
+	After func(d time.Duration) <-chan time.Time
 }
 
 // CLI defines the command-line interface structure for Kong.
 type CLI struct {
-	Add    AddCmd    `cmd:"" help:"Add and crawl a documentation project"`
-	List   ListCmd   `cmd:"" help:"List all registered projects"`
-	Delete DeleteCmd `cmd:"" help:"Delete a project and its documents"`
-	Docs   DocsCmd   `cmd:"" help:"List documents for a project"`
-	Ask    AskCmd    `cmd:"" help:"Ask a question about project documentation"`
+	DB string `name:"db" type:"path" default:"${db_path=locdoc.db}" help:"SQLite database path (overrides LOCDOC_DB)"`
+
+	Add      AddCmd      `cmd:"" help:"Add and crawl a documentation project"`
+	List     ListCmd     `cmd:"" help:"List all registered projects"`
+	Delete   DeleteCmd   `cmd:"" help:"Delete a project and its documents"`
+	Docs     DocsCmd     `cmd:"" help:"List documents for a project"`
+	Search   SearchCmd   `cmd:"" help:"Search documents in a project"`
+	Ask      AskCmd      `cmd:"" help:"Ask a question about project documentation"`
+	Sessions SessionsCmd `cmd:"" help:"List ask sessions for a project"`
+	Reorder  ReorderCmd  `cmd:"" help:"Move a document to a new position within a project"`
+	Tag      TagCmd      `cmd:"" help:"Add or remove project tags"`
 }
 
 // AddCmd is the "add" subcommand.
 type AddCmd struct {
-	Name        string        `arg:"" help:"Project name"`
-	URL         string        `arg:"" help:"Documentation URL"`
-	Preview     bool          `short:"p" help:"Show URLs without creating project"`
-	Force       bool          `short:"f" help:"Delete existing project first"`
-	Filter      []string      `short:"F" name:"filter" help:"Filter URLs by regex (repeatable)"`
-	Concurrency int           `short:"c" default:"3" help:"Concurrent fetch limit"`
-	Timeout     time.Duration `short:"t" default:"10s" help:"Fetch timeout per page"`
-	Debug       bool          `short:"d" help:"Show debug information"`
+	Name           string        `arg:"" help:"Project name"`
+	URL            string        `arg:"" help:"Documentation URL"`
+	Preview        bool          `short:"p" help:"Show URLs without creating project"`
+	PreviewLimit   int           `name:"preview-limit" default:"100" help:"Maximum number of URLs listed by --preview (0 for no limit)"`
+	DryRun         bool          `name:"dry-run" help:"Crawl without saving anything and list the pages that would be saved"`
+	Force          bool          `short:"f" help:"Delete existing project first"`
+	ReProbe        bool          `name:"re-probe" help:"Detect HTTP vs browser fetching again instead of reusing the cached result"`
+	SkipExisting   bool          `name:"skip-existing" help:"Add to an existing project, skipping unchanged pages and replacing changed ones"`
+	Filter         []string      `short:"F" name:"filter" help:"Filter URLs by regex (repeatable)"`
+	Exclude        []string      `name:"exclude" help:"Exclude URLs matching regex (repeatable)"`
+	IncludeParam   []string      `name:"include-param" sep:"none" help:"Only include URLs with this query parameter, e.g. v=stable (repeatable)"`
+	ExcludeParam   []string      `name:"exclude-param" sep:"none" help:"Exclude URLs with this query parameter, e.g. lang=python (repeatable)"`
+	FilterFile     string        `name:"filter-file" type:"existingfile" help:"Read filter patterns from file (+include, -exclude per line)"`
+	Concurrency    int           `short:"c" default:"3" help:"Concurrent fetch limit. High values can trigger rate limiting on the target server"`
+	MaxConcurrency int           `name:"max-concurrency" env:"LOCDOC_MAX_CONCURRENCY" default:"50" help:"Upper bound for --concurrency"`
+	Browsers       int           `name:"browsers" default:"3" help:"Maximum number of Chrome instances for pages that need a browser (1 to 8, each holds one page at a time)"`
+	Depth          int           `name:"depth" help:"Only follow links this many steps from the URL during recursive crawls (0 for no limit, remembered by the project)"`
+	FrontierSize   int           `name:"frontier-size" default:"10000" help:"Maximum number of queued URLs during recursive crawls (lowest-priority links are dropped, 0 for no limit)"`
+	MaxBytes       ByteSize      `name:"max-bytes" help:"Stop crawling once this much content is saved, e.g. 100MB or 1GB (0 for no limit)"`
+	ConnectTimeout time.Duration `name:"connect-timeout" default:"5s" help:"Time limit for connecting to the server"`
+	ReadTimeout    time.Duration `name:"read-timeout" default:"30s" help:"Time limit for receiving a page once connected"`
+	Timeout        time.Duration `short:"t" hidden:"" help:"Deprecated: use --read-timeout"`
+	RetryBase      time.Duration `name:"retry-base" default:"1s" help:"Delay before the first fetch retry"`
+	RetryCount     int           `name:"retry-count" default:"3" help:"Number of fetch retries (0 to disable)"`
+	RetryFactor    float64       `name:"retry-factor" default:"2.0" help:"Multiplier applied to the retry delay after each attempt"`
+	RetryBrowser   bool          `name:"retry-browser" help:"Retry pages that fail over HTTP once with the browser fetcher"`
+	CustomSelector string        `name:"selector" help:"CSS selector for the main content when automatic extraction picks the wrong part of the page (first match is used)"`
+	MinContent     int           `name:"min-content" default:"100" help:"Skip pages with less extracted content (bytes, 0 to disable)"`
+	MinQuality     float64       `name:"min-quality" default:"0.1" help:"Skip pages whose content quality score (0 to 1) is below this value (0 to disable)"`
+	PreserveTables bool          `name:"preserve-tables" default:"true" negatable:"" help:"Keep tables as Markdown pipe tables, as earlier versions always did (--no-preserve-tables flattens them to text)"`
+	CodeLanguage   bool          `name:"code-language" default:"true" negatable:"" help:"Tag code fences with the language from the HTML class (--no-code-language leaves them untagged)"`
+	Language       string        `name:"language" help:"Only save pages declaring this language, e.g. en (pages without a declared language are kept)"`
+	ProgressJSON   bool          `name:"progress-json" help:"Write progress as JSON lines to stdout (default when stdout is not a terminal)"`
+	MetricsAddr    string        `name:"metrics-addr" help:"Serve Prometheus metrics at this address during the crawl (e.g. :9090)"`
+	MetricsLinger  time.Duration `name:"metrics-linger" help:"Keep serving metrics this long after the crawl finishes, so a final scrape sees the totals"`
+	LogFile        string        `name:"log-file" type:"path" help:"Append JSON crawl logs to this file"`
+	OnComplete     string        `name:"on-complete" help:"Shell command to run after a successful crawl (receives LOCDOC_* env vars)"`
+	Webhook        string        `name:"webhook" help:"POST a JSON summary to this URL after each crawl (remembered by the project)"`
+	Tag            []string      `name:"tag" help:"Tag the project with this label (repeatable)"`
+	StoreExtracted bool          `name:"store-extracted-html" help:"Keep each page's extracted HTML for 'locdoc docs --extracted-html'"`
+	Embed          bool          `name:"embed" help:"Store an embedding of each page, computed with the Gemini Embeddings API (requires GEMINI_API_KEY), for 'locdoc ask --embed'"`
+	EmbedModel     string        `name:"embed-model" env:"LOCDOC_EMBED_MODEL" help:"Ollama embedding model used to store page embeddings for 'locdoc ask --embed-model', e.g. nomic-embed-text"`
+	OllamaURL      string        `name:"ollama-url" env:"OLLAMA_HOST" default:"http://localhost:11434" help:"Address of the Ollama server used by --embed-model"`
+	Watch          bool          `name:"watch" help:"Keep re-crawling on a schedule until interrupted"`
+	Interval       time.Duration `name:"interval" default:"1h" help:"Time between re-crawls in --watch mode"`
+	Cookie         []string      `name:"cookie" sep:"none" help:"Cookie to send, e.g. \"session=abc; Domain=example.com\"; defaults to the URL's host (repeatable)"`
+	Stealth        bool          `name:"stealth" help:"Hide headless browser fingerprints from anti-bot scripts"`
+	AllowDomain    []string      `name:"allow-domain" help:"Only follow links to this host during recursive crawls (repeatable)"`
+	BlockDomain    []string      `name:"block-domain" help:"Never follow links to this host during recursive crawls (repeatable)"`
+	Debug          bool          `short:"d" help:"Show debug information"`
+}
+
+// maxBrowsers caps --browsers. Each browser is a full Chrome process, so
+// unlike fetch concurrency the limit is set by memory on the local machine.
+const maxBrowsers = 8
+
+// Validate is called by Kong after parsing to check flag values.
+func (c *AddCmd) Validate() error {
+	if c.Concurrency < 1 || c.Concurrency > c.MaxConcurrency {
+		return fmt.Errorf("concurrency must be between 1 and %d", c.MaxConcurrency)
+	}
+	if c.Browsers < 1 || c.Browsers > maxBrowsers {
+		return fmt.Errorf("browsers must be between 1 and %d", maxBrowsers)
+	}
+	if c.Depth < 0 {
+		return fmt.Errorf("depth must not be negative")
+	}
+	if c.PreviewLimit < 0 {
+		return fmt.Errorf("preview-limit must not be negative")
+	}
+	if c.MetricsLinger < 0 {
+		return fmt.Errorf("metrics-linger must not be negative")
+	}
+	// --timeout predates the split into connect and read timeouts and
+	// bounded receiving the page
+	if c.Timeout > 0 {
+		c.ReadTimeout = c.Timeout
+	}
+	if c.FrontierSize < 0 {
+		return fmt.Errorf("frontier-size must not be negative")
+	}
+	if c.MinQuality < 0 || c.MinQuality > 1 {
+		return fmt.Errorf("min-quality must be between 0 and 1")
+	}
+	if c.RetryCount < 0 {
+		return fmt.Errorf("retry-count must not be negative")
+	}
+	if c.RetryFactor < 1 {
+		return fmt.Errorf("retry-factor must be at least 1")
+	}
+	if c.Watch && c.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if c.SkipExisting && c.Force {
+		return fmt.Errorf("--skip-existing cannot be combined with --force")
+	}
+	if c.Watch && c.Preview {
+		return fmt.Errorf("--watch cannot be combined with --preview")
+	}
+	// Re-crawls replace the project's documents wholesale, which needs the
+	// previous rows to stay untouched until the new crawl succeeds
+	if c.Watch && c.SkipExisting {
+		return fmt.Errorf("--watch cannot be combined with --skip-existing")
+	}
+	if c.DryRun && (c.Watch || c.Preview) {
+		return fmt.Errorf("--dry-run cannot be combined with --watch or --preview")
+	}
+	if c.Embed && c.EmbedModel != "" {
+		return fmt.Errorf("--embed cannot be combined with --embed-model")
+	}
+	// goquery treats an invalid selector as matching nothing, which would
+	// silently fall back to automatic extraction on every page
+	if c.CustomSelector != "" {
+		if _, err := cascadia.Compile(c.CustomSelector); err != nil {
+			return fmt.Errorf("invalid selector %q: %w", c.CustomSelector, err)
+		}
+	}
+	if c.Webhook != "" {
+		u, err := url.Parse(c.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// URLFilter builds the URL filter from --filter-file, --filter, --exclude,
+// --include-param and --exclude-param. File patterns are applied first,
+// followed by the command-line patterns. Returns nil when no patterns were
+// given.
+func (c *AddCmd) URLFilter() (*locdoc.URLFilter, error) {
+	if c.FilterFile == "" && len(c.Filter) == 0 && len(c.Exclude) == 0 &&
+		len(c.IncludeParam) == 0 && len(c.ExcludeParam) == 0 {
+		return nil, nil
+	}
+
+	filter := &locdoc.URLFilter{}
+	if c.FilterFile != "" {
+		var err error
+		filter, err = locdoc.URLFilterFromFile(c.FilterFile)
+		switch {
+		case locdoc.ErrorCode(err) == locdoc.EINVALID:
+			return nil, locdoc.Errorf(locdoc.EINVALID, "%s: %s", c.FilterFile, locdoc.ErrorMessage(err))
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, locdoc.Errorf(locdoc.ENOTFOUND, "filter file not found: %s", c.FilterFile)
+		case err != nil:
+			return nil, fmt.Errorf("read filter file: %w", err)
+		}
+	}
+
+	for _, pattern := range c.Filter {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, locdoc.Errorf(locdoc.EINVALID, "invalid regex filter pattern %q: %v", pattern, err)
+		}
+		filter.Include = append(filter.Include, re)
+	}
+	for _, pattern := range c.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, locdoc.Errorf(locdoc.EINVALID, "invalid regex exclude pattern %q: %v", pattern, err)
+		}
+		filter.Exclude = append(filter.Exclude, re)
+	}
+	for _, param := range c.IncludeParam {
+		key, value, err := locdoc.ParseQueryParam(param)
+		if err != nil {
+			return nil, err
+		}
+		if filter.IncludeQueryParams == nil {
+			filter.IncludeQueryParams = make(map[string]string)
+		}
+		filter.IncludeQueryParams[key] = value
+	}
+	for _, param := range c.ExcludeParam {
+		key, value, err := locdoc.ParseQueryParam(param)
+		if err != nil {
+			return nil, err
+		}
+		if filter.ExcludeQueryParams == nil {
+			filter.ExcludeQueryParams = make(map[string]string)
+		}
+		filter.ExcludeQueryParams[key] = value
+	}
+	return filter, nil
+}
+
+// Cookies parses the --cookie flags. Each value uses Set-Cookie syntax so
+// attributes such as Domain and Path can scope the cookie. A cookie without
+// a Domain is scoped to the host of the project URL, so session cookies are
+// not leaked to other hosts the crawl reaches.
+func (c *AddCmd) Cookies() ([]*http.Cookie, error) {
+	var host string
+	if u, err := url.Parse(c.URL); err == nil {
+		host = u.Hostname()
+	}
+
+	cookies := make([]*http.Cookie, 0, len(c.Cookie))
+	for _, raw := range c.Cookie {
+		cookie, err := http.ParseSetCookie(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cookie %q: %w", raw, err)
+		}
+		if cookie.Domain == "" {
+			cookie.Domain = host
+		}
+		cookies = append(cookies, cookie)
+	}
+	return cookies, nil
+}
+
+// ByteSize is a size in bytes that parses human-readable values such as
+// 512KB, 100MB or 1.5GB. Units are powers of 1024, are case-insensitive and
+// may omit the trailing B; a bare number is a count of bytes.
+type ByteSize int64
+
+// byteUnit is a size suffix and its multiplier.
+type byteUnit struct {
+	suffix string
+	size   float64
+}
+
+// byteUnits returns the size suffixes and their multipliers, longest suffix
+// first.
+func byteUnits() []byteUnit {
+	return []byteUnit{
+		{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"tb", 1 << 40},
+		{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30}, {"t", 1 << 40},
+		{"b", 1},
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so Kong can parse flags.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	multiplier := 1.0
+	for _, u := range byteUnits() {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			multiplier = u.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid size %q: use a number of bytes or a value like 100MB", string(text))
+	}
+	*b = ByteSize(n * multiplier)
+	return nil
 }
 
 // ListCmd is the "list" subcommand.
-type ListCmd struct{}
+type ListCmd struct {
+	Sort string `name:"sort" enum:"created,name,docs" default:"created" help:"Sort projects by ${enum}"`
+	Tag  string `name:"tag" help:"Only list projects with this tag"`
+}
 
 // DeleteCmd is the "delete" subcommand.
 type DeleteCmd struct {
@@ -56,12 +322,112 @@ type DeleteCmd struct {
 
 // DocsCmd is the "docs" subcommand.
 type DocsCmd struct {
+	Name   string `arg:"" help:"Project name"`
+	Full   bool   `help:"Show full document content"`
+	HTML   bool   `name:"extracted-html" help:"Show the extracted HTML stored by 'locdoc add --store-extracted-html'"`
+	Recent bool   `help:"List the most recently fetched documents first"`
+	Limit  int    `name:"limit" help:"List at most this many documents (0 for all)"`
+	Page   string `name:"page" help:"Continue the listing from this cursor, printed at the end of the previous page"`
+
+	URLPrefix    string `name:"url-prefix" help:"Only list documents whose URL starts with this prefix; a prefix starting with / matches the URL path"`
+	FromPosition *int   `name:"from-position" aliases:"from" help:"Only list documents at this position or later (0-based, as set by 'locdoc reorder')"`
+	ToPosition   *int   `name:"to-position" aliases:"to" help:"Only list documents at this position or earlier (0-based)"`
+}
+
+// Validate is called by Kong after parsing to check flag values.
+func (c *DocsCmd) Validate() error {
+	if c.Limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	if c.Page != "" && c.Recent {
+		return fmt.Errorf("--page cannot be combined with --recent")
+	}
+	if c.HTML && c.Full {
+		return fmt.Errorf("--extracted-html cannot be combined with --full")
+	}
+	if (c.FromPosition != nil && *c.FromPosition < 0) || (c.ToPosition != nil && *c.ToPosition < 0) {
+		return fmt.Errorf("positions must not be negative")
+	}
+	if c.FromPosition != nil && c.ToPosition != nil && *c.FromPosition > *c.ToPosition {
+		return fmt.Errorf("--from-position must not be greater than --to-position")
+	}
+	return nil
+}
+
+// ReorderCmd is the "reorder" subcommand.
+type ReorderCmd struct {
+	Name       string `arg:"" help:"Project name"`
+	Move       string `name:"move" required:"" help:"Source URL of the document to move"`
+	ToPosition int    `name:"to-position" required:"" help:"New position of the document"`
+}
+
+// TagCmd is the "tag" subcommand.
+type TagCmd struct {
+	Add    TagAddCmd    `cmd:"" help:"Add a tag to a project"`
+	Remove TagRemoveCmd `cmd:"" help:"Remove a tag from a project"`
+}
+
+// TagAddCmd is the "tag add" subcommand.
+type TagAddCmd struct {
 	Name string `arg:"" help:"Project name"`
-	Full bool   `help:"Show full document content"`
+	Tag  string `arg:"" help:"Tag to add"`
+}
+
+// TagRemoveCmd is the "tag remove" subcommand.
+type TagRemoveCmd struct {
+	Name string `arg:"" help:"Project name"`
+	Tag  string `arg:"" help:"Tag to remove"`
+}
+
+// SearchCmd is the "search" subcommand.
+type SearchCmd struct {
+	Name    string `arg:"" help:"Project name"`
+	Query   string `arg:"" help:"Search terms"`
+	Limit   int    `short:"n" default:"10" help:"Maximum number of results"`
+	Verbose bool   `short:"v" help:"Show relevance scores"`
 }
 
 // AskCmd is the "ask" subcommand.
 type AskCmd struct {
-	Name     string `arg:"" help:"Project name"`
-	Question string `arg:"" help:"Question to ask about the documentation"`
+	Name             string   `arg:"" help:"Project name"`
+	Question         string   `arg:"" optional:"" help:"Question to ask about the documentation (omit to ask interactively)"`
+	Interactive      bool     `short:"i" name:"interactive" help:"Ask follow-up questions one per line until end of input or \"exit\""`
+	Provider         string   `name:"provider" enum:"gemini,openai,ollama" default:"gemini" env:"LOCDOC_PROVIDER" help:"AI provider that answers the question (${enum})"`
+	Model            string   `name:"model" help:"Model to answer with; defaults depend on --provider"`
+	OllamaURL        string   `name:"ollama-url" env:"OLLAMA_HOST" default:"http://localhost:11434" help:"Address of the Ollama server"`
+	OpenAIModel      string   `name:"openai-model" help:"Deprecated: use --provider openai --model"`
+	ShowSources      bool     `name:"show-sources" help:"List the documents cited in the answer"`
+	Format           string   `name:"format" enum:"text,sources" default:"text" help:"Output format (${enum}); sources adds a numbered list of cited documents and sections"`
+	ResponseLanguage string   `name:"language" help:"Answer in this language (e.g. French), translating documentation excerpts as needed"`
+	ExcludeDoc       []string `name:"exclude-doc" sep:"none" help:"Leave the document with this URL out of the context (repeatable)"`
+	Embed            bool     `name:"embed" help:"Send only the documents most similar to the question, using the Gemini embeddings stored by 'locdoc add --embed'"`
+	EmbedModel       string   `name:"embed-model" env:"LOCDOC_EMBED_MODEL" help:"Ollama embedding model used at crawl time; only the documents most similar to the question are sent"`
+	Nearest          int      `name:"nearest" default:"10" help:"Number of documents sent with --embed or --embed-model"`
+	Session          string   `name:"session" help:"Continue the ask session with this ID, including its earlier questions and answers"`
+	NewSession       bool     `name:"new-session" help:"Start a new ask session and print its ID"`
+}
+
+// Validate is called by Kong after parsing to check flag values.
+func (c *AskCmd) Validate() error {
+	if c.Session != "" && c.NewSession {
+		return fmt.Errorf("--session cannot be combined with --new-session")
+	}
+	if c.OpenAIModel != "" && (c.Model != "" || c.Provider == "ollama") {
+		return fmt.Errorf("--openai-model cannot be combined with --model or --provider ollama")
+	}
+	if c.Embed && c.EmbedModel != "" {
+		return fmt.Errorf("--embed cannot be combined with --embed-model")
+	}
+	if c.Nearest < 1 {
+		return fmt.Errorf("nearest must be at least 1")
+	}
+	if c.Interactive && c.Question != "" {
+		return fmt.Errorf("--interactive reads questions from stdin and cannot be combined with a question argument")
+	}
+	return nil
+}
+
+// SessionsCmd is the "sessions" subcommand.
+type SessionsCmd struct {
+	Name string `arg:"" help:"Project name"`
 }
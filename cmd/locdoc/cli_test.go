@@ -3,12 +3,17 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/alecthomas/kong"
+	"github.com/fwojciec/locdoc"
 	main "github.com/fwojciec/locdoc/cmd/locdoc"
+	lochttp "github.com/fwojciec/locdoc/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,12 +31,13 @@ func TestAddCmd_TimeoutFlag(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	// Parse add command with --timeout flag
-	_, err = parser.Parse([]string{"add", "--timeout", "30s", "myproject", "https://example.com"})
+	// Parse add command with --connect-timeout and --read-timeout flags
+	_, err = parser.Parse([]string{"add", "--connect-timeout", "2s", "--read-timeout", "1m", "myproject", "https://example.com"})
 	require.NoError(t, err)
 
-	// Verify the timeout was parsed correctly
-	assert.Equal(t, 30*time.Second, cli.Add.Timeout)
+	// Verify the timeouts were parsed correctly
+	assert.Equal(t, 2*time.Second, cli.Add.ConnectTimeout)
+	assert.Equal(t, time.Minute, cli.Add.ReadTimeout)
 }
 
 func TestAddCmd_TimeoutFlagDefault(t *testing.T) {
@@ -47,12 +53,179 @@ func TestAddCmd_TimeoutFlagDefault(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	// Parse add command without --timeout flag
+	// Parse add command without timeout flags
 	_, err = parser.Parse([]string{"add", "myproject", "https://example.com"})
 	require.NoError(t, err)
 
-	// Verify the default timeout is 10 seconds
-	assert.Equal(t, 10*time.Second, cli.Add.Timeout)
+	// Verify the default timeouts are 5s to connect and 30s to read
+	assert.Equal(t, 5*time.Second, cli.Add.ConnectTimeout)
+	assert.Equal(t, 30*time.Second, cli.Add.ReadTimeout)
+}
+
+func TestAddCmd_DeprecatedTimeoutFlag(t *testing.T) {
+	t.Parallel()
+
+	cli := &main.CLI{}
+	parser, err := kong.New(cli,
+		kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+		kong.Exit(func(int) {}),
+	)
+	require.NoError(t, err)
+
+	// -t is the short form of the old --timeout flag
+	_, err = parser.Parse([]string{"add", "-t", "20s", "myproject", "https://example.com"})
+	require.NoError(t, err)
+
+	// The old per-page timeout now sets the read timeout
+	assert.Equal(t, 20*time.Second, cli.Add.ReadTimeout)
+	assert.Equal(t, 5*time.Second, cli.Add.ConnectTimeout)
+}
+
+func TestAddCmd_MaxBytesFlag(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, value string) (*main.CLI, error) {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse([]string{"add", "--max-bytes", value, "myproject", "https://example.com"})
+		return cli, err
+	}
+
+	for _, tc := range []struct {
+		value string
+		want  main.ByteSize
+	}{
+		{"2048", 2048},
+		{"512KB", 512 << 10},
+		{"100MB", 100 << 20},
+		{"1GB", 1 << 30},
+		{"1.5gb", 3 << 29},
+		{"10m", 10 << 20},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			cli, err := parse(t, tc.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, cli.Add.MaxBytes)
+		})
+	}
+
+	t.Run("rejects invalid sizes", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "lots")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid size")
+	})
+}
+
+func TestAddCmd_SelectorFlag(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) (*main.CLI, error) {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"add", "myproject", "https://example.com"}, args...))
+		return cli, err
+	}
+
+	t.Run("defaults to automatic extraction", func(t *testing.T) {
+		t.Parallel()
+
+		cli, err := parse(t)
+
+		require.NoError(t, err)
+		assert.Empty(t, cli.Add.CustomSelector)
+	})
+
+	t.Run("accepts a CSS selector", func(t *testing.T) {
+		t.Parallel()
+
+		cli, err := parse(t, "--selector", "#content")
+
+		require.NoError(t, err)
+		assert.Equal(t, "#content", cli.Add.CustomSelector)
+	})
+
+	t.Run("rejects an invalid CSS selector", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--selector", "div[")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid selector "div["`)
+	})
+}
+
+func TestAddCmd_PreserveTablesFlag(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) *main.CLI {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"add", "myproject", "https://example.com"}, args...))
+		require.NoError(t, err)
+		return cli
+	}
+
+	t.Run("preserves tables by default", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, parse(t).Add.PreserveTables)
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, parse(t, "--no-preserve-tables").Add.PreserveTables)
+	})
+}
+
+func TestAddCmd_CodeLanguageFlag(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) *main.CLI {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"add", "myproject", "https://example.com"}, args...))
+		require.NoError(t, err)
+		return cli
+	}
+
+	t.Run("tags code fences by default", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, parse(t).Add.CodeLanguage)
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, parse(t, "--no-code-language").Add.CodeLanguage)
+	})
 }
 
 func TestCLI_HelpShowsAllCommands(t *testing.T) {
@@ -106,3 +279,407 @@ func TestMain_Run_HelpShowsKongOutput(t *testing.T) {
 	assert.Contains(t, helpOutput, "Usage:", "Help should have Kong-style Usage prefix")
 	assert.Contains(t, helpOutput, "Flags:", "Help should have Kong-style Flags section")
 }
+
+func TestAddCmd_URLFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("appends flag patterns after filter file patterns", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "filters.txt")
+		require.NoError(t, os.WriteFile(path, []byte("+/docs/\n-/docs/v1/\n"), 0o644))
+
+		cmd := &main.AddCmd{
+			FilterFile: path,
+			Filter:     []string{"/guide/"},
+			Exclude:    []string{"/draft/"},
+		}
+
+		filter, err := cmd.URLFilter()
+
+		require.NoError(t, err)
+		require.Len(t, filter.Include, 2)
+		require.Len(t, filter.Exclude, 2)
+		assert.Equal(t, "/docs/", filter.Include[0].String())
+		assert.Equal(t, "/guide/", filter.Include[1].String())
+		assert.Equal(t, "/docs/v1/", filter.Exclude[0].String())
+		assert.Equal(t, "/draft/", filter.Exclude[1].String())
+	})
+
+	t.Run("reports a missing filter file as not found", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{FilterFile: filepath.Join(t.TempDir(), "missing.txt")}
+
+		_, err := cmd.URLFilter()
+
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+
+	t.Run("passes through other filter file read errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Reading a directory fails with an error other than not-exist
+		cmd := &main.AddCmd{FilterFile: t.TempDir()}
+
+		_, err := cmd.URLFilter()
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINTERNAL, locdoc.ErrorCode(err))
+	})
+
+	t.Run("adds query parameter filters", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{
+			IncludeParam: []string{"v=stable"},
+			ExcludeParam: []string{"lang=python"},
+		}
+
+		filter, err := cmd.URLFilter()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"v": "stable"}, filter.IncludeQueryParams)
+		assert.Equal(t, map[string]string{"lang": "python"}, filter.ExcludeQueryParams)
+	})
+
+	t.Run("rejects query parameter filters without a value separator", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&main.AddCmd{IncludeParam: []string{"stable"}}).URLFilter()
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+
+	t.Run("returns nil without patterns", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := (&main.AddCmd{}).URLFilter()
+
+		require.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+}
+
+func TestAddCmd_CookieFlag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses repeatable cookie flags", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+
+		_, err = parser.Parse([]string{"add",
+			"--cookie", "session=abc; Domain=docs.example.com",
+			"--cookie", "theme=dark",
+			"myproject", "https://docs.example.com"})
+		require.NoError(t, err)
+
+		cookies, err := cli.Add.Cookies()
+		require.NoError(t, err)
+		require.Len(t, cookies, 2)
+		assert.Equal(t, "session", cookies[0].Name)
+		assert.Equal(t, "abc", cookies[0].Value)
+		assert.Equal(t, "docs.example.com", cookies[0].Domain)
+		assert.Equal(t, "theme", cookies[1].Name)
+		assert.Equal(t, "docs.example.com", cookies[1].Domain)
+	})
+
+	t.Run("does not send cookie without domain to other hosts", func(t *testing.T) {
+		t.Parallel()
+
+		var sent bool
+		srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			_, err := r.Cookie("session")
+			sent = err == nil
+		}))
+		defer srv.Close()
+
+		cmd := &main.AddCmd{URL: "https://docs.example.com", Cookie: []string{"session=abc"}}
+		cookies, err := cmd.Cookies()
+		require.NoError(t, err)
+
+		_, err = lochttp.NewFetcher(lochttp.WithCookies(cookies)).Fetch(context.Background(), srv.URL)
+
+		require.NoError(t, err)
+		assert.False(t, sent)
+	})
+
+	t.Run("rejects malformed cookie", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{Cookie: []string{"no-equals-sign"}}
+
+		_, err := cmd.Cookies()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cookie")
+	})
+}
+
+func TestAddCmd_ConcurrencyValidation(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) error {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"add", "myproject", "https://example.com"}, args...))
+		return err
+	}
+
+	t.Run("rejects concurrency above default maximum", func(t *testing.T) {
+		t.Parallel()
+
+		err := parse(t, "--concurrency", "51")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "concurrency must be between 1 and 50")
+	})
+
+	t.Run("rejects zero concurrency", func(t *testing.T) {
+		t.Parallel()
+
+		err := parse(t, "--concurrency", "0")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "concurrency must be between 1 and 50")
+	})
+
+	t.Run("accepts concurrency within raised maximum", func(t *testing.T) {
+		t.Parallel()
+
+		err := parse(t, "--concurrency", "51", "--max-concurrency", "100")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects more browsers than the cap", func(t *testing.T) {
+		t.Parallel()
+
+		err := parse(t, "--concurrency", "20", "--browsers", "9")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "browsers must be between 1 and 8")
+	})
+
+	t.Run("rejects min-quality above one", func(t *testing.T) {
+		t.Parallel()
+
+		err := parse(t, "--min-quality", "1.5")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "min-quality must be between 0 and 1")
+	})
+}
+
+func TestAddCmd_WatchValidation(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) (*main.CLI, error) {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"add", "myproject", "https://example.com"}, args...))
+		return cli, err
+	}
+
+	t.Run("defaults interval to one hour", func(t *testing.T) {
+		t.Parallel()
+
+		cli, err := parse(t, "--watch")
+
+		require.NoError(t, err)
+		assert.True(t, cli.Add.Watch)
+		assert.Equal(t, time.Hour, cli.Add.Interval)
+	})
+
+	t.Run("rejects non-positive interval", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--watch", "--interval", "0s")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interval must be positive")
+	})
+
+	t.Run("rejects watch with preview", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--watch", "--preview")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--watch cannot be combined with --preview")
+	})
+
+	t.Run("rejects watch with skip-existing", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--watch", "--skip-existing")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--watch cannot be combined with --skip-existing")
+	})
+}
+
+func TestAddCmd_WebhookValidation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepts an https URL", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{Concurrency: 1, MaxConcurrency: 50, Browsers: 1, RetryFactor: 2, Webhook: "https://hooks.example.com/crawl"}
+
+		assert.NoError(t, cmd.Validate())
+	})
+
+	t.Run("rejects a URL without an http scheme", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{Concurrency: 1, MaxConcurrency: 50, Browsers: 1, RetryFactor: 2, Webhook: "hooks.example.com/crawl"}
+
+		err := cmd.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook must be an http or https URL")
+	})
+}
+
+func TestAddCmd_EmbedValidation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepts --embed", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{Concurrency: 1, MaxConcurrency: 50, Browsers: 1, RetryFactor: 2, Embed: true}
+
+		assert.NoError(t, cmd.Validate())
+	})
+
+	t.Run("rejects --embed with --embed-model", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AddCmd{Concurrency: 1, MaxConcurrency: 50, Browsers: 1, RetryFactor: 2, Embed: true, EmbedModel: "nomic-embed-text"}
+
+		err := cmd.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--embed cannot be combined with --embed-model")
+	})
+}
+
+func TestDocsCmd_PositionFlags(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) (*main.CLI, error) {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"docs", "myproject"}, args...))
+		return cli, err
+	}
+
+	t.Run("parses --from and --to", func(t *testing.T) {
+		t.Parallel()
+
+		cli, err := parse(t, "--from", "5", "--to-position", "10")
+
+		require.NoError(t, err)
+		require.NotNil(t, cli.Docs.FromPosition)
+		require.NotNil(t, cli.Docs.ToPosition)
+		assert.Equal(t, 5, *cli.Docs.FromPosition)
+		assert.Equal(t, 10, *cli.Docs.ToPosition)
+	})
+
+	t.Run("leaves range open when not set", func(t *testing.T) {
+		t.Parallel()
+
+		cli, err := parse(t)
+
+		require.NoError(t, err)
+		assert.Nil(t, cli.Docs.FromPosition)
+		assert.Nil(t, cli.Docs.ToPosition)
+	})
+
+	t.Run("rejects --from greater than --to", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--from", "10", "--to", "5")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--from-position must not be greater than --to-position")
+	})
+}
+
+func TestAskCmd_ProviderFlag(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, args ...string) (*main.CLI, error) {
+		t.Helper()
+		cli := &main.CLI{}
+		parser, err := kong.New(cli,
+			kong.Writers(&bytes.Buffer{}, &bytes.Buffer{}),
+			kong.Exit(func(int) {}),
+		)
+		require.NoError(t, err)
+		_, err = parser.Parse(append([]string{"ask", "myproject", "question"}, args...))
+		return cli, err
+	}
+
+	t.Run("accepts a known provider and model", func(t *testing.T) {
+		t.Parallel()
+
+		cli, err := parse(t, "--provider", "ollama", "--model", "qwen3")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ollama", cli.Ask.Provider)
+		assert.Equal(t, "qwen3", cli.Ask.Model)
+	})
+
+	t.Run("rejects an unknown provider", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--provider", "claude")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--provider must be one of")
+	})
+
+	t.Run("rejects --openai-model with --model", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := &main.AskCmd{Provider: "gemini", OpenAIModel: "gpt-4o", Model: "gpt-4.1"}
+
+		err := cmd.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--openai-model cannot be combined")
+	})
+
+	t.Run("rejects --nearest below 1", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, "--embed", "--nearest", "0")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nearest must be at least 1")
+	})
+}
@@ -21,35 +21,112 @@ func (c *DocsCmd) Run(deps *Dependencies) error {
 
 	project := projects[0]
 
-	docs, err := deps.Documents.FindDocuments(deps.Ctx, locdoc.DocumentFilter{
+	sortBy := locdoc.SortByPosition
+	if c.Recent {
+		sortBy = locdoc.SortByFetchedAt
+	}
+
+	filter := locdoc.DocumentFilter{
 		ProjectID: &project.ID,
-		SortBy:    locdoc.SortByPosition,
-	})
+		SortBy:    sortBy,
+		Limit:     c.Limit,
+	}
+	if c.Page != "" {
+		filter.AfterID = &c.Page
+	}
+	if c.URLPrefix != "" {
+		filter.URLPrefix = &c.URLPrefix
+	}
+	filter.FromPosition = c.FromPosition
+	filter.ToPosition = c.ToPosition
+	ranged := c.FromPosition != nil || c.ToPosition != nil
+
+	docs, err := deps.Documents.FindDocuments(deps.Ctx, filter)
 	if err != nil {
 		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
 		return err
 	}
 
+	if len(docs) == 0 && c.Page != "" {
+		fmt.Fprintln(deps.Stdout, "No more documents.")
+		return nil
+	}
+	if len(docs) == 0 && c.URLPrefix != "" {
+		fmt.Fprintf(deps.Stderr, "error: no documents in %q match URL prefix %q\n", c.Name, c.URLPrefix)
+		return locdoc.Errorf(locdoc.ENOTFOUND, "no documents in %q match URL prefix %q", c.Name, c.URLPrefix)
+	}
+	if len(docs) == 0 && ranged {
+		fmt.Fprintf(deps.Stderr, "error: no documents in %q at positions %s\n", c.Name, c.positionRange())
+		return locdoc.Errorf(locdoc.ENOTFOUND, "no documents in %q at positions %s", c.Name, c.positionRange())
+	}
 	if len(docs) == 0 {
 		fmt.Fprintf(deps.Stderr, "error: project %q has no documents. To re-add, first run 'locdoc delete %s --force', then run 'locdoc add %s <url>'.\n", c.Name, c.Name, c.Name)
 		return locdoc.Errorf(locdoc.ENOTFOUND, "project %q has no documents", c.Name)
 	}
 
-	if c.Full {
+	// A full page may be followed by more; the last ID is the next cursor
+	var next string
+	if c.Limit > 0 && len(docs) == c.Limit && !c.Recent {
+		next = docs[len(docs)-1].ID
+	}
+
+	if c.HTML {
+		for _, doc := range docs {
+			fmt.Fprintf(deps.Stdout, "<!-- %s -->\n", doc.SourceURL)
+			if doc.ExtractedHTML == "" {
+				fmt.Fprintln(deps.Stdout, "<!-- no extracted HTML stored; re-crawl with --store-extracted-html -->")
+				continue
+			}
+			fmt.Fprintln(deps.Stdout, doc.ExtractedHTML)
+		}
+	} else if c.Full {
 		// Print full formatted content (same as what ask sends to LLM)
 		fmt.Fprintln(deps.Stdout, locdoc.FormatDocuments(docs))
-		return nil
+	} else {
+		// Print summary listing
+		if c.Limit > 0 || c.Page != "" || ranged {
+			fmt.Fprintf(deps.Stdout, "Documents for %s (%d shown):\n\n", c.Name, len(docs))
+		} else {
+			fmt.Fprintf(deps.Stdout, "Documents for %s (%d total):\n\n", c.Name, len(docs))
+		}
+		for i, doc := range docs {
+			title := doc.Title
+			if title == "" {
+				title = doc.SourceURL
+			}
+			fmt.Fprintf(deps.Stdout, "  %d. %s\n     %s\n", i+1, title, doc.SourceURL)
+			if doc.Summary != "" {
+				fmt.Fprintf(deps.Stdout, "     %s\n", doc.Summary)
+			}
+		}
 	}
 
-	// Print summary listing
-	fmt.Fprintf(deps.Stdout, "Documents for %s (%d total):\n\n", c.Name, len(docs))
-	for i, doc := range docs {
-		title := doc.Title
-		if title == "" {
-			title = doc.SourceURL
+	if next != "" {
+		prefix := ""
+		if c.URLPrefix != "" {
+			// Quoted so the hint can be pasted into a shell
+			prefix = fmt.Sprintf(" --url-prefix %q", c.URLPrefix)
+		}
+		if c.FromPosition != nil {
+			prefix += fmt.Sprintf(" --from-position %d", *c.FromPosition)
 		}
-		fmt.Fprintf(deps.Stdout, "  %d. %s\n     %s\n", i+1, title, doc.SourceURL)
+		if c.ToPosition != nil {
+			prefix += fmt.Sprintf(" --to-position %d", *c.ToPosition)
+		}
+		fmt.Fprintf(deps.Stderr, "\nNext page: locdoc docs %s%s --limit %d --page %s\n", c.Name, prefix, c.Limit, next)
 	}
-
 	return nil
 }
+
+// positionRange describes the --from-position/--to-position range for
+// messages, e.g. "5-10", "5 and later" or "10 and earlier".
+func (c *DocsCmd) positionRange() string {
+	switch {
+	case c.FromPosition != nil && c.ToPosition != nil:
+		return fmt.Sprintf("%d-%d", *c.FromPosition, *c.ToPosition)
+	case c.FromPosition != nil:
+		return fmt.Sprintf("%d and later", *c.FromPosition)
+	default:
+		return fmt.Sprintf("%d and earlier", *c.ToPosition)
+	}
+}
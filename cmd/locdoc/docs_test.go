@@ -56,6 +56,357 @@ func TestDocsCmd_Run(t *testing.T) {
 		assert.Contains(t, stdout.String(), "Components")
 	})
 
+	t.Run("shows document summaries", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{
+					{ID: "doc-1", Title: "Getting Started", SourceURL: "https://react.dev/docs/getting-started", Summary: "Quick start guide"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		err := (&main.DocsCmd{Name: "react-docs"}).Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "https://react.dev/docs/getting-started\n     Quick start guide\n")
+	})
+
+	t.Run("shows stored extracted HTML with --extracted-html", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{
+					{ID: "doc-1", SourceURL: "https://react.dev/docs/a", ExtractedHTML: "<article>A</article>"},
+					{ID: "doc-2", SourceURL: "https://react.dev/docs/b"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		err := (&main.DocsCmd{Name: "react-docs", HTML: true}).Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "<!-- https://react.dev/docs/a -->\n<article>A</article>\n")
+		assert.Contains(t, stdout.String(), "<!-- https://react.dev/docs/b -->\n<!-- no extracted HTML stored")
+	})
+
+	t.Run("sorts by fetch time with --recent flag", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		var gotFilter locdoc.DocumentFilter
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				gotFilter = filter
+				return []*locdoc.Document{
+					{ID: "doc-1", Title: "Getting Started", SourceURL: "https://react.dev/docs/getting-started"},
+				}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    &bytes.Buffer{},
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.DocsCmd{Name: "react-docs", Recent: true}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, locdoc.SortByFetchedAt, gotFilter.SortBy)
+	})
+
+	t.Run("pages through documents with --limit and --page", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		var gotFilter locdoc.DocumentFilter
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				gotFilter = filter
+				return []*locdoc.Document{
+					{ID: "doc-3", Title: "Hooks", SourceURL: "https://react.dev/docs/hooks"},
+					{ID: "doc-4", Title: "State", SourceURL: "https://react.dev/docs/state"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    stderr,
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.DocsCmd{Name: "react-docs", Limit: 2, Page: "doc-2"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, gotFilter.AfterID)
+		assert.Equal(t, "doc-2", *gotFilter.AfterID)
+		assert.Equal(t, 2, gotFilter.Limit)
+		assert.Equal(t, locdoc.SortByPosition, gotFilter.SortBy)
+		assert.Contains(t, stdout.String(), "Documents for react-docs (2 shown)")
+		assert.Contains(t, stderr.String(), "Next page: locdoc docs react-docs --limit 2 --page doc-4")
+	})
+
+	t.Run("filters by --url-prefix", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		var gotFilter locdoc.DocumentFilter
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				gotFilter = filter
+				return []*locdoc.Document{
+					{ID: "doc-1", Title: "Hooks", SourceURL: "https://react.dev/reference/hooks"},
+					{ID: "doc-2", Title: "State", SourceURL: "https://react.dev/reference/state"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    stderr,
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.DocsCmd{Name: "react-docs", Limit: 2, URLPrefix: "/reference/"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, gotFilter.URLPrefix)
+		assert.Equal(t, "/reference/", *gotFilter.URLPrefix)
+		assert.Contains(t, stderr.String(), `Next page: locdoc docs react-docs --url-prefix "/reference/" --limit 2 --page doc-2`)
+	})
+
+	t.Run("keeps the position range and quoted prefix in the next-page hint", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{
+					{ID: "doc-6", Title: "Hooks", SourceURL: "https://react.dev/api docs/hooks"},
+					{ID: "doc-7", Title: "State", SourceURL: "https://react.dev/api docs/state"},
+				}, nil
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    &bytes.Buffer{},
+			Stderr:    stderr,
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		from, to := 5, 10
+		cmd := &main.DocsCmd{Name: "react-docs", Limit: 2, URLPrefix: "/api docs/", FromPosition: &from, ToPosition: &to}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(),
+			`Next page: locdoc docs react-docs --url-prefix "/api docs/" --from-position 5 --to-position 10 --limit 2 --page doc-7`)
+	})
+
+	t.Run("returns not found when no documents match --url-prefix", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{}, nil
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    &bytes.Buffer{},
+			Stderr:    stderr,
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.DocsCmd{Name: "react-docs", URLPrefix: "/blog/"}
+		err := cmd.Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, stderr.String(), `no documents in "react-docs" match URL prefix "/blog/"`)
+	})
+
+	t.Run("shows full content of a position range", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+
+		var gotFilter locdoc.DocumentFilter
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				gotFilter = filter
+				return []*locdoc.Document{
+					{ID: "doc-6", Title: "Chapter 6", Position: 5, Content: "# Chapter 6"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		from, to := 5, 10
+		cmd := &main.DocsCmd{Name: "react-docs", Full: true, FromPosition: &from, ToPosition: &to}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, gotFilter.FromPosition)
+		require.NotNil(t, gotFilter.ToPosition)
+		assert.Equal(t, 5, *gotFilter.FromPosition)
+		assert.Equal(t, 10, *gotFilter.ToPosition)
+		assert.Contains(t, stdout.String(), "# Chapter 6")
+	})
+
+	t.Run("returns not found when no documents are in the position range", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{}, nil
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    &bytes.Buffer{},
+			Stderr:    stderr,
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		from := 50
+		cmd := &main.DocsCmd{Name: "react-docs", FromPosition: &from}
+		err := cmd.Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, stderr.String(), `no documents in "react-docs" at positions 50 and later`)
+	})
+
+	t.Run("reports the end of a paged listing", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			},
+		}
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return []*locdoc.Document{}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    stderr,
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.DocsCmd{Name: "react-docs", Limit: 2, Page: "doc-4"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, "No more documents.\n", stdout.String())
+		assert.NotContains(t, stderr.String(), "Next page")
+	})
+
 	t.Run("shows full content with --full flag", func(t *testing.T) {
 		t.Parallel()
 
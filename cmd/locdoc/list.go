@@ -2,26 +2,68 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 )
 
 // Run executes the list command.
 func (c *ListCmd) Run(deps *Dependencies) error {
-	projects, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{})
+	filter := locdoc.ProjectFilter{SortBy: locdoc.SortOrder(c.Sort)}
+	if c.Tag != "" {
+		filter.Tag = &c.Tag
+	}
+
+	projects, err := deps.Projects.FindProjects(deps.Ctx, filter)
 	if err != nil {
 		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
 		return err
 	}
 
+	if len(projects) == 0 && c.Tag != "" {
+		fmt.Fprintf(deps.Stdout, "No projects tagged %q.\n", c.Tag)
+		return nil
+	}
 	if len(projects) == 0 {
 		fmt.Fprintln(deps.Stdout, "No projects found. Use 'locdoc add' to create one.")
 		return nil
 	}
 
+	now := time.Now()
 	for _, p := range projects {
-		fmt.Fprintf(deps.Stdout, "%s  %s  %s\n", p.ID, p.Name, p.SourceURL)
+		lastCrawled := "never"
+		if p.LastCrawledAt != nil {
+			lastCrawled = formatAge(now.Sub(*p.LastCrawledAt))
+		}
+		fmt.Fprintf(deps.Stdout, "%s  %s  %s  Last crawled: %s", p.ID, p.Name, p.SourceURL, lastCrawled)
+		if len(p.Tags) > 0 {
+			fmt.Fprintf(deps.Stdout, "  Tags: %s", strings.Join(p.Tags, ", "))
+		}
+		fmt.Fprintln(deps.Stdout)
 	}
 
 	return nil
 }
+
+// formatAge formats an elapsed duration as human-relative time in its
+// largest whole unit, e.g. "2 hours ago".
+func formatAge(d time.Duration) string {
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	for _, u := range units {
+		if n := int(d / u.size); n >= 1 {
+			if n == 1 {
+				return fmt.Sprintf("1 %s ago", u.name)
+			}
+			return fmt.Sprintf("%d %ss ago", n, u.name)
+		}
+	}
+	return "just now"
+}
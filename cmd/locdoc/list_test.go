@@ -48,6 +48,58 @@ func TestListCmd_Run(t *testing.T) {
 		assert.Contains(t, stdout.String(), "https://react.dev/docs")
 	})
 
+	t.Run("passes --sort to the project filter", func(t *testing.T) {
+		t.Parallel()
+
+		var gotFilter locdoc.ProjectFilter
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, filter locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				gotFilter = filter
+				return nil, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+		}
+
+		err := (&main.ListCmd{Sort: "docs"}).Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, locdoc.SortByDocumentCount, gotFilter.SortBy)
+	})
+
+	t.Run("shows when each project was last crawled", func(t *testing.T) {
+		t.Parallel()
+
+		crawledAt := time.Now().Add(-2*time.Hour - 5*time.Minute)
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{
+					{ID: "proj-1", Name: "crawled", SourceURL: "https://a.dev", LastCrawledAt: &crawledAt},
+					{ID: "proj-2", Name: "fresh", SourceURL: "https://b.dev"},
+				}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+		}
+
+		err := (&main.ListCmd{}).Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "proj-1  crawled  https://a.dev  Last crawled: 2 hours ago\n")
+		assert.Contains(t, stdout.String(), "proj-2  fresh  https://b.dev  Last crawled: never\n")
+	})
+
 	t.Run("shows helpful message when no projects exist", func(t *testing.T) {
 		t.Parallel()
 
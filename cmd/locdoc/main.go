@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/alecthomas/kong"
 	"github.com/fwojciec/locdoc"
@@ -15,6 +19,9 @@ import (
 	"github.com/fwojciec/locdoc/goquery"
 	"github.com/fwojciec/locdoc/htmltomarkdown"
 	lochttp "github.com/fwojciec/locdoc/http"
+	"github.com/fwojciec/locdoc/metadata"
+	"github.com/fwojciec/locdoc/ollama"
+	"github.com/fwojciec/locdoc/openai"
 	"github.com/fwojciec/locdoc/readability"
 	"github.com/fwojciec/locdoc/rod"
 	locslog "github.com/fwojciec/locdoc/slog"
@@ -23,12 +30,15 @@ import (
 )
 
 func main() {
-	ctx := context.Background()
+	// Cancel on Ctrl-C or SIGTERM so long-running commands shut down cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	m := NewMain()
 
 	if err := m.Run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		stop()
 		os.Exit(1)
 	}
 }
@@ -41,6 +51,9 @@ type Main struct {
 	// SQLite database used by SQLite service implementations.
 	DB *sqlite.DB
 
+	// Log file opened by OpenLogFile, closed by Close.
+	LogFile *os.File
+
 	// Services for end-to-end testing.
 	ProjectService  locdoc.ProjectService
 	DocumentService locdoc.DocumentService
@@ -55,10 +68,25 @@ func NewMain() *Main {
 
 // Close gracefully stops the program.
 func (m *Main) Close() error {
+	var errs []error
 	if m.DB != nil {
-		return m.DB.Close()
+		errs = append(errs, m.DB.Close())
+	}
+	if m.LogFile != nil {
+		errs = append(errs, m.LogFile.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// OpenLogFile opens path for appending and returns a logger that writes
+// JSON lines to it at debug level. The file is closed by Close.
+func (m *Main) OpenLogFile(path string) (*slog.Logger, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %q: %w", path, err)
 	}
-	return nil
+	m.LogFile = f
+	return slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})), nil
 }
 
 // Run executes the CLI with the given arguments.
@@ -66,6 +94,7 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 	// Initialize dependencies struct for Kong binding
 	deps := &Dependencies{
 		Ctx:    ctx,
+		Stdin:  os.Stdin,
 		Stdout: stdout,
 		Stderr: stderr,
 	}
@@ -77,6 +106,7 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 		kong.Writers(stdout, stderr),
 		kong.Exit(func(int) {}), // Don't exit on help
 		kong.Bind(deps),
+		kong.Vars{"db_path": m.DBPath},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create parser: %w", err)
@@ -100,10 +130,14 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 		return err
 	}
 
+	// The --db flag defaults to DBPath, so it always holds the resolved path
+	m.DBPath = cli.DB
+	cmd = strings.Fields(kongCtx.Command())[0]
+
 	// Open database
 	m.DB = sqlite.NewDB(m.DBPath)
 	if err := m.DB.Open(); err != nil {
-		fmt.Fprintf(stderr, "Hint: Set LOCDOC_DB to use a different database path\n")
+		fmt.Fprintf(stderr, "Hint: Use --db or set LOCDOC_DB to use a different database path\n")
 		return fmt.Errorf("failed to open database at %q: %w", m.DBPath, err)
 	}
 	defer m.Close()
@@ -114,45 +148,87 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 	deps.DB = m.DB
 	deps.Projects = m.ProjectService
 	deps.Documents = m.DocumentService
+	deps.Sessions = sqlite.NewSessionService(m.DB)
 	deps.Sitemaps = lochttp.NewSitemapService(nil)
 
 	// Wire command-specific dependencies based on command
 	if cmd == "add" {
-		rodFetcher, err := rod.NewFetcher(rod.WithFetchTimeout(cli.Add.Timeout))
+		if cli.Add.Timeout > 0 {
+			fmt.Fprintln(stderr, "warning: --timeout is deprecated; use --read-timeout")
+		}
+
+		cookies, err := cli.Add.Cookies()
+		if err != nil {
+			return err
+		}
+
+		rodOpts := []rod.Option{
+			rod.WithFetchTimeout(cli.Add.ConnectTimeout + cli.Add.ReadTimeout),
+			rod.WithNavigationTimeout(cli.Add.ConnectTimeout),
+			rod.WithPageLoadTimeout(cli.Add.ReadTimeout),
+			rod.WithBrowserPoolSize(cli.Add.Browsers),
+			rod.WithCookies(cookies),
+		}
+		if cli.Add.Stealth {
+			rodOpts = append(rodOpts, rod.WithStealth())
+		}
+		rodFetcher, err := rod.NewFetcher(rodOpts...)
 		if err != nil {
 			fmt.Fprintln(stderr, "Hint: Chrome or Chromium must be installed")
 			return fmt.Errorf("failed to start browser: %w", err)
 		}
 		defer rodFetcher.Close()
 
-		httpFetcher := lochttp.NewFetcher(lochttp.WithTimeout(cli.Add.Timeout))
+		httpFetcher := lochttp.NewFetcher(
+			lochttp.WithConnectTimeout(cli.Add.ConnectTimeout),
+			lochttp.WithReadTimeout(cli.Add.ReadTimeout),
+			lochttp.WithCookies(cookies),
+		)
 
 		// Create link selector registry for recursive crawling fallback
 		detector := goquery.NewDetector()
-		fallbackSelector := goquery.NewGenericSelector()
+		var fallbackSelector locdoc.LinkSelector = goquery.NewGenericSelector()
+		if goquery.IsPaginatedURL(cli.Add.URL) {
+			// Seed URL is one page of a sequence; follow next-page links
+			fallbackSelector = goquery.NewPaginatedSelector()
+		}
 		linkSelectors := goquery.NewRegistry(detector, fallbackSelector)
 		registerFrameworkSelectors(linkSelectors)
 
 		// Create rate limiter for recursive crawling (1 request per second per domain)
 		rateLimiter := crawl.NewDomainLimiter(1.0)
-		extractor := readability.NewExtractor()
+		var extractor locdoc.Extractor = readability.NewExtractor()
+		if cli.Add.CustomSelector != "" {
+			extractor = goquery.NewSelectorExtractor(cli.Add.CustomSelector, extractor)
+		}
+		extractor = metadata.NewExtractor(extractor)
 
 		// Use interfaces to allow wrapping with logging decorators
 		var activeLinkSelectors locdoc.LinkSelectorRegistry = linkSelectors
 		var activeRodFetcher locdoc.Fetcher = rodFetcher
 		var activeHTTPFetcher locdoc.Fetcher = httpFetcher
 
-		// Wrap services with logging decorators when debug is enabled
+		// Wrap services with logging decorators for each enabled log destination
+		var loggers []*slog.Logger
 		if cli.Add.Debug {
-			logger := slog.New(slog.NewTextHandler(stderr, nil))
+			loggers = append(loggers, slog.New(slog.NewTextHandler(stderr, nil)))
+		}
+		if cli.Add.LogFile != "" {
+			logger, err := m.OpenLogFile(cli.Add.LogFile)
+			if err != nil {
+				return err
+			}
+			loggers = append(loggers, logger)
+		}
+		for _, logger := range loggers {
 			deps.Sitemaps = locslog.NewLoggingSitemapService(deps.Sitemaps, logger)
-			activeRodFetcher = locslog.NewLoggingFetcher(rodFetcher, logger)
-			activeHTTPFetcher = locslog.NewLoggingFetcher(httpFetcher, logger)
-			activeLinkSelectors = locslog.NewLoggingRegistry(linkSelectors, detector, logger)
+			activeRodFetcher = locslog.NewLoggingFetcher(activeRodFetcher, logger)
+			activeHTTPFetcher = locslog.NewLoggingFetcher(activeHTTPFetcher, logger)
+			activeLinkSelectors = locslog.NewLoggingRegistry(activeLinkSelectors, detector, logger)
 		}
 
-		// Create Discoverer for URL discovery (preview mode and recursive crawl fallback)
-		deps.Discoverer = &crawl.Discoverer{
+		// Create Crawler (used by both preview and full crawl)
+		deps.Crawler = &crawl.Crawler{
 			HTTPFetcher:   activeHTTPFetcher,
 			RodFetcher:    activeRodFetcher,
 			Prober:        detector,
@@ -160,12 +236,10 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 			LinkSelectors: activeLinkSelectors,
 			RateLimiter:   rateLimiter,
 			Concurrency:   cli.Add.Concurrency,
-		}
-
-		// Create Crawler with embedded Discoverer (used by both preview and full crawl)
-		deps.Crawler = &crawl.Crawler{
-			Discoverer: deps.Discoverer,
-			Sitemaps:   deps.Sitemaps,
+			RetryDelays:   crawl.ExponentialRetryDelays(cli.Add.RetryBase, cli.Add.RetryCount, cli.Add.RetryFactor),
+			RetryJitter:   crawl.DefaultRetryJitter,
+			FrontierSize:  cli.Add.FrontierSize,
+			Sitemaps:      deps.Sitemaps,
 		}
 
 		// Add full crawl dependencies for non-preview mode
@@ -175,35 +249,152 @@ func (m *Main) Run(ctx context.Context, args []string, stdout, stderr io.Writer)
 				return fmt.Errorf("failed to create token counter: %w", err)
 			}
 
-			deps.Crawler.Converter = htmltomarkdown.NewConverter()
+			deps.Crawler.Converter = htmltomarkdown.NewConverter(
+				htmltomarkdown.WithPreserveTables(cli.Add.PreserveTables),
+				htmltomarkdown.WithCodeLanguage(cli.Add.CodeLanguage),
+			)
 			deps.Crawler.Documents = m.DocumentService
 			deps.Crawler.TokenCounter = tokenCounter
+			deps.Crawler.ContentScorer = crawl.NewBasicContentScorer()
+
+			if cli.Add.MetricsAddr != "" {
+				deps.Metrics = lochttp.NewMetrics()
+			}
 		}
 	}
 
 	if cmd == "ask" {
-		apiKey := os.Getenv("GEMINI_API_KEY")
-		if apiKey == "" {
-			fmt.Fprintln(stderr, "GEMINI_API_KEY environment variable not set. Get an API key at https://aistudio.google.com/apikey")
-			return fmt.Errorf("GEMINI_API_KEY not set. Get a key at https://aistudio.google.com/apikey")
+		asker, err := cli.Ask.NewAsker(ctx, m.DocumentService, stderr)
+		if err != nil {
+			return err
 		}
+		deps.Asker = asker
+	}
+
+	return kongCtx.Run(deps)
+}
+
+const defaultModel = "gemini-3-flash-preview"
+
+// Default models for the other ask providers, used when --model is not set.
+const (
+	defaultOpenAIModel = "gpt-4.1-mini"
+	defaultOllamaModel = "llama3.2"
+)
+
+// NewAsker creates the Asker for the selected provider. Missing credentials
+// are reported on stderr with a hint on where to get them.
+func (c *AskCmd) NewAsker(ctx context.Context, docs locdoc.DocumentService, stderr io.Writer) (locdoc.Asker, error) {
+	provider, model := c.Provider, c.Model
+	if c.OpenAIModel != "" {
+		provider, model = "openai", c.OpenAIModel
+	}
+
+	encoder, err := newVectorEncoder(ctx, stderr, c.Embed, c.EmbedModel, c.OllamaURL)
+	if err != nil {
+		return nil, err
+	}
 
-		client, err := genai.NewClient(ctx, &genai.ClientConfig{
-			APIKey:  apiKey,
-			Backend: genai.BackendGeminiAPI,
-		})
+	switch provider {
+	case "gemini", "":
+		client, err := newGeminiClient(ctx, stderr)
 		if err != nil {
-			fmt.Fprintln(stderr, "Hint: Check your GEMINI_API_KEY is valid")
-			return fmt.Errorf("failed to connect to Gemini API: %w", err)
+			return nil, err
+		}
+
+		if model == "" {
+			model = defaultModel
+		}
+		asker := gemini.NewAsker(client, docs, model)
+		asker.ResponseLanguage = c.ResponseLanguage
+		asker.ExcludeURLs = c.ExcludeDoc
+		asker.Encoder = encoder
+		asker.NearestDocuments = c.Nearest
+		return asker, nil
+
+	case "openai":
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			fmt.Fprintln(stderr, "OPENAI_API_KEY environment variable not set. Get an API key at https://platform.openai.com/api-keys")
+			return nil, fmt.Errorf("OPENAI_API_KEY not set. Get a key at https://platform.openai.com/api-keys")
 		}
 
-		deps.Asker = gemini.NewAsker(client, m.DocumentService, defaultModel)
+		if model == "" {
+			model = defaultOpenAIModel
+		}
+		asker := openai.NewAsker(apiKey, model, docs)
+		asker.ResponseLanguage = c.ResponseLanguage
+		asker.ExcludeURLs = c.ExcludeDoc
+		asker.Encoder = encoder
+		asker.NearestDocuments = c.Nearest
+		return asker, nil
+
+	case "ollama":
+		if model == "" {
+			model = defaultOllamaModel
+		}
+		asker := ollama.NewAsker(model, docs)
+		asker.BaseURL = ollamaBaseURL(c.OllamaURL)
+		asker.ResponseLanguage = c.ResponseLanguage
+		asker.ExcludeURLs = c.ExcludeDoc
+		asker.Encoder = encoder
+		asker.NearestDocuments = c.Nearest
+		return asker, nil
+
+	default:
+		return nil, locdoc.Errorf(locdoc.EINVALID, "unknown provider %q: use gemini, openai or ollama", provider)
 	}
+}
 
-	return kongCtx.Run(deps)
+// newGeminiClient creates a Gemini API client from GEMINI_API_KEY. A missing
+// key is reported on stderr with a hint on where to get one.
+func newGeminiClient(ctx context.Context, stderr io.Writer) (*genai.Client, error) {
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		fmt.Fprintln(stderr, "GEMINI_API_KEY environment variable not set. Get an API key at https://aistudio.google.com/apikey")
+		return nil, fmt.Errorf("GEMINI_API_KEY not set. Get a key at https://aistudio.google.com/apikey")
+	}
+
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:  apiKey,
+		Backend: genai.BackendGeminiAPI,
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "Hint: Check your GEMINI_API_KEY is valid")
+		return nil, fmt.Errorf("failed to connect to Gemini API: %w", err)
+	}
+	return client, nil
 }
 
-const defaultModel = "gemini-3-flash-preview"
+// newVectorEncoder creates the encoder selected by --embed (Gemini) or
+// --embed-model (Ollama). Returns nil when neither is set.
+func newVectorEncoder(ctx context.Context, stderr io.Writer, embed bool, embedModel, ollamaURL string) (locdoc.VectorEncoder, error) {
+	switch {
+	case embed:
+		client, err := newGeminiClient(ctx, stderr)
+		if err != nil {
+			return nil, err
+		}
+		return gemini.NewEmbeddingEncoder(client, gemini.DefaultEmbeddingModel), nil
+	case embedModel != "":
+		encoder := ollama.NewEncoder(embedModel)
+		encoder.BaseURL = ollamaBaseURL(ollamaURL)
+		return encoder, nil
+	}
+	return nil, nil
+}
+
+// ollamaBaseURL normalizes an --ollama-url value. OLLAMA_HOST is commonly
+// set as host:port without a scheme. Empty values use ollama.DefaultBaseURL.
+func ollamaBaseURL(addr string) string {
+	if addr == "" {
+		return ollama.DefaultBaseURL
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	return strings.TrimSuffix(addr, "/")
+}
 
 // tokenizerModel is used for token counting. Using gemini-2.5-flash until
 // gemini-3-flash-preview is supported by google.golang.org/genai/tokenizer.
@@ -231,4 +422,6 @@ func registerFrameworkSelectors(registry locdoc.LinkSelectorRegistry) {
 	registry.Register(locdoc.FrameworkVuePress, goquery.NewVuePressSelector())
 	registry.Register(locdoc.FrameworkGitBook, goquery.NewGitBookSelector())
 	registry.Register(locdoc.FrameworkNextra, goquery.NewNextraSelector())
+	registry.Register(locdoc.FrameworkAntora, goquery.NewAntoraSelector())
+	registry.Register(locdoc.FrameworkAntoraDynamic, goquery.NewAntoraSelector())
 }
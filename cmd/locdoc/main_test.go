@@ -3,11 +3,16 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/fwojciec/locdoc"
 	main "github.com/fwojciec/locdoc/cmd/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	locslog "github.com/fwojciec/locdoc/slog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -110,3 +115,108 @@ func TestRun_DatabaseOpenError(t *testing.T) {
 	// Hint should be printed to stderr separately for cleaner output
 	assert.Contains(t, stderr.String(), "LOCDOC_DB", "stderr should mention LOCDOC_DB environment variable")
 }
+
+func TestRun_DBFlag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("overrides DBPath before opening the database", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		flagPath := filepath.Join(tmpDir, "flag.db")
+
+		m := main.NewMain()
+		m.DBPath = filepath.Join(tmpDir, "default.db")
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+
+		err := m.Run(testContext(), []string{"--db", flagPath, "list"}, stdout, stderr)
+
+		require.NoError(t, err)
+		assert.Equal(t, flagPath, m.DBPath)
+		_, statErr := os.Stat(flagPath)
+		require.NoError(t, statErr, "database should be created at the flag path")
+		_, statErr = os.Stat(filepath.Join(tmpDir, "default.db"))
+		assert.True(t, os.IsNotExist(statErr), "default database should not be created")
+	})
+
+	t.Run("help shows resolved database path", func(t *testing.T) {
+		t.Parallel()
+
+		dbPath := filepath.Join(t.TempDir(), "resolved.db")
+
+		m := main.NewMain()
+		m.DBPath = dbPath
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+
+		err := m.Run(testContext(), []string{"--help"}, stdout, stderr)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "--db")
+		assert.Contains(t, stdout.String(), dbPath)
+	})
+}
+
+func TestMain_OpenLogFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs crawl fetches as JSON lines", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "crawl.log")
+
+		m := main.NewMain()
+		logger, err := m.OpenLogFile(path)
+		require.NoError(t, err)
+
+		fetcher := locslog.NewLoggingFetcher(&mock.Fetcher{
+			FetchFn: func(_ context.Context, _ string) (string, error) {
+				return "<html><body>Test content</body></html>", nil
+			},
+		}, logger)
+		crawler := newTestSitemapCrawler(
+			[]string{"https://example.com/docs/page1"},
+			fetcher,
+			&mock.DocumentWriter{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+		)
+		deps := newTestAddDeps(crawler, &bytes.Buffer{}, &bytes.Buffer{})
+
+		cmd := &main.AddCmd{Name: "testdocs", URL: "https://example.com/docs", Concurrency: 1}
+		require.NoError(t, cmd.Run(deps))
+		require.NoError(t, m.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.NotEmpty(t, lines)
+		for _, line := range lines {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &entry), "line should be JSON: %s", line)
+			assert.Contains(t, entry, "url")
+		}
+	})
+
+	t.Run("appends to existing file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "crawl.log")
+
+		for i := 0; i < 2; i++ {
+			m := main.NewMain()
+			logger, err := m.OpenLogFile(path)
+			require.NoError(t, err)
+			logger.Info("fetch", "url", "https://example.com")
+			require.NoError(t, m.Close())
+		}
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(data), "\n"))
+	})
+}
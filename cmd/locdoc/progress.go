@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/fwojciec/locdoc/crawl"
+)
+
+// progressLine is one JSON-lines progress record written by jsonProgress.
+type progressLine struct {
+	Event    string `json:"event"`
+	URL      string `json:"url,omitempty"`
+	Position int    `json:"position,omitempty"`
+	Saved    int    `json:"saved"`
+	Failed   int    `json:"failed,omitempty"`
+	Skipped  int    `json:"skipped,omitempty"`
+	Total    int    `json:"total,omitempty"`
+	Bytes    int    `json:"bytes,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// jsonProgress writes crawl progress as one JSON object per line so that
+// scripts can consume it, e.g. with jq.
+type jsonProgress struct {
+	enc   *json.Encoder
+	saved int
+}
+
+// newJSONProgress returns a jsonProgress that writes to w.
+func newJSONProgress(w io.Writer) *jsonProgress {
+	return &jsonProgress{enc: json.NewEncoder(w)}
+}
+
+// handle writes a line for a crawl progress event. Started and finished
+// events are not written; finish reports the final result instead.
+func (p *jsonProgress) handle(event crawl.ProgressEvent) {
+	line := progressLine{
+		URL:      event.URL,
+		Position: event.Completed,
+		Total:    event.Total,
+	}
+
+	switch event.Type {
+	case crawl.ProgressCompleted:
+		p.saved++
+		line.Event = "completed"
+	case crawl.ProgressFailed:
+		line.Event = "failed"
+		if event.Error != nil {
+			line.Error = event.Error.Error()
+		}
+	case crawl.ProgressSkipped:
+		line.Event = "skipped"
+		line.Reason = event.Reason
+	case crawl.ProgressRetryAlternate:
+		line.Event = "retry_alternate"
+		line.Reason = event.Reason
+	case crawl.ProgressLimitReached:
+		line.Event = "limit_reached"
+		line.Reason = event.Reason
+	default:
+		return
+	}
+
+	line.Saved = p.saved
+	_ = p.enc.Encode(line)
+}
+
+// finish writes the final summary line.
+func (p *jsonProgress) finish(result *crawl.Result) {
+	_ = p.enc.Encode(progressLine{
+		Event:   "finished",
+		Saved:   result.Saved,
+		Failed:  result.Failed,
+		Skipped: result.Skipped,
+		Bytes:   result.Bytes,
+	})
+}
+
+// isTerminal reports whether stream, a reader or writer, is a terminal.
+// Streams that are not files, such as buffers in tests, are treated as
+// terminals so that output keeps the human-readable format unless JSON is
+// requested explicitly.
+func isTerminal(stream any) bool {
+	f, ok := stream.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Run executes the reorder command.
+func (c *ReorderCmd) Run(deps *Dependencies) error {
+	projects, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &c.Name})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintf(deps.Stderr, "error: project %q not found. Use 'locdoc list' to see available projects.\n", c.Name)
+		return locdoc.Errorf(locdoc.ENOTFOUND, "project %q not found", c.Name)
+	}
+
+	project := projects[0]
+	docs, err := deps.Documents.FindDocuments(deps.Ctx, locdoc.DocumentFilter{
+		ProjectID: &project.ID,
+		SourceURL: &c.Move,
+	})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	if len(docs) == 0 {
+		fmt.Fprintf(deps.Stderr, "error: document %q not found. Use 'locdoc docs %s' to see stored documents.\n", c.Move, c.Name)
+		return locdoc.Errorf(locdoc.ENOTFOUND, "document %q not found", c.Move)
+	}
+
+	if err := deps.Documents.UpdateDocumentPosition(deps.Ctx, docs[0].ID, c.ToPosition); err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	fmt.Fprintf(deps.Stdout, "Moved %s from position %d to %d\n", c.Move, docs[0].Position, c.ToPosition)
+	return nil
+}
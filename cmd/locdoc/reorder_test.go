@@ -0,0 +1,82 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	main "github.com/fwojciec/locdoc/cmd/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderCmd_Run(t *testing.T) {
+	t.Parallel()
+
+	projects := &mock.ProjectService{
+		FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+			return []*locdoc.Project{{ID: "proj-123", Name: "htmx"}}, nil
+		},
+	}
+
+	t.Run("moves the document with the given URL", func(t *testing.T) {
+		t.Parallel()
+
+		var movedID string
+		var movedTo int
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				if filter.SourceURL != nil && *filter.SourceURL == "https://htmx.org/docs/" {
+					return []*locdoc.Document{{ID: "doc-1", SourceURL: *filter.SourceURL, Position: 3}}, nil
+				}
+				return nil, nil
+			},
+			UpdateDocumentPositionFn: func(_ context.Context, id string, position int) error {
+				movedID, movedTo = id, position
+				return nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.ReorderCmd{Name: "htmx", Move: "https://htmx.org/docs/", ToPosition: 0}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Equal(t, "doc-1", movedID)
+		assert.Equal(t, 0, movedTo)
+		assert.Contains(t, stdout.String(), "from position 3 to 0")
+	})
+
+	t.Run("returns ENOTFOUND for unknown document", func(t *testing.T) {
+		t.Parallel()
+
+		documents := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return nil, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    &bytes.Buffer{},
+			Stderr:    &bytes.Buffer{},
+			Projects:  projects,
+			Documents: documents,
+		}
+
+		cmd := &main.ReorderCmd{Name: "htmx", Move: "https://htmx.org/missing/", ToPosition: 0}
+		err := cmd.Run(deps)
+
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
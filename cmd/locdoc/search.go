@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Run executes the search command.
+func (c *SearchCmd) Run(deps *Dependencies) error {
+	projects, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &c.Name})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintf(deps.Stderr, "error: project %q not found. Use 'locdoc list' to see available projects.\n", c.Name)
+		return locdoc.Errorf(locdoc.ENOTFOUND, "project %q not found", c.Name)
+	}
+
+	project := projects[0]
+
+	docs, err := deps.Documents.FindDocuments(deps.Ctx, locdoc.DocumentFilter{
+		ProjectID: &project.ID,
+		Query:     c.Query,
+		SortBy:    locdoc.SortByRelevance,
+		Limit:     c.Limit,
+	})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	if len(docs) == 0 {
+		fmt.Fprintf(deps.Stdout, "No documents in %s match %q.\n", c.Name, c.Query)
+		return nil
+	}
+
+	fmt.Fprintf(deps.Stdout, "Results for %q in %s:\n\n", c.Query, c.Name)
+	for i, doc := range docs {
+		title := doc.Title
+		if title == "" {
+			title = doc.SourceURL
+		}
+		if c.Verbose {
+			fmt.Fprintf(deps.Stdout, "  %d. %s (score %.2f)\n     %s\n", i+1, title, doc.Score, doc.SourceURL)
+		} else {
+			fmt.Fprintf(deps.Stdout, "  %d. %s\n     %s\n", i+1, title, doc.SourceURL)
+		}
+		if doc.Snippet != "" {
+			fmt.Fprintf(deps.Stdout, "     %s\n", strings.Join(strings.Fields(doc.Snippet), " "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	main "github.com/fwojciec/locdoc/cmd/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCmd_Run(t *testing.T) {
+	t.Parallel()
+
+	newProjects := func() *mock.ProjectService {
+		return &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, filter locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				if filter.Name != nil && *filter.Name == "react-docs" {
+					return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+				}
+				return []*locdoc.Project{}, nil
+			},
+		}
+	}
+
+	newDocuments := func(got *locdoc.DocumentFilter) *mock.DocumentService {
+		return &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				*got = filter
+				return []*locdoc.Document{
+					{ID: "doc-1", Title: "Routing", SourceURL: "https://react.dev/docs/routing", Score: 4.25, Snippet: "...nested <b>routing</b>\nwith layouts..."},
+					{ID: "doc-2", Title: "Effects", SourceURL: "https://react.dev/docs/effects", Score: 1.5},
+				}, nil
+			},
+		}
+	}
+
+	t.Run("searches project documents by relevance", func(t *testing.T) {
+		t.Parallel()
+
+		var got locdoc.DocumentFilter
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    &bytes.Buffer{},
+			Projects:  newProjects(),
+			Documents: newDocuments(&got),
+		}
+
+		cmd := &main.SearchCmd{Name: "react-docs", Query: "routing", Limit: 5}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, got.ProjectID)
+		assert.Equal(t, "proj-123", *got.ProjectID)
+		assert.Equal(t, "routing", got.Query)
+		assert.Equal(t, locdoc.SortByRelevance, got.SortBy)
+		assert.Equal(t, 5, got.Limit)
+		assert.Contains(t, stdout.String(), "1. Routing")
+		assert.Contains(t, stdout.String(), "2. Effects")
+		assert.NotContains(t, stdout.String(), "score")
+		assert.Contains(t, stdout.String(), "https://react.dev/docs/routing\n     ...nested <b>routing</b> with layouts...\n")
+	})
+
+	t.Run("shows scores with --verbose", func(t *testing.T) {
+		t.Parallel()
+
+		var got locdoc.DocumentFilter
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    stdout,
+			Stderr:    &bytes.Buffer{},
+			Projects:  newProjects(),
+			Documents: newDocuments(&got),
+		}
+
+		cmd := &main.SearchCmd{Name: "react-docs", Query: "routing", Verbose: true}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Routing (score 4.25)")
+		assert.Contains(t, stdout.String(), "Effects (score 1.50)")
+	})
+
+	t.Run("reports when nothing matches", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: newProjects(),
+			Documents: &mock.DocumentService{
+				FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+					return nil, nil
+				},
+			},
+		}
+
+		cmd := &main.SearchCmd{Name: "react-docs", Query: "routing"}
+		err := cmd.Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), `No documents in react-docs match "routing"`)
+	})
+
+	t.Run("returns error when project not found", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:       context.Background(),
+			Stdout:    &bytes.Buffer{},
+			Stderr:    stderr,
+			Projects:  newProjects(),
+			Documents: &mock.DocumentService{},
+		}
+
+		cmd := &main.SearchCmd{Name: "missing", Query: "routing"}
+		err := cmd.Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, stderr.String(), `project "missing" not found`)
+	})
+}
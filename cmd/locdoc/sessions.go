@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Run executes the sessions command.
+func (c *SessionsCmd) Run(deps *Dependencies) error {
+	projects, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &c.Name})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintf(deps.Stderr, "error: project %q not found. Use 'locdoc list' to see available projects.\n", c.Name)
+		return locdoc.Errorf(locdoc.ENOTFOUND, "project %q not found", c.Name)
+	}
+
+	sessions, err := deps.Sessions.FindSessions(deps.Ctx, locdoc.SessionFilter{ProjectID: &projects[0].ID})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Fprintf(deps.Stdout, "No sessions found. Use 'locdoc ask %s --new-session' to start one.\n", c.Name)
+		return nil
+	}
+
+	now := time.Now()
+	for _, s := range sessions {
+		first := ""
+		if len(s.Turns) > 0 {
+			first = s.Turns[0].Question
+		}
+		fmt.Fprintf(deps.Stdout, "%s  %d turns  Started: %s  %s\n", s.ID, len(s.Turns), formatAge(now.Sub(s.CreatedAt)), first)
+	}
+
+	return nil
+}
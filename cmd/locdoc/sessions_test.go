@@ -0,0 +1,81 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/locdoc"
+	main "github.com/fwojciec/locdoc/cmd/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionsCmd_Run(t *testing.T) {
+	t.Parallel()
+
+	projects := &mock.ProjectService{
+		FindProjectsFn: func(_ context.Context, filter locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+			if filter.Name != nil && *filter.Name == "react-docs" {
+				return []*locdoc.Project{{ID: "proj-123", Name: "react-docs"}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	t.Run("lists sessions with ID, turn count, and first question", func(t *testing.T) {
+		t.Parallel()
+
+		var gotFilter locdoc.SessionFilter
+		sessions := &mock.SessionService{
+			FindSessionsFn: func(_ context.Context, filter locdoc.SessionFilter) ([]*locdoc.Session, error) {
+				gotFilter = filter
+				return []*locdoc.Session{{
+					ID:        "sess-1",
+					ProjectID: "proj-123",
+					CreatedAt: time.Now().Add(-2 * time.Hour),
+					Turns: []locdoc.Turn{
+						{Question: "What is useState?", Answer: "A hook."},
+						{Question: "How do I initialize it?", Answer: "Pass a value."},
+					},
+				}}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Sessions: sessions,
+		}
+
+		err := (&main.SessionsCmd{Name: "react-docs"}).Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, gotFilter.ProjectID)
+		assert.Equal(t, "proj-123", *gotFilter.ProjectID)
+		assert.Contains(t, stdout.String(), "sess-1")
+		assert.Contains(t, stdout.String(), "2 turns")
+		assert.Contains(t, stdout.String(), "What is useState?")
+	})
+
+	t.Run("returns ENOTFOUND for unknown project", func(t *testing.T) {
+		t.Parallel()
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+			Sessions: &mock.SessionService{},
+		}
+
+		err := (&main.SessionsCmd{Name: "missing"}).Run(deps)
+
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
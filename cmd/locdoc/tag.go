@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Run executes the tag add command.
+func (c *TagAddCmd) Run(deps *Dependencies) error {
+	project, err := findProjectByName(deps, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if project.HasTag(c.Tag) {
+		fmt.Fprintf(deps.Stdout, "Project %q is already tagged %q\n", project.Name, c.Tag)
+		return nil
+	}
+
+	tags := mergeTags(project.Tags, []string{c.Tag})
+	if _, err := deps.Projects.UpdateProject(deps.Ctx, project.ID, locdoc.ProjectUpdate{Tags: &tags}); err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	fmt.Fprintf(deps.Stdout, "Tagged project %q with %q\n", project.Name, c.Tag)
+	return nil
+}
+
+// Run executes the tag remove command.
+func (c *TagRemoveCmd) Run(deps *Dependencies) error {
+	project, err := findProjectByName(deps, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if !project.HasTag(c.Tag) {
+		fmt.Fprintf(deps.Stderr, "error: project %q is not tagged %q\n", project.Name, c.Tag)
+		return locdoc.Errorf(locdoc.ENOTFOUND, "project %q is not tagged %q", project.Name, c.Tag)
+	}
+
+	tags := slices.DeleteFunc(slices.Clone(project.Tags), func(tag string) bool { return tag == c.Tag })
+	if _, err := deps.Projects.UpdateProject(deps.Ctx, project.ID, locdoc.ProjectUpdate{Tags: &tags}); err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return err
+	}
+
+	fmt.Fprintf(deps.Stdout, "Removed tag %q from project %q\n", c.Tag, project.Name)
+	return nil
+}
+
+// mergeTags appends the tags in add that are not already in tags, keeping
+// the existing order.
+func mergeTags(tags, add []string) []string {
+	merged := slices.Clone(tags)
+	for _, tag := range add {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// findProjectByName looks up a project by name, reporting a missing project
+// on stderr.
+func findProjectByName(deps *Dependencies, name string) (*locdoc.Project, error) {
+	projects, err := deps.Projects.FindProjects(deps.Ctx, locdoc.ProjectFilter{Name: &name})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "error: %s\n", locdoc.ErrorMessage(err))
+		return nil, err
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintf(deps.Stderr, "error: project %q not found. Use 'locdoc list' to see available projects.\n", name)
+		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "project %q not found", name)
+	}
+
+	return projects[0], nil
+}
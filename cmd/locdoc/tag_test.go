@@ -0,0 +1,186 @@
+package main_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	main "github.com/fwojciec/locdoc/cmd/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/fwojciec/locdoc/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagAddCmd_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("appends the tag to the project", func(t *testing.T) {
+		t.Parallel()
+
+		var gotUpdate locdoc.ProjectUpdate
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-1", Name: "react", Tags: []string{"frontend"}}}, nil
+			},
+			UpdateProjectFn: func(_ context.Context, _ string, upd locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				gotUpdate = upd
+				return &locdoc.Project{}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+		}
+
+		err := (&main.TagAddCmd{Name: "react", Tag: "docs"}).Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, gotUpdate.Tags)
+		assert.Equal(t, []string{"frontend", "docs"}, *gotUpdate.Tags)
+		assert.Contains(t, stdout.String(), `Tagged project "react" with "docs"`)
+	})
+
+	t.Run("leaves an existing tag alone", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-1", Name: "react", Tags: []string{"frontend"}}}, nil
+			},
+		}
+
+		stdout := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   stdout,
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+		}
+
+		err := (&main.TagAddCmd{Name: "react", Tag: "frontend"}).Run(deps)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "already tagged")
+	})
+
+	t.Run("returns ENOTFOUND for unknown project", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return nil, nil
+			},
+		}
+
+		stderr := &bytes.Buffer{}
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   stderr,
+			Projects: projects,
+		}
+
+		err := (&main.TagAddCmd{Name: "missing", Tag: "docs"}).Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, stderr.String(), `project "missing" not found`)
+	})
+}
+
+func TestTagRemoveCmd_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes the tag from the project", func(t *testing.T) {
+		t.Parallel()
+
+		var gotUpdate locdoc.ProjectUpdate
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-1", Name: "react", Tags: []string{"frontend", "docs"}}}, nil
+			},
+			UpdateProjectFn: func(_ context.Context, _ string, upd locdoc.ProjectUpdate) (*locdoc.Project, error) {
+				gotUpdate = upd
+				return &locdoc.Project{}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+		}
+
+		err := (&main.TagRemoveCmd{Name: "react", Tag: "frontend"}).Run(deps)
+
+		require.NoError(t, err)
+		require.NotNil(t, gotUpdate.Tags)
+		assert.Equal(t, []string{"docs"}, *gotUpdate.Tags)
+	})
+
+	t.Run("returns ENOTFOUND when the project lacks the tag", func(t *testing.T) {
+		t.Parallel()
+
+		projects := &mock.ProjectService{
+			FindProjectsFn: func(_ context.Context, _ locdoc.ProjectFilter) ([]*locdoc.Project, error) {
+				return []*locdoc.Project{{ID: "proj-1", Name: "react"}}, nil
+			},
+		}
+
+		deps := &main.Dependencies{
+			Ctx:      context.Background(),
+			Stdout:   &bytes.Buffer{},
+			Stderr:   &bytes.Buffer{},
+			Projects: projects,
+		}
+
+		err := (&main.TagRemoveCmd{Name: "react", Tag: "frontend"}).Run(deps)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
+
+func TestRun_TagCommands(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db := sqlite.NewDB(dbPath)
+	require.NoError(t, db.Open())
+	svc := sqlite.NewProjectService(db)
+	ctx := context.Background()
+	require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "react", SourceURL: "https://react.dev"}))
+	require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "htmx", SourceURL: "https://htmx.org"}))
+	require.NoError(t, db.Close())
+
+	run := func(args ...string) string {
+		t.Helper()
+		m := main.NewMain()
+		m.DBPath = dbPath
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		require.NoError(t, m.Run(testContext(), args, stdout, stderr), stderr.String())
+		return stdout.String()
+	}
+
+	run("tag", "add", "react", "frontend")
+
+	out := run("list", "--tag", "frontend")
+	assert.Contains(t, out, "react")
+	assert.Contains(t, out, "Tags: frontend")
+	assert.NotContains(t, out, "htmx")
+
+	run("tag", "remove", "react", "frontend")
+
+	out = run("list", "--tag", "frontend")
+	assert.Contains(t, out, `No projects tagged "frontend"`)
+	assert.NotContains(t, run("list"), "Tags:")
+}
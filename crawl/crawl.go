@@ -5,32 +5,240 @@ package crawl
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	"golang.org/x/sync/errgroup"
 )
 
-// Crawler orchestrates the crawling of documentation sites.
+// Crawler orchestrates the crawling of documentation sites. It discovers
+// URLs from the sitemap or, failing that, by recursively walking the site,
+// probing the site to choose between HTTP and browser fetching.
 type Crawler struct {
-	*Discoverer
+	HTTPFetcher   locdoc.Fetcher
+	RodFetcher    locdoc.Fetcher
+	Prober        locdoc.Prober
+	Extractor     locdoc.Extractor
+	LinkSelectors locdoc.LinkSelectorRegistry
+	RateLimiter   locdoc.DomainLimiter
+	Concurrency   int
+	RetryDelays   []time.Duration
+
+	// RetryJitter randomizes each retry delay by up to ±RetryJitter*delay.
+	// Zero disables jitter.
+	RetryJitter float64
+
+	// ExcludeHiddenContent skips links inside hidden elements. By default
+	// they are followed, since collapsed sidebars and inactive tabs are
+	// hidden in the DOM but still link to real pages.
+	ExcludeHiddenContent bool
+
+	// FrontierSize caps the number of queued URLs during recursive
+	// discovery. When full, the lowest-priority links are dropped.
+	// Zero means no limit.
+	FrontierSize int
+
+	// DrainTimeout is how long to wait for in-flight URLs to finish when a
+	// recursive walk stops early, e.g. on cancellation. Zero uses
+	// DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
+	// MaxCrawlURLs limits the number of URLs fetched by a recursive walk.
+	// Zero uses a default of 1000.
+	MaxCrawlURLs int
+
+	// MaxDepth limits recursive walks to pages at most this many links
+	// away from the source URL. Zero means unlimited.
+	MaxDepth int
+
+	// Sitemaps discovers URLs from sitemaps. Nil skips sitemap discovery,
+	// so DiscoverURLs only walks the site.
 	Sitemaps     locdoc.SitemapService
 	Converter    locdoc.Converter
 	Documents    locdoc.DocumentWriter
 	TokenCounter locdoc.TokenCounter
+
+	// ContentScorer rates converted pages. When set, pages scoring below
+	// MinQualityScore are skipped. Nil disables quality filtering.
+	ContentScorer   locdoc.ContentScorer
+	MinQualityScore float64
+
+	// MinContentLength is the minimum size in bytes of the extracted HTML
+	// for a page to be saved. Shorter pages (redirect stubs, empty pages)
+	// are skipped. Zero disables the check.
+	MinContentLength int
+
+	// Language restricts the crawl to pages declaring this BCP 47 language
+	// tag, e.g. "en". A primary tag also matches its regional variants, so
+	// "en" keeps "en-US". Pages that declare no language are kept. Empty
+	// disables the filter.
+	Language string
+
+	// ExtractRetries and ConvertRetries are the number of times extraction
+	// and conversion are attempted for a page before it counts as failed.
+	// Attempts are made back to back, without the fetch retry delays.
+	// Values below 1 mean a single attempt.
+	ExtractRetries int
+	ConvertRetries int
+
+	// HashFunc computes Document.ContentHash from a page's Markdown, which
+	// is compared against existing documents to skip unchanged pages.
+	// Defaults to ComputeHash (xxhash). Changing it makes every page of an
+	// existing project look changed on the next crawl.
+	HashFunc func(content string) string
+
+	// StoreExtractedHTML saves each page's extracted content HTML in
+	// Document.ExtractedHTML, to help debug extraction problems.
+	StoreExtractedHTML bool
+
+	// VectorEncoder, when set, embeds each saved document's content so the
+	// project can be searched by similarity. Unchanged pages are not
+	// re-encoded.
+	VectorEncoder locdoc.VectorEncoder
+
+	// RetryWithAlternate makes a URL that still fails after all HTTP fetch
+	// retries get one last attempt with RodFetcher before it counts as
+	// failed. Applies per URL, independently of the probe decision.
+	RetryWithAlternate bool
+
+	// AllowedDomains restricts recursive crawling to links whose host is in
+	// the list. When empty, only links on the source URL's host are followed.
+	AllowedDomains []string
+
+	// BlockedDomains lists hosts whose links are never followed during
+	// recursive crawling, even if they are otherwise in scope.
+	BlockedDomains []string
+
+	// Existing makes crawls incremental. When set, each page is looked up by
+	// project and URL before saving: an unchanged page is skipped, and a
+	// changed one replaces the stored document. Replaced documents are
+	// deleted once the crawl succeeds, so a failed crawl leaves them in
+	// place. Nil saves every page.
+	Existing locdoc.DocumentService
+
+	// UseUpsert saves pages with UpsertDocument instead of CreateDocument,
+	// so a page already stored for the project is updated in place and
+	// keeps its ID. With Existing set, changed pages are upserted rather
+	// than deleted and re-created.
+	UseUpsert bool
+
+	// MaxBytes caps the total size of the markdown saved by a crawl. Once
+	// it is reached no further URLs are fetched, pages still in flight are
+	// skipped and a ProgressLimitReached event is reported. Zero means
+	// unlimited.
+	MaxBytes int64
+
+	// DryRun performs every step of a crawl except writing documents.
+	// Pages that would have been saved are listed in Result.WouldSave
+	// instead, and Result.Saved stays zero.
+	DryRun bool
+
+	// DocumentTransformer, if set, rewrites each document after conversion
+	// and before it is saved, e.g. to strip tracking comments or rewrite
+	// links. It may modify any field and return the same document or a new
+	// one; returning nil skips the page. ContentHash is left as computed
+	// from the converted page, so incremental crawls still recognize
+	// unchanged pages.
+	DocumentTransformer func(doc *locdoc.Document) *locdoc.Document
+
+	// OnDocumentSaved, if set, is called with each document right after it
+	// is written. Calls are made one at a time from the goroutine that
+	// saves documents, so the callback must return quickly; hand slow work
+	// off to another goroutine. When Documents supports transactions, the
+	// write has already been committed.
+	OnDocumentSaved func(doc *locdoc.Document)
+
+	// activeWorkers counts walk workers currently processing a URL.
+	activeWorkers atomic.Int32
+}
+
+// ActiveWorkers returns the number of workers currently processing a URL
+// during a recursive walk. It never exceeds the walk's concurrency.
+func (c *Crawler) ActiveWorkers() int {
+	return int(c.activeWorkers.Load())
+}
+
+// followLinks reports whether links discovered on a page at depth should
+// be queued.
+func (c *Crawler) followLinks(depth int) bool {
+	return c.MaxDepth <= 0 || depth < c.MaxDepth
+}
+
+// selectorOptions returns the options passed to link selectors.
+func (c *Crawler) selectorOptions() locdoc.SelectorOptions {
+	return locdoc.SelectorOptions{IncludeHiddenContent: !c.ExcludeHiddenContent}
 }
 
 // Result holds the outcome of a crawl operation.
 type Result struct {
-	Saved  int
-	Failed int
-	Bytes  int
+	Saved   int
+	Failed  int
+	Skipped int // Number of entries in SkippedPages
+	Bytes   int // Size of the stored markdown
+	Tokens  int
+
+	// ExtractFailed and ConvertFailed count the failed pages whose
+	// extraction or conversion still failed after all retries. They are
+	// included in Failed.
+	ExtractFailed int
+	ConvertFailed int
+
+	// TransferBytes is the total size of fetched HTML responses, including
+	// pages that were later skipped or failed conversion.
+	TransferBytes int
+
+	// FetcherType is the fetcher used for the crawl, either taken from
+	// the project's cached value or determined by probing.
+	FetcherType locdoc.FetcherType
+
+	// Errors lists each failed URL, in the order the failures occurred.
+	Errors []CrawlError
+
+	// SkippedPages lists each skipped URL with the reason it was skipped,
+	// in the order the pages were skipped.
+	SkippedPages []SkippedPage
+
+	// Unvisited lists URLs that were discovered but never fetched, because
+	// MaxCrawlURLs or MaxBytes was reached or the crawl was canceled. They
+	// are in the order they would have been crawled.
+	Unvisited []string
+
+	// StopReason says why Unvisited is not empty: "crawl limit reached",
+	// "byte limit reached", "canceled" or "timed out". It is empty when
+	// every discovered URL was crawled.
+	StopReason string
+
+	// WouldSave lists the pages a DryRun crawl would have saved, in the
+	// order they would have been saved. Bytes and Tokens total these pages.
+	WouldSave []PreviewDocument
+}
+
+// PreviewDocument describes a page that a dry-run crawl would have saved.
+type PreviewDocument struct {
+	URL    string
+	Title  string
+	Bytes  int // Size of the markdown that would be stored
 	Tokens int
 }
 
+// SkippedPage describes a fetched URL that was deliberately not saved, for
+// example because its content was unchanged or too short.
+type SkippedPage struct {
+	URL    string
+	Reason string
+}
+
+// CrawlError describes a URL that could not be fetched, converted or saved.
+type CrawlError struct {
+	URL     string
+	Err     error
+	Attempt int // Number of fetch attempts made, including retries
+}
+
 // ProgressEvent reports progress during a crawl operation.
 type ProgressEvent struct {
 	Type      ProgressType
@@ -38,6 +246,7 @@ type ProgressEvent struct {
 	Total     int
 	URL       string
 	Error     error
+	Reason    string // Why a page was skipped or refetched, or which limit stopped the crawl (ProgressSkipped, ProgressRetryAlternate, ProgressLimitReached)
 }
 
 // ProgressType indicates the type of progress event.
@@ -48,6 +257,9 @@ const (
 	ProgressCompleted
 	ProgressFailed
 	ProgressFinished
+	ProgressSkipped
+	ProgressRetryAlternate // HTTP fetching failed or the probe got unusable content; Rod is used instead
+	ProgressLimitReached   // A crawl limit was reached and no further pages were fetched
 )
 
 // ProgressFunc is a callback for reporting crawl progress.
@@ -55,13 +267,24 @@ type ProgressFunc func(event ProgressEvent)
 
 // crawlResult holds the outcome of processing a single URL.
 type crawlResult struct {
-	position   int
-	url        string
-	title      string
-	markdown   string
-	hash       string
-	err        error
-	discovered []locdoc.DiscoveredLink // Links discovered on this page (for recursive crawling)
+	position    int
+	url         string
+	depth       int    // Links followed from the seed URL to reach url (recursive crawling)
+	canonical   string // Canonical URL declared by the page, if different from url
+	title       string
+	markdown    string
+	hash        string
+	readability float32
+	language    string
+	summary     string
+	extracted   string // Extracted content HTML, kept when StoreExtractedHTML is set
+	err         error
+	failedStep  string                  // "extract" or "convert" if err came from that step rather than fetching
+	skipReason  string                  // Non-empty if the page was fetched but deliberately not saved
+	altReason   string                  // Non-empty if the page was refetched with the alternate fetcher
+	attempts    int                     // Number of fetch attempts made
+	transfer    int                     // Size of the fetched HTML
+	discovered  []locdoc.DiscoveredLink // Links discovered on this page (for recursive crawling)
 }
 
 // probeConfig holds dependencies for probeFetcher.
@@ -73,82 +296,263 @@ type probeConfig struct {
 }
 
 // probeFetcher determines which fetcher to use for crawling by probing the first URL.
-// Returns the fetcher to use for subsequent requests.
+// Returns the fetcher to use for subsequent requests, and a warning when the
+// HTTP response was unusable.
 //
 // Logic:
 // 1. HTTP fetch first URL
-// 2. Detect framework
-// 3. If known framework → use HTTP or Rod based on RequiresJS
-// 4. If unknown → Rod fetch, compare content, choose based on differences
-// 5. If HTTP fails → fall back to Rod
-func probeFetcher(ctx context.Context, probeURL string, cfg probeConfig) locdoc.Fetcher {
+// 2. If the response is a challenge, login or empty page → fall back to Rod
+// 3. Detect framework
+// 4. If known framework → use HTTP or Rod based on RequiresJSForFramework
+// 5. If unknown → Rod fetch, compare content, choose based on differences
+// 6. If HTTP fails → fall back to Rod
+func probeFetcher(ctx context.Context, probeURL string, cfg probeConfig) (locdoc.Fetcher, string) {
 	// Probe with HTTP
 	httpHTML, httpErr := cfg.HTTPFetcher.Fetch(ctx, probeURL)
 	if httpErr != nil {
 		// HTTP failed, fall back to Rod
-		return cfg.RodFetcher
+		return cfg.RodFetcher, ""
+	}
+	if !cfg.Prober.IsUsableContent(httpHTML) {
+		return cfg.RodFetcher, "HTTP response looks like a challenge, login or empty page"
 	}
 
 	// Detect framework
 	framework := cfg.Prober.Detect(httpHTML)
-	requiresJS, known := cfg.Prober.RequiresJS(framework)
+	requiresJS, known := cfg.Prober.RequiresJSForFramework(framework)
 
 	if known {
 		if requiresJS {
-			return cfg.RodFetcher
+			return cfg.RodFetcher, ""
 		}
-		return cfg.HTTPFetcher
+		return cfg.HTTPFetcher, ""
 	}
 
 	// Unknown framework: compare HTTP vs Rod content
 	rodHTML, rodErr := cfg.RodFetcher.Fetch(ctx, probeURL)
 	if rodErr != nil {
 		// Rod failed, use HTTP
-		return cfg.HTTPFetcher
+		return cfg.HTTPFetcher, ""
 	}
 
 	if ContentDiffers(httpHTML, rodHTML, cfg.Extractor) {
-		return cfg.RodFetcher
+		return cfg.RodFetcher, ""
+	}
+	return cfg.HTTPFetcher, ""
+}
+
+// selectFetcher returns the fetcher for a project's crawl. A fetcher type
+// cached on the project is used directly; otherwise probeURL is probed, and
+// a ProgressRetryAlternate event warns when its HTTP response was unusable.
+func (c *Crawler) selectFetcher(ctx context.Context, project *locdoc.Project, probeURL string, progress ProgressFunc) (locdoc.Fetcher, locdoc.FetcherType) {
+	switch project.FetcherType {
+	case locdoc.FetcherTypeHTTP:
+		return c.HTTPFetcher, locdoc.FetcherTypeHTTP
+	case locdoc.FetcherTypeRod:
+		return c.RodFetcher, locdoc.FetcherTypeRod
+	}
+
+	cfg := probeConfig{
+		HTTPFetcher: c.HTTPFetcher,
+		RodFetcher:  c.RodFetcher,
+		Prober:      c.Prober,
+		Extractor:   c.Extractor,
 	}
-	return cfg.HTTPFetcher
+	fetcher, warning := probeFetcher(ctx, probeURL, cfg)
+	if warning != "" && progress != nil {
+		progress(ProgressEvent{
+			Type:   ProgressRetryAlternate,
+			URL:    probeURL,
+			Reason: warning,
+		})
+	}
+	if fetcher == c.HTTPFetcher {
+		return fetcher, locdoc.FetcherTypeHTTP
+	}
+	return fetcher, locdoc.FetcherTypeRod
+}
+
+// txBeginner is implemented by document stores that support transactions.
+type txBeginner interface {
+	BeginTx(ctx context.Context) (locdoc.DocumentTx, error)
+}
+
+// documentDeleter is implemented by document stores that can remove the
+// documents of a failed crawl.
+type documentDeleter interface {
+	BulkDeleteDocuments(ctx context.Context, ids []string) error
 }
 
 // CrawlProject crawls all pages for a project and saves them as documents.
 // The progress callback, if provided, receives events as crawling proceeds.
+//
+// If Documents supports transactions, each page is saved in its own short
+// transaction, so the database stays available to other users during a
+// long crawl and pages saved before an interruption are kept. If the crawl
+// returns an error, the documents it created are deleted again when
+// Documents supports it; pages updated in place by UseUpsert are kept.
+// Stored documents that changed pages replace are deleted only once the
+// crawl has succeeded, so a failed crawl leaves them in place. A DryRun
+// crawl writes nothing.
 func (c *Crawler) CrawlProject(ctx context.Context, project *locdoc.Project, progress ProgressFunc) (*Result, error) {
-	// Reconstruct URLFilter from project's stored filter patterns
-	var urlFilter *locdoc.URLFilter
-	if project.Filter != "" {
-		urlFilter = &locdoc.URLFilter{}
-		for _, pattern := range strings.Split(project.Filter, "\n") {
-			if pattern == "" {
-				continue
+	var writes crawlWrites
+	result, err := c.crawlProject(ctx, project, &writes, progress)
+	if err == nil && len(writes.replaced) > 0 {
+		// A canceled crawl still succeeds with the pages it saved
+		if delErr := c.deleteReplaced(context.WithoutCancel(ctx), writes.replaced); delErr != nil {
+			err = fmt.Errorf("delete replaced documents: %w", delErr)
+		}
+	}
+	if err != nil {
+		if deleter, ok := c.Documents.(documentDeleter); ok && len(writes.created) > 0 {
+			// Clean up even when the crawl failed because ctx was canceled
+			if delErr := deleter.BulkDeleteDocuments(context.WithoutCancel(ctx), writes.created); delErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("delete documents of failed crawl: %w", delErr))
 			}
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				return nil, fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+		}
+		return nil, err
+	}
+	result.StopReason = c.stopReason(ctx, result)
+	return result, nil
+}
+
+// crawlWrites records the documents a crawl has written, so they can be
+// undone if it fails, and the stored documents they replace, which are
+// deleted once it succeeds.
+type crawlWrites struct {
+	created  []string
+	replaced []string
+}
+
+// deleteReplaced deletes the documents with the given IDs from Existing,
+// all in one transaction when Existing supports them.
+func (c *Crawler) deleteReplaced(ctx context.Context, ids []string) error {
+	beginner, ok := c.Existing.(txBeginner)
+	if !ok {
+		return deleteDocuments(ctx, c.Existing, ids)
+	}
+	tx, err := beginner.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := deleteDocuments(ctx, tx, ids); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// deleteDocuments deletes the documents with the given IDs from store.
+func deleteDocuments(ctx context.Context, store locdoc.DocumentService, ids []string) error {
+	for _, id := range ids {
+		if err := store.DeleteDocument(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopReason explains why a crawl left discovered URLs unvisited.
+func (c *Crawler) stopReason(ctx context.Context, result *Result) string {
+	switch {
+	case len(result.Unvisited) == 0:
+		return ""
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return "timed out"
+	case ctx.Err() != nil:
+		return "canceled"
+	case c.byteLimitReached(result.Bytes):
+		return "byte limit reached"
+	default:
+		return "crawl limit reached"
+	}
+}
+
+// DiscoverURLs returns the URLs CrawlProject would crawl for a project
+// without fetching page content for conversion or saving any documents.
+// URLs come from the sitemap; when the sitemap yields none, or Sitemaps is
+// nil, and recursive crawling is configured, links are discovered by
+// walking the site from project.SourceURL. A WithOnURL callback is invoked
+// for every URL, whichever source it came from.
+func (c *Crawler) DiscoverURLs(
+	ctx context.Context,
+	project *locdoc.Project,
+	urlFilter *locdoc.URLFilter,
+	opts ...DiscoverOption,
+) ([]string, error) {
+	if c.Sitemaps != nil {
+		urls, err := c.sitemapURLs(ctx, project, urlFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(urls) > 0 {
+			cfg := &discoverConfig{}
+			for _, opt := range opts {
+				opt(cfg)
+			}
+			if cfg.onURL != nil {
+				for _, u := range urls {
+					cfg.onURL(u)
+				}
 			}
-			urlFilter.Include = append(urlFilter.Include, re)
+			return urls, nil
 		}
 	}
 
-	// Discover URLs from sitemap
+	// Fall back to recursive discovery if LinkSelectors is configured
+	if c.LinkSelectors == nil || c.RateLimiter == nil {
+		return nil, nil
+	}
+	return c.discoverRecursive(ctx, project.SourceURL, urlFilter, opts...)
+}
+
+// sitemapURLs discovers the project's URLs from its sitemap.
+// A site without a sitemap yields no URLs so callers fall back to
+// recursive discovery; any other error is returned.
+func (c *Crawler) sitemapURLs(ctx context.Context, project *locdoc.Project, urlFilter *locdoc.URLFilter) ([]string, error) {
 	urls, err := c.Sitemaps.DiscoverURLs(ctx, project.SourceURL, urlFilter)
+	if locdoc.ErrorCode(err) == locdoc.ENOTFOUND {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("sitemap discovery: %w", err)
 	}
+	return urls, nil
+}
+
+// crawlProject performs the crawl, recording the documents it writes in
+// writes.
+func (c *Crawler) crawlProject(ctx context.Context, project *locdoc.Project, writes *crawlWrites, progress ProgressFunc) (*Result, error) {
+	// Reconstruct URLFilter from project's stored filter patterns
+	var urlFilter *locdoc.URLFilter
+	if project.Filter != "" {
+		var err error
+		urlFilter, err = locdoc.ParseStoredURLFilter(project.Filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Discover URLs from sitemap
+	urls, err := c.sitemapURLs(ctx, project, urlFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	if len(urls) == 0 {
 		// Fall back to recursive crawling if LinkSelectors is configured
 		if c.LinkSelectors != nil && c.RateLimiter != nil {
-			cfg := probeConfig{
-				HTTPFetcher: c.HTTPFetcher,
-				RodFetcher:  c.RodFetcher,
-				Prober:      c.Prober,
-				Extractor:   c.Extractor,
+			fetcher, fetcherType := c.selectFetcher(ctx, project, project.SourceURL, progress)
+			result, err := c.recursiveCrawl(ctx, project, urlFilter, fetcher, writes, progress)
+			if err != nil {
+				return nil, err
 			}
-			fetcher := probeFetcher(ctx, project.SourceURL, cfg)
-			return c.recursiveCrawl(ctx, project, urlFilter, fetcher, progress)
+			result.FetcherType = fetcherType
+			return result, nil
 		}
 		return &Result{}, nil
 	}
@@ -175,20 +579,21 @@ func (c *Crawler) CrawlProject(ctx context.Context, project *locdoc.Project, pro
 	}
 
 	// Probe first URL to determine which fetcher to use
-	cfg := probeConfig{
-		HTTPFetcher: c.HTTPFetcher,
-		RodFetcher:  c.RodFetcher,
-		Prober:      c.Prober,
-		Extractor:   c.Extractor,
-	}
-	fetcher := probeFetcher(ctx, urls[0], cfg)
+	fetcher, fetcherType := c.selectFetcher(ctx, project, urls[0], progress)
 
-	// Start workers
+	// Start workers. Dispatch stops once the pages fetched so far reach
+	// MaxBytes; URLs from dispatched onwards are never fetched.
 	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
+	var limitReached atomic.Bool
+	dispatched := len(urls)
 	go func() {
 		for i, url := range urls {
+			if limitReached.Load() {
+				dispatched = i
+				break
+			}
 			i, url := i, url
 			g.Go(func() error {
 				result := c.processURL(gctx, i, url, fetcher)
@@ -203,12 +608,33 @@ func (c *Crawler) CrawlProject(ctx context.Context, project *locdoc.Project, pro
 	// Collect results in order
 	results := make([]crawlResult, len(urls))
 	var failedCount int
+	var skipped []SkippedPage
+	var transferBytes int
+	var fetchedBytes int
+	var crawlErrors []CrawlError
 	for result := range resultCh {
 		completed.Add(1)
 		results[result.position] = result
+		transferBytes += result.transfer
+		if result.err == nil && result.skipReason == "" {
+			fetchedBytes += len(result.markdown)
+			if c.byteLimitReached(fetchedBytes) {
+				limitReached.Store(true)
+			}
+		}
+
+		if result.altReason != "" && progress != nil {
+			progress(ProgressEvent{
+				Type:   ProgressRetryAlternate,
+				Total:  total,
+				URL:    result.url,
+				Reason: result.altReason,
+			})
+		}
 
 		if result.err != nil {
 			failedCount++
+			crawlErrors = append(crawlErrors, CrawlError{URL: result.url, Err: result.err, Attempt: result.attempts})
 			if progress != nil {
 				progress(ProgressEvent{
 					Type:      ProgressFailed,
@@ -218,6 +644,17 @@ func (c *Crawler) CrawlProject(ctx context.Context, project *locdoc.Project, pro
 					Error:     result.err,
 				})
 			}
+		} else if result.skipReason != "" {
+			skipped = append(skipped, SkippedPage{URL: result.url, Reason: result.skipReason})
+			if progress != nil {
+				progress(ProgressEvent{
+					Type:      ProgressSkipped,
+					Completed: int(completed.Load()),
+					Total:     total,
+					URL:       result.url,
+					Reason:    result.skipReason,
+				})
+			}
 		} else {
 			if progress != nil {
 				progress(ProgressEvent{
@@ -234,33 +671,70 @@ func (c *Crawler) CrawlProject(ctx context.Context, project *locdoc.Project, pro
 	var savedCount int
 	var totalBytes int
 	var totalTokens int
+	var wouldSave []PreviewDocument
 
-	for _, result := range results {
-		if result.err != nil {
+	for _, result := range results[:dispatched] {
+		if result.err != nil || result.skipReason != "" {
+			continue
+		}
+		// Pages fetched before dispatch stopped are kept only up to MaxBytes
+		if c.byteLimitReached(totalBytes) {
+			skipped = append(skipped, SkippedPage{URL: result.url, Reason: "byte limit reached"})
 			continue
 		}
 
 		doc := &locdoc.Document{
-			ProjectID:   project.ID,
-			SourceURL:   result.url,
-			Title:       result.title,
-			Content:     result.markdown,
-			ContentHash: result.hash,
-			Position:    result.position,
+			ProjectID:        project.ID,
+			SourceURL:        result.url,
+			Title:            result.title,
+			Content:          result.markdown,
+			ContentHash:      result.hash,
+			Position:         result.position,
+			ReadabilityScore: result.readability,
+			Language:         result.language,
+			Summary:          result.summary,
+			ExtractedHTML:    result.extracted,
+		}
+		if doc = c.transformDocument(doc); doc == nil {
+			skipped = append(skipped, SkippedPage{URL: result.url, Reason: "dropped by transformer"})
+			continue
 		}
 
-		if err := c.Documents.CreateDocument(ctx, doc); err != nil {
+		saved, err := c.saveDocument(ctx, writes, doc)
+		if err != nil {
 			failedCount++
+			crawlErrors = append(crawlErrors, CrawlError{URL: result.url, Err: err, Attempt: result.attempts})
+			continue
+		}
+		if !saved {
+			skipped = append(skipped, SkippedPage{URL: result.url, Reason: "unchanged"})
 			continue
 		}
 
-		savedCount++
-		totalBytes += len(result.markdown)
-		if c.TokenCounter != nil {
-			if tokens, err := c.TokenCounter.CountTokens(ctx, result.markdown); err == nil {
-				totalTokens += tokens
-			}
+		tokens := c.countTokens(ctx, doc.Content)
+		if c.DryRun {
+			wouldSave = append(wouldSave, PreviewDocument{
+				URL:    doc.SourceURL,
+				Title:  doc.Title,
+				Bytes:  len(doc.Content),
+				Tokens: tokens,
+			})
+		} else {
+			savedCount++
 		}
+		totalBytes += len(doc.Content)
+		totalTokens += tokens
+	}
+
+	var unvisited []string
+	if dispatched < len(urls) {
+		unvisited = urls[dispatched:]
+	}
+	if c.byteLimitReached(totalBytes) && progress != nil {
+		progress(ProgressEvent{
+			Type:   ProgressLimitReached,
+			Reason: "byte limit",
+		})
 	}
 
 	// Notify finished
@@ -272,12 +746,137 @@ func (c *Crawler) CrawlProject(ctx context.Context, project *locdoc.Project, pro
 		})
 	}
 
-	return &Result{
-		Saved:  savedCount,
-		Failed: failedCount,
-		Bytes:  totalBytes,
-		Tokens: totalTokens,
-	}, nil
+	res := &Result{
+		Saved:   savedCount,
+		Failed:  failedCount,
+		Skipped: len(skipped),
+		Bytes:   totalBytes,
+		Tokens:  totalTokens,
+
+		TransferBytes: transferBytes,
+		FetcherType:   fetcherType,
+		Errors:        crawlErrors,
+		SkippedPages:  skipped,
+		Unvisited:     unvisited,
+		WouldSave:     wouldSave,
+	}
+	for i := range results[:dispatched] {
+		res.countFailedStep(&results[i])
+	}
+	return res, nil
+}
+
+// byteLimitReached reports whether n bytes of saved content reach MaxBytes.
+func (c *Crawler) byteLimitReached(n int) bool {
+	return c.MaxBytes > 0 && int64(n) >= c.MaxBytes
+}
+
+// countTokens returns the number of tokens in content, or zero when no
+// TokenCounter is set or counting fails.
+func (c *Crawler) countTokens(ctx context.Context, content string) int {
+	if c.TokenCounter == nil {
+		return 0
+	}
+	tokens, err := c.TokenCounter.CountTokens(ctx, content)
+	if err != nil {
+		return 0
+	}
+	return tokens
+}
+
+// transformDocument passes doc through DocumentTransformer, if set.
+func (c *Crawler) transformDocument(doc *locdoc.Document) *locdoc.Document {
+	if c.DocumentTransformer == nil {
+		return doc
+	}
+	return c.DocumentTransformer(doc)
+}
+
+// saveDocument stores doc in Documents, within a transaction of its own
+// when Documents supports them. When Existing is set, a stored document with
+// the same URL and content hash makes it a no-op that returns false, and a
+// stored document with different content is recorded in writes as replaced,
+// unless UseUpsert updates it in place. In a DryRun nothing is written, but
+// the return value still reports whether doc would be saved. The IDs of
+// created documents are recorded in writes.
+func (c *Crawler) saveDocument(ctx context.Context, writes *crawlWrites, doc *locdoc.Document) (bool, error) {
+	var existing []*locdoc.Document
+	if c.Existing != nil {
+		var err error
+		existing, err = c.Existing.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &doc.ProjectID,
+			SourceURL: &doc.SourceURL,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, old := range existing {
+			if old.ContentHash == doc.ContentHash {
+				return false, nil
+			}
+		}
+	}
+
+	if c.DryRun {
+		return true, nil
+	}
+	// Encode before any transaction starts, so the network call doesn't
+	// hold the database
+	if c.VectorEncoder != nil {
+		embedding, err := c.VectorEncoder.EncodeText(ctx, doc.Content)
+		if err != nil {
+			return false, err
+		}
+		doc.Embedding = embedding
+	}
+
+	beginner, ok := c.Documents.(txBeginner)
+	if !ok {
+		if err := c.writeDocument(ctx, c.Documents, doc); err != nil {
+			return false, err
+		}
+	} else {
+		tx, err := beginner.BeginTx(ctx)
+		if err != nil {
+			return false, fmt.Errorf("begin transaction: %w", err)
+		}
+		if err := c.writeDocument(ctx, tx, doc); err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if err := tx.Commit(); err != nil {
+			return false, fmt.Errorf("commit transaction: %w", err)
+		}
+	}
+
+	if !c.UseUpsert {
+		writes.created = append(writes.created, doc.ID)
+		for _, old := range existing {
+			writes.replaced = append(writes.replaced, old.ID)
+		}
+	}
+	if c.OnDocumentSaved != nil {
+		c.OnDocumentSaved(doc)
+	}
+	return true, nil
+}
+
+// writeDocument writes doc to docs, updating the stored document for its
+// URL in place when UseUpsert is set.
+func (c *Crawler) writeDocument(ctx context.Context, docs locdoc.DocumentWriter, doc *locdoc.Document) error {
+	if c.UseUpsert {
+		return docs.UpsertDocument(ctx, doc)
+	}
+	return docs.CreateDocument(ctx, doc)
+}
+
+// hash returns the content hash of markdown using HashFunc, or ComputeHash
+// when it is not set.
+func (c *Crawler) hash(markdown string) string {
+	if c.HashFunc != nil {
+		return c.HashFunc(markdown)
+	}
+	return computeHash(markdown)
 }
 
 // processURL fetches and processes a single URL.
@@ -292,32 +891,131 @@ func (c *Crawler) processURL(ctx context.Context, position int, url string, fetc
 	if delays == nil {
 		delays = DefaultRetryDelays()
 	}
+	delays = JitteredRetryDelays(delays, c.RetryJitter)
+	html, err := c.fetchWithRetry(ctx, url, fetcher, delays, &result)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.transfer = len(html)
+
+	c.convertPage(html, &result)
+	return result
+}
+
+// fetchWithRetry fetches url with retries. When RetryWithAlternate is set and
+// the HTTP fetcher exhausts its retries, the page is fetched once more with
+// RodFetcher and result.altReason records why.
+func (c *Crawler) fetchWithRetry(ctx context.Context, url string, fetcher locdoc.Fetcher, delays []time.Duration, result *crawlResult) (string, error) {
 	fetchFn := func(ctx context.Context, url string) (string, error) {
+		result.attempts++
 		return fetcher.Fetch(ctx, url)
 	}
 	html, err := FetchWithRetryDelays(ctx, url, fetchFn, nil, delays)
-	if err != nil {
-		result.err = err
-		return result
+	if err == nil || !c.RetryWithAlternate || c.RodFetcher == nil || fetcher != c.HTTPFetcher || ctx.Err() != nil {
+		return html, err
 	}
 
+	result.altReason = err.Error()
+	result.attempts++
+	return c.RodFetcher.Fetch(ctx, url)
+}
+
+// convertPage extracts the main content from html and converts it to markdown,
+// recording the outcome in result. Pages whose extracted content is shorter
+// than MinContentLength are marked as skipped rather than converted.
+func (c *Crawler) convertPage(html string, result *crawlResult) {
 	// Extract content
-	extracted, err := c.Extractor.Extract(html)
+	extracted, err := c.extract(html)
 	if err != nil {
 		result.err = err
-		return result
+		result.failedStep = "extract"
+		return
+	}
+
+	if !matchesLanguage(extracted.Language, c.Language) {
+		result.skipReason = "language " + extracted.Language
+		return
+	}
+
+	if c.MinContentLength > 0 && len(extracted.ContentHTML) < c.MinContentLength {
+		result.skipReason = "content too short"
+		return
 	}
 
 	// Convert to markdown
-	markdown, err := c.Converter.Convert(extracted.ContentHTML)
+	markdown, err := c.convert(extracted.ContentHTML)
 	if err != nil {
 		result.err = err
-		return result
+		result.failedStep = "convert"
+		return
+	}
+
+	if c.ContentScorer != nil && c.ContentScorer.Score(markdown) < c.MinQualityScore {
+		result.skipReason = "low quality"
+		return
 	}
 
 	result.title = extracted.Title
 	result.markdown = markdown
-	result.hash = computeHash(markdown)
+	result.hash = c.hash(markdown)
+	result.readability = ReadabilityScore(markdown)
+	result.language = extracted.Language
+	result.summary = extracted.OGDescription
+	if c.StoreExtractedHTML {
+		result.extracted = extracted.ContentHTML
+	}
+}
 
-	return result
+// extract extracts the main content of html, attempting up to
+// ExtractRetries times.
+func (c *Crawler) extract(html string) (*locdoc.ExtractResult, error) {
+	var err error
+	for range max(c.ExtractRetries, 1) {
+		var extracted *locdoc.ExtractResult
+		if extracted, err = c.Extractor.Extract(html); err == nil {
+			return extracted, nil
+		}
+	}
+	return nil, err
+}
+
+// convert converts html to markdown, attempting up to ConvertRetries times.
+func (c *Crawler) convert(html string) (string, error) {
+	var err error
+	for range max(c.ConvertRetries, 1) {
+		var markdown string
+		if markdown, err = c.Converter.Convert(html); err == nil {
+			return markdown, nil
+		}
+	}
+	return "", err
+}
+
+// countFailedStep adds a failed page to ExtractFailed or ConvertFailed
+// when extraction or conversion caused the failure.
+func (r *Result) countFailedStep(res *crawlResult) {
+	switch res.failedStep {
+	case "extract":
+		r.ExtractFailed++
+	case "convert":
+		r.ConvertFailed++
+	}
+}
+
+// skip records url as skipped for reason.
+func (r *Result) skip(url, reason string) {
+	r.Skipped++
+	r.SkippedPages = append(r.SkippedPages, SkippedPage{URL: url, Reason: reason})
+}
+
+// matchesLanguage reports whether a page declaring lang passes a filter for
+// want. Tags are compared case-insensitively and a primary tag matches its
+// regional variants. Empty lang or want always match.
+func matchesLanguage(lang, want string) bool {
+	if lang == "" || want == "" {
+		return true
+	}
+	lang, want = strings.ToLower(lang), strings.ToLower(want)
+	return lang == want || strings.HasPrefix(lang, want+"-")
 }
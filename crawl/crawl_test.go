@@ -2,12 +2,19 @@ package crawl_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/crawl"
 	"github.com/fwojciec/locdoc/mock"
+	"github.com/fwojciec/locdoc/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,10 +23,10 @@ import (
 // All mocks return minimal successful responses by default.
 // Use the returned mocks struct to customize behavior for specific tests.
 //
-// Composes a Discoverer (via newTestDiscoverer) with Crawler-specific mocks
-// for storage (Sitemaps, Converter, Documents, TokenCounter).
+// Extends the discovery Crawler from newTestDiscoverer with mocks for
+// storage (Sitemaps, Converter, Documents, TokenCounter).
 func newTestCrawler() (*crawl.Crawler, *crawlerMocks) {
-	d, dm := newTestDiscoverer()
+	c, dm := newTestDiscoverer()
 
 	m := &crawlerMocks{
 		discovererMocks: dm,
@@ -33,7 +40,7 @@ func newTestCrawler() (*crawl.Crawler, *crawlerMocks) {
 				return "Content", nil
 			},
 		},
-		Documents: &mock.DocumentService{
+		Documents: &mock.DocumentWriter{
 			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
 				return nil
 			},
@@ -45,72 +52,25 @@ func newTestCrawler() (*crawl.Crawler, *crawlerMocks) {
 		},
 	}
 
-	c := &crawl.Crawler{
-		Discoverer:   d,
-		Sitemaps:     m.Sitemaps,
-		Converter:    m.Converter,
-		Documents:    m.Documents,
-		TokenCounter: m.TokenCounter,
-	}
+	c.Sitemaps = m.Sitemaps
+	c.Converter = m.Converter
+	c.Documents = m.Documents
+	c.TokenCounter = m.TokenCounter
 
 	return c, m
 }
 
 // crawlerMocks holds references to all mocks used by newTestCrawler.
-// Embeds discovererMocks to provide access to Discoverer mocks.
+// Embeds discovererMocks to provide access to the discovery mocks.
 // Tests can modify the function fields to customize behavior.
 type crawlerMocks struct {
 	*discovererMocks
 	Sitemaps     *mock.SitemapService
 	Converter    *mock.Converter
-	Documents    *mock.DocumentService
+	Documents    *mock.DocumentWriter
 	TokenCounter *mock.TokenCounter
 }
 
-func TestCrawler_EmbedsDiscoverer(t *testing.T) {
-	t.Parallel()
-
-	t.Run("Crawler fields are accessible through embedded Discoverer", func(t *testing.T) {
-		t.Parallel()
-
-		httpFetcher := &mock.Fetcher{}
-		rodFetcher := &mock.Fetcher{}
-		prober := &mock.Prober{}
-		extractor := &mock.Extractor{}
-		linkSelectors := &mock.LinkSelectorRegistry{}
-		rateLimiter := &mock.DomainLimiter{}
-
-		discoverer := &crawl.Discoverer{
-			HTTPFetcher:   httpFetcher,
-			RodFetcher:    rodFetcher,
-			Prober:        prober,
-			Extractor:     extractor,
-			LinkSelectors: linkSelectors,
-			RateLimiter:   rateLimiter,
-			Concurrency:   5,
-			RetryDelays:   []time.Duration{100 * time.Millisecond},
-		}
-
-		c := &crawl.Crawler{
-			Discoverer:   discoverer,
-			Sitemaps:     &mock.SitemapService{},
-			Converter:    &mock.Converter{},
-			Documents:    &mock.DocumentService{},
-			TokenCounter: &mock.TokenCounter{},
-		}
-
-		// Verify embedded fields are accessible directly on Crawler
-		assert.Same(t, httpFetcher, c.HTTPFetcher)
-		assert.Same(t, rodFetcher, c.RodFetcher)
-		assert.Same(t, prober, c.Prober)
-		assert.Same(t, extractor, c.Extractor)
-		assert.Same(t, linkSelectors, c.LinkSelectors)
-		assert.Same(t, rateLimiter, c.RateLimiter)
-		assert.Equal(t, 5, c.Concurrency)
-		assert.Equal(t, []time.Duration{100 * time.Millisecond}, c.RetryDelays)
-	})
-}
-
 func TestCrawler_CrawlProject(t *testing.T) {
 	t.Parallel()
 
@@ -118,21 +78,19 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		t.Parallel()
 
 		c := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher: &mock.Fetcher{},
-				RodFetcher:  &mock.Fetcher{},
-				Extractor:   &mock.Extractor{},
-				Concurrency: 10,
-				RetryDelays: []time.Duration{0}, // no delay for tests
-				// Note: no LinkSelectors or RateLimiter - no fallback crawling
-			},
+			HTTPFetcher: &mock.Fetcher{},
+			RodFetcher:  &mock.Fetcher{},
+			Extractor:   &mock.Extractor{},
+			Concurrency: 10,
+			RetryDelays: []time.Duration{0}, // no delay for tests
+			// Note: no LinkSelectors or RateLimiter - no fallback crawling
 			Sitemaps: &mock.SitemapService{
 				DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
 					return []string{}, nil
 				},
 			},
 			Converter:    &mock.Converter{},
-			Documents:    &mock.DocumentService{},
+			Documents:    &mock.DocumentWriter{},
 			TokenCounter: &mock.TokenCounter{},
 		}
 
@@ -174,49 +132,47 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		}
 
 		c := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher: &mock.Fetcher{FetchFn: fetchFn},
-				RodFetcher:  &mock.Fetcher{FetchFn: fetchFn},
-				Prober: &mock.Prober{
-					DetectFn: func(_ string) locdoc.Framework {
-						return locdoc.FrameworkSphinx
-					},
-					RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
-						return false, true
-					},
+			HTTPFetcher: &mock.Fetcher{FetchFn: fetchFn},
+			RodFetcher:  &mock.Fetcher{FetchFn: fetchFn},
+			Prober: &mock.Prober{
+				DetectFn: func(_ string) locdoc.Framework {
+					return locdoc.FrameworkSphinx
 				},
-				Extractor: &mock.Extractor{
-					ExtractFn: func(html string) (*locdoc.ExtractResult, error) {
-						return &locdoc.ExtractResult{
-							Title:       "Test Page",
-							ContentHTML: "<p>Content</p>",
-						}, nil
-					},
+				RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
+					return false, true
 				},
-				LinkSelectors: &mock.LinkSelectorRegistry{
-					GetForHTMLFn: func(html string) locdoc.LinkSelector {
-						return &mock.LinkSelector{
-							ExtractLinksFn: func(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
-								// Return a link to page1 from the main page
-								if baseURL == "https://example.com/docs/" {
-									return []locdoc.DiscoveredLink{
-										{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
-									}, nil
-								}
-								return nil, nil
-							},
-							NameFn: func() string { return "test" },
-						}
-					},
+			},
+			Extractor: &mock.Extractor{
+				ExtractFn: func(html string) (*locdoc.ExtractResult, error) {
+					return &locdoc.ExtractResult{
+						Title:       "Test Page",
+						ContentHTML: "<p>Content</p>",
+					}, nil
 				},
-				RateLimiter: &mock.DomainLimiter{
-					WaitFn: func(_ context.Context, _ string) error {
-						return nil
-					},
+			},
+			LinkSelectors: &mock.LinkSelectorRegistry{
+				GetForHTMLFn: func(html string) locdoc.LinkSelector {
+					return &mock.LinkSelector{
+						ExtractLinksFn: func(html string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+							// Return a link to page1 from the main page
+							if baseURL == "https://example.com/docs/" {
+								return []locdoc.DiscoveredLink{
+									{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
+								}, nil
+							}
+							return nil, nil
+						},
+						NameFn: func() string { return "test" },
+					}
 				},
-				Concurrency: 1,
-				RetryDelays: []time.Duration{0},
 			},
+			RateLimiter: &mock.DomainLimiter{
+				WaitFn: func(_ context.Context, _ string) error {
+					return nil
+				},
+			},
+			Concurrency: 1,
+			RetryDelays: []time.Duration{0},
 			Sitemaps: &mock.SitemapService{
 				DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
 					return []string{}, nil // No sitemap URLs
@@ -227,7 +183,7 @@ func TestCrawler_CrawlProject(t *testing.T) {
 					return "Content", nil
 				},
 			},
-			Documents: &mock.DocumentService{
+			Documents: &mock.DocumentWriter{
 				CreateDocumentFn: func(_ context.Context, doc *locdoc.Document) error {
 					savedDocs = append(savedDocs, doc)
 					return nil
@@ -255,6 +211,139 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		assert.Equal(t, 3, fetchCalls, "should fetch for probe and both pages")
 	})
 
+	t.Run("falls back to recursive crawl when the site has no sitemap", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return nil, locdoc.ErrSitemapNotFound
+		}
+
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved, "should crawl the seed URL")
+	})
+
+	t.Run("returns sitemap network errors without crawling", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return nil, errors.New("connection refused")
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			t.Fatal("Fetch should not be called when sitemap discovery fails")
+			return "", nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.ErrorContains(t, err, "connection refused")
+		assert.Equal(t, locdoc.EINTERNAL, locdoc.ErrorCode(err))
+	})
+
+	t.Run("stops saving sitemap pages once MaxBytes is reached", func(t *testing.T) {
+		t.Parallel()
+
+		const numPages = 10
+		var urls []string
+		for i := 1; i <= numPages; i++ {
+			urls = append(urls, fmt.Sprintf("https://example.com/docs/page%d", i))
+		}
+
+		c, m := newTestCrawler()
+		c.MaxBytes = 2 * int64(len("Content")) // two converted pages
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return urls, nil
+		}
+
+		var limitEvents []crawl.ProgressEvent
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, func(event crawl.ProgressEvent) {
+			if event.Type == crawl.ProgressLimitReached {
+				limitEvents = append(limitEvents, event)
+			}
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 2*len("Content"), result.Bytes)
+		require.Len(t, limitEvents, 1)
+		assert.Equal(t, "byte limit", limitEvents[0].Reason)
+	})
+
+	t.Run("recursive crawl stops once MaxBytes is reached", func(t *testing.T) {
+		t.Parallel()
+
+		const numPages = 10
+
+		c, m := newTestCrawler()
+		c.Concurrency = 3
+		c.MaxBytes = 2 * int64(len("Content")) // two converted pages
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					if baseURL != "https://example.com/docs/" {
+						return nil, nil
+					}
+					// The seed page links to the other nine pages
+					var links []locdoc.DiscoveredLink
+					for i := 1; i < numPages; i++ {
+						links = append(links, locdoc.DiscoveredLink{
+							URL:      fmt.Sprintf("https://example.com/docs/page%d", i),
+							Priority: locdoc.PriorityNavigation,
+						})
+					}
+					return links, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		var limitEvents []crawl.ProgressEvent
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, func(event crawl.ProgressEvent) {
+			if event.Type == crawl.ProgressLimitReached {
+				limitEvents = append(limitEvents, event)
+			}
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 2*len("Content"), result.Bytes)
+		assert.NotEmpty(t, result.Unvisited, "pages not dispatched before the limit should be reported")
+		assert.Equal(t, "byte limit reached", result.StopReason)
+		require.Len(t, limitEvents, 1)
+		assert.Equal(t, "byte limit", limitEvents[0].Reason)
+	})
+
 	t.Run("recursive crawl respects path prefix scope", func(t *testing.T) {
 		t.Parallel()
 
@@ -267,7 +356,7 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					// Return links - one in scope, one out of scope
 					return []locdoc.DiscoveredLink{
 						{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -300,200 +389,1155 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		}
 	})
 
-	t.Run("recursive crawl uses rate limiter", func(t *testing.T) {
+	t.Run("recursive crawl saves pages under their canonical URL", func(t *testing.T) {
 		t.Parallel()
 
-		var waitCalls []string
+		var savedURLs []string
 
 		c, m := newTestCrawler()
-		m.RateLimiter.WaitFn = func(_ context.Context, domain string) error {
-			waitCalls = append(waitCalls, domain)
+		c.Concurrency = 1
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			savedURLs = append(savedURLs, doc.SourceURL)
 			return nil
 		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					switch baseURL {
+					case "https://example.com/docs/":
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/intro?ref=nav", Priority: locdoc.PriorityNavigation},
+						}, nil
+					case "https://example.com/docs/intro?ref=nav":
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/guide", Priority: locdoc.PriorityNavigation},
+							{URL: baseURL, Priority: locdoc.PriorityIgnore, CanonicalURL: "https://example.com/docs/intro"},
+						}, nil
+					case "https://example.com/docs/guide":
+						// Links to the canonical form, which must not be fetched again
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/intro", Priority: locdoc.PriorityNavigation},
+						}, nil
+					}
+					return nil, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
 
 		project := &locdoc.Project{
-			ID:        "test-id",
-			Name:      "test",
-			SourceURL: "https://example.com/docs/",
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
-		_, err := c.CrawlProject(context.Background(), project, nil)
+		result, err := c.CrawlProject(context.Background(), project, nil)
 
 		require.NoError(t, err)
-		assert.Len(t, waitCalls, 1)
-		assert.Equal(t, "example.com", waitCalls[0])
+		assert.Equal(t, []string{
+			"https://example.com/docs/",
+			"https://example.com/docs/intro",
+			"https://example.com/docs/guide",
+		}, savedURLs)
+		assert.Equal(t, 3, result.Saved)
+		assert.NotContains(t, m.HTTPFetcher.FetchedURLs(), "https://example.com/docs/intro")
 	})
 
-	t.Run("recursive crawl applies URL filter", func(t *testing.T) {
+	t.Run("recursive crawl keeps the fetched URL when the canonical URL is out of scope", func(t *testing.T) {
 		t.Parallel()
 
 		var savedURLs []string
 
 		c, m := newTestCrawler()
+		c.Concurrency = 1
 		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
 			savedURLs = append(savedURLs, doc.SourceURL)
 			return nil
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
-					// Return links - one matches filter, one doesn't
-					return []locdoc.DiscoveredLink{
-						{URL: "https://example.com/docs/guide/intro", Priority: locdoc.PriorityNavigation},
-						{URL: "https://example.com/docs/api/ref", Priority: locdoc.PriorityNavigation},
-					}, nil
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					switch baseURL {
+					case "https://example.com/docs/v2/":
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/v2/intro", Priority: locdoc.PriorityNavigation},
+						}, nil
+					case "https://example.com/docs/v2/intro":
+						// Versioned docs often point at the latest version
+						return []locdoc.DiscoveredLink{
+							{URL: baseURL, Priority: locdoc.PriorityIgnore, CanonicalURL: "https://example.com/docs/latest/intro"},
+						}, nil
+					}
+					return nil, nil
 				},
 				NameFn: func() string { return "test" },
 			}
 		}
 
 		project := &locdoc.Project{
-			ID:        "test-id",
-			Name:      "test",
-			SourceURL: "https://example.com/docs/",
-			Filter:    ".*/guide/.*", // Only allow URLs containing /guide/
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/v2/",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
 		result, err := c.CrawlProject(context.Background(), project, nil)
 
 		require.NoError(t, err)
-		require.NotNil(t, result)
-		// Should save seed URL and only the /guide/ URL
+		assert.Equal(t, []string{
+			"https://example.com/docs/v2/",
+			"https://example.com/docs/v2/intro",
+		}, savedURLs)
 		assert.Equal(t, 2, result.Saved)
-		assert.Contains(t, savedURLs, "https://example.com/docs/")
-		assert.Contains(t, savedURLs, "https://example.com/docs/guide/intro")
-		// Should NOT contain /api/ URL
-		for _, u := range savedURLs {
-			assert.NotContains(t, u, "/api/")
-		}
 	})
 
-	t.Run("recursive crawl stops on context cancellation", func(t *testing.T) {
+	t.Run("recursive crawl skips pages whose canonical URL is already queued", func(t *testing.T) {
 		t.Parallel()
 
-		crawlFetchCount := 0
-		ctx, cancel := context.WithCancel(context.Background())
+		var savedURLs []string
 
 		c, m := newTestCrawler()
-		// Use known framework to avoid probe comparison fetch
-		m.Prober.DetectFn = func(_ string) locdoc.Framework {
-			return locdoc.FrameworkSphinx
-		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
-			return false, true
-		}
-		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			crawlFetchCount++
-			return `<html><body><p>Content</p></body></html>`, nil
+		c.Concurrency = 1
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			savedURLs = append(savedURLs, doc.SourceURL)
+			return nil
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
-					// Return many links to ensure there's work queued
-					return []locdoc.DiscoveredLink{
-						{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
-						{URL: "https://example.com/docs/page2", Priority: locdoc.PriorityNavigation},
-						{URL: "https://example.com/docs/page3", Priority: locdoc.PriorityNavigation},
-					}, nil
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					switch baseURL {
+					case "https://example.com/docs/":
+						// The alias is crawled first, while the canonical form is still queued
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/intro?ref=nav", Priority: locdoc.PriorityTOC},
+							{URL: "https://example.com/docs/intro", Priority: locdoc.PriorityNavigation},
+						}, nil
+					case "https://example.com/docs/intro?ref=nav":
+						return []locdoc.DiscoveredLink{
+							{URL: baseURL, Priority: locdoc.PriorityIgnore, CanonicalURL: "https://example.com/docs/intro"},
+						}, nil
+					}
+					return nil, nil
 				},
 				NameFn: func() string { return "test" },
 			}
 		}
-		m.RateLimiter.WaitFn = func(ctx context.Context, _ string) error {
-			// Cancel after first actual crawl URL is processed (probe + 1 crawl = 2)
-			if crawlFetchCount >= 2 {
-				cancel()
-			}
-			return ctx.Err()
-		}
 
 		project := &locdoc.Project{
-			ID:        "test-id",
-			Name:      "test",
-			SourceURL: "https://example.com/docs/",
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
-		result, err := c.CrawlProject(ctx, project, nil)
+		result, err := c.CrawlProject(context.Background(), project, nil)
 
-		// Should return without error (partial results)
 		require.NoError(t, err)
-		require.NotNil(t, result)
-		// Should have processed exactly 1 URL (seed) before cancellation stopped further processing
-		assert.Equal(t, 1, result.Saved)
-		// Probe fetch + 1 actual crawl = 2 fetches
-		assert.Equal(t, 2, crawlFetchCount, "should stop after probe + 1 crawl fetch due to cancellation")
+		assert.Equal(t, []string{
+			"https://example.com/docs/",
+			"https://example.com/docs/intro",
+		}, savedURLs)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []crawl.SkippedPage{{
+			URL:    "https://example.com/docs/intro?ref=nav",
+			Reason: "duplicate of https://example.com/docs/intro",
+		}}, result.SkippedPages)
 	})
 
-	t.Run("crawls single URL and saves document", func(t *testing.T) {
+	t.Run("recursive crawl stops at MaxDepth", func(t *testing.T) {
 		t.Parallel()
 
-		var savedDoc *locdoc.Document
+		var savedURLs []string
 
 		c, m := newTestCrawler()
-		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
-			return []string{"https://example.com/page1"}, nil
-		}
-		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			return "<html><body>Test content</body></html>", nil
-		}
-		m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
-			return &locdoc.ExtractResult{
-				Title:       "Test Page",
-				ContentHTML: "<p>Test content</p>",
-			}, nil
-		}
-		m.Converter.ConvertFn = func(_ string) (string, error) {
-			return "Test content", nil
-		}
+		c.MaxDepth = 2
 		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
-			savedDoc = doc
+			savedURLs = append(savedURLs, doc.SourceURL)
 			return nil
 		}
-		m.TokenCounter.CountTokensFn = func(_ context.Context, text string) (int, error) {
-			return len(text) / 4, nil // ~4 chars per token
+		// Each page links to the next: docs/ -> page1 -> page2 -> page3
+		next := map[string]string{
+			"https://example.com/docs/":      "https://example.com/docs/page1",
+			"https://example.com/docs/page1": "https://example.com/docs/page2",
+			"https://example.com/docs/page2": "https://example.com/docs/page3",
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					if u, ok := next[baseURL]; ok {
+						return []locdoc.DiscoveredLink{{URL: u, Priority: locdoc.PriorityNavigation}}, nil
+					}
+					return nil, nil
+				},
+				NameFn: func() string { return "test" },
+			}
 		}
 
 		project := &locdoc.Project{
-			ID:        "proj-123",
-			Name:      "test",
-			SourceURL: "https://example.com",
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
 		result, err := c.CrawlProject(context.Background(), project, nil)
 
 		require.NoError(t, err)
-		require.NotNil(t, result)
-		assert.Equal(t, 1, result.Saved)
-		assert.Equal(t, 0, result.Failed)
-		assert.Equal(t, len("Test content"), result.Bytes)
-		assert.Equal(t, 3, result.Tokens) // 12 chars / 4 = 3
-
-		// Verify saved document
-		require.NotNil(t, savedDoc)
-		assert.Equal(t, "proj-123", savedDoc.ProjectID)
-		assert.Equal(t, "https://example.com/page1", savedDoc.SourceURL)
-		assert.Equal(t, "Test Page", savedDoc.Title)
-		assert.Equal(t, "Test content", savedDoc.Content)
-		assert.Equal(t, 0, savedDoc.Position)
-		assert.NotEmpty(t, savedDoc.ContentHash)
+		assert.Equal(t, []string{
+			"https://example.com/docs/",
+			"https://example.com/docs/page1",
+			"https://example.com/docs/page2",
+		}, savedURLs)
+		assert.Equal(t, 3, result.Saved)
 	})
 
-	t.Run("counts failed URLs when fetch fails", func(t *testing.T) {
+	t.Run("recursive crawl never queues blocked domains", func(t *testing.T) {
 		t.Parallel()
 
-		fetchFn := func(_ context.Context, url string) (string, error) {
-			if url == "https://example.com/page1" {
-				return "", locdoc.Errorf(locdoc.EINTERNAL, "fetch failed")
-			}
-			return "<html><body>Page 2</body></html>", nil
-		}
-
 		c, m := newTestCrawler()
-		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
-			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		c.AllowedDomains = []string{"example.com", "cdn.example.com"}
+		c.BlockedDomains = []string{"cdn.example.com"}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "<html><body>Content</body></html>", nil
 		}
-		m.HTTPFetcher.FetchFn = fetchFn
-		m.RodFetcher.FetchFn = fetchFn
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					return []locdoc.DiscoveredLink{
+						{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
+						{URL: "https://cdn.example.com/docs/bundle", Priority: locdoc.PriorityNavigation},
+					}, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		project := &locdoc.Project{
+			ID:        "test-id",
+			Name:      "test",
+			SourceURL: "https://example.com/docs/",
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		for _, u := range m.HTTPFetcher.FetchedURLs() {
+			assert.NotContains(t, u, "cdn.example.com")
+		}
+	})
+
+	t.Run("recursive crawl follows links to allowed domains", func(t *testing.T) {
+		t.Parallel()
+
+		var mu sync.Mutex
+		var savedURLs []string
+
+		c, m := newTestCrawler()
+		c.AllowedDomains = []string{"example.com", "docs.example.com"}
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			mu.Lock()
+			savedURLs = append(savedURLs, doc.SourceURL)
+			mu.Unlock()
+			return nil
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					return []locdoc.DiscoveredLink{
+						{URL: "https://docs.example.com/docs/page1", Priority: locdoc.PriorityNavigation},
+						{URL: "https://other.com/docs/page", Priority: locdoc.PriorityNavigation},
+					}, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		project := &locdoc.Project{
+			ID:        "test-id",
+			Name:      "test",
+			SourceURL: "https://example.com/docs/",
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Contains(t, savedURLs, "https://docs.example.com/docs/page1")
+		assert.NotContains(t, savedURLs, "https://other.com/docs/page")
+	})
+
+	t.Run("recursive crawl uses rate limiter", func(t *testing.T) {
+		t.Parallel()
+
+		var waitCalls []string
+
+		c, m := newTestCrawler()
+		m.RateLimiter.WaitFn = func(_ context.Context, domain string) error {
+			waitCalls = append(waitCalls, domain)
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:        "test-id",
+			Name:      "test",
+			SourceURL: "https://example.com/docs/",
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, waitCalls, 1)
+		assert.Equal(t, "example.com", waitCalls[0])
+	})
+
+	t.Run("recursive crawl applies URL filter", func(t *testing.T) {
+		t.Parallel()
+
+		var savedURLs []string
+
+		c, m := newTestCrawler()
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			savedURLs = append(savedURLs, doc.SourceURL)
+			return nil
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					// Return links - one matches filter, one doesn't
+					return []locdoc.DiscoveredLink{
+						{URL: "https://example.com/docs/guide/intro", Priority: locdoc.PriorityNavigation},
+						{URL: "https://example.com/docs/api/ref", Priority: locdoc.PriorityNavigation},
+					}, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		project := &locdoc.Project{
+			ID:        "test-id",
+			Name:      "test",
+			SourceURL: "https://example.com/docs/",
+			Filter:    ".*/guide/.*", // Only allow URLs containing /guide/
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		// Should save seed URL and only the /guide/ URL
+		assert.Equal(t, 2, result.Saved)
+		assert.Contains(t, savedURLs, "https://example.com/docs/")
+		assert.Contains(t, savedURLs, "https://example.com/docs/guide/intro")
+		// Should NOT contain /api/ URL
+		for _, u := range savedURLs {
+			assert.NotContains(t, u, "/api/")
+		}
+	})
+
+	t.Run("recursive crawl stops on context cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		crawlFetchCount := 0
+		ctx, cancel := context.WithCancel(context.Background())
+
+		c, m := newTestCrawler()
+		// Use known framework to avoid probe comparison fetch
+		m.Prober.DetectFn = func(_ string) locdoc.Framework {
+			return locdoc.FrameworkSphinx
+		}
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
+			return false, true
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			crawlFetchCount++
+			// Cancel after first actual crawl URL is fetched (probe + 1 crawl = 2)
+			if crawlFetchCount >= 2 {
+				cancel()
+			}
+			return `<html><body><p>Content</p></body></html>`, nil
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					// Return many links to ensure there's work queued
+					return []locdoc.DiscoveredLink{
+						{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
+						{URL: "https://example.com/docs/page2", Priority: locdoc.PriorityNavigation},
+						{URL: "https://example.com/docs/page3", Priority: locdoc.PriorityNavigation},
+					}, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+		m.RateLimiter.SimulateContextError = true
+
+		project := &locdoc.Project{
+			ID:        "test-id",
+			Name:      "test",
+			SourceURL: "https://example.com/docs/",
+		}
+
+		result, err := c.CrawlProject(ctx, project, nil)
+
+		// Should return without error (partial results)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		// Should have processed exactly 1 URL (seed) before cancellation stopped further processing
+		assert.Equal(t, 1, result.Saved)
+		// Probe fetch + 1 actual crawl = 2 fetches
+		assert.Equal(t, 2, crawlFetchCount, "should stop after probe + 1 crawl fetch due to cancellation")
+		assert.NotEmpty(t, result.Unvisited)
+		assert.Equal(t, "canceled", result.StopReason)
+	})
+
+	t.Run("crawls single URL and saves document", func(t *testing.T) {
+		t.Parallel()
+
+		var savedDoc *locdoc.Document
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1"}, nil
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "<html><body>Test content</body></html>", nil
+		}
+		m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
+			return &locdoc.ExtractResult{
+				Title:       "Test Page",
+				ContentHTML: "<p>Test content</p>",
+			}, nil
+		}
+		m.Converter.ConvertFn = func(_ string) (string, error) {
+			return "Test content", nil
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			savedDoc = doc
+			return nil
+		}
+		m.TokenCounter.CountTokensFn = func(_ context.Context, text string) (int, error) {
+			return len(text) / 4, nil // ~4 chars per token
+		}
+
+		project := &locdoc.Project{
+			ID:        "proj-123",
+			Name:      "test",
+			SourceURL: "https://example.com",
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, 1, result.Saved)
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, len("Test content"), result.Bytes)
+		assert.Equal(t, 3, result.Tokens) // 12 chars / 4 = 3
+
+		// Verify saved document
+		require.NotNil(t, savedDoc)
+		assert.Equal(t, "proj-123", savedDoc.ProjectID)
+		assert.Equal(t, "https://example.com/page1", savedDoc.SourceURL)
+		assert.Equal(t, "Test Page", savedDoc.Title)
+		assert.Equal(t, "Test content", savedDoc.Content)
+		assert.Equal(t, 0, savedDoc.Position)
+		assert.NotEmpty(t, savedDoc.ContentHash)
+	})
+
+	t.Run("skips pages with content shorter than MinContentLength", func(t *testing.T) {
+		t.Parallel()
+
+		var events []crawl.ProgressEvent
+
+		c, m := newTestCrawler()
+		c.MinContentLength = 500
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/stub"}, nil
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "<html><body>Redirecting...</body></html>", nil
+		}
+		m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
+			return &locdoc.ExtractResult{
+				Title:       "Stub",
+				ContentHTML: "<p>Redirecting...</p>",
+			}, nil
+		}
+		m.Converter.ConvertFn = func(_ string) (string, error) {
+			t.Error("Convert should not be called for skipped pages")
+			return "", nil
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, _ *locdoc.Document) error {
+			t.Error("CreateDocument should not be called for skipped pages")
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:        "proj-123",
+			Name:      "test",
+			SourceURL: "https://example.com",
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, func(e crawl.ProgressEvent) {
+			events = append(events, e)
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Saved)
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, 1, result.Skipped)
+
+		var skipped []crawl.ProgressEvent
+		for _, e := range events {
+			if e.Type == crawl.ProgressSkipped {
+				skipped = append(skipped, e)
+			}
+		}
+		require.Len(t, skipped, 1)
+		assert.Equal(t, "https://example.com/stub", skipped[0].URL)
+		assert.Equal(t, "content too short", skipped[0].Reason)
+	})
+
+	t.Run("stores the Open Graph description as the document summary", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/start"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "<html></html>", nil
+		}
+		m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
+			return &locdoc.ExtractResult{
+				Title:         "Start",
+				ContentHTML:   "<p>Content</p>",
+				OGDescription: "Quick start guide",
+			}, nil
+		}
+
+		var saved *locdoc.Document
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			saved = doc
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, "Quick start guide", saved.Summary)
+	})
+
+	t.Run("stores extracted HTML when StoreExtractedHTML is set", func(t *testing.T) {
+		t.Parallel()
+
+		for _, store := range []bool{true, false} {
+			c, m := newTestCrawler()
+			c.StoreExtractedHTML = store
+			m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+				return []string{"https://example.com/start"}, nil
+			}
+			m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+				return "<html></html>", nil
+			}
+			m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
+				return &locdoc.ExtractResult{Title: "Start", ContentHTML: "<article><p>Extracted</p></article>"}, nil
+			}
+
+			var saved *locdoc.Document
+			m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+				saved = doc
+				return nil
+			}
+
+			project := &locdoc.Project{
+				ID:          "proj-123",
+				Name:        "test",
+				SourceURL:   "https://example.com",
+				FetcherType: locdoc.FetcherTypeHTTP,
+			}
+
+			_, err := c.CrawlProject(context.Background(), project, nil)
+
+			require.NoError(t, err)
+			require.NotNil(t, saved)
+			if store {
+				assert.Equal(t, "<article><p>Extracted</p></article>", saved.ExtractedHTML)
+			} else {
+				assert.Empty(t, saved.ExtractedHTML)
+			}
+		}
+	})
+
+	t.Run("hashes content with HashFunc when set", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		c.HashFunc = func(_ string) string { return "fixed-hash" }
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/start"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "<html></html>", nil
+		}
+
+		var saved *locdoc.Document
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			saved = doc
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, "fixed-hash", saved.ContentHash)
+	})
+
+	t.Run("default hash is deterministic across crawls", func(t *testing.T) {
+		t.Parallel()
+
+		var hashes []string
+		for range 2 {
+			c, m := newTestCrawler()
+			m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+				return []string{"https://example.com/start"}, nil
+			}
+			m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+				return "<html></html>", nil
+			}
+			m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+				hashes = append(hashes, doc.ContentHash)
+				return nil
+			}
+
+			project := &locdoc.Project{
+				ID:          "proj-123",
+				Name:        "test",
+				SourceURL:   "https://example.com",
+				FetcherType: locdoc.FetcherTypeHTTP,
+			}
+
+			_, err := c.CrawlProject(context.Background(), project, nil)
+			require.NoError(t, err)
+		}
+
+		require.Len(t, hashes, 2)
+		assert.Equal(t, hashes[0], hashes[1])
+		assert.Equal(t, crawl.ComputeHash("Content"), hashes[0])
+	})
+
+	t.Run("stores content embedding when VectorEncoder is set", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		var encoded string
+		c.VectorEncoder = &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, text string) ([]float32, error) {
+				encoded = text
+				return []float32{0.1, 0.2, 0.3}, nil
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/start"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "<html></html>", nil
+		}
+
+		var saved *locdoc.Document
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			saved = doc
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, "Content", encoded)
+		assert.Equal(t, []float32{0.1, 0.2, 0.3}, saved.Embedding)
+	})
+
+	t.Run("skips pages in other languages when Language is set", func(t *testing.T) {
+		t.Parallel()
+
+		langs := map[string]string{
+			"https://example.com/en":      "en-US",
+			"https://example.com/fr":      "fr",
+			"https://example.com/unknown": "",
+		}
+
+		c, m := newTestCrawler()
+		c.Language = "en"
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/en", "https://example.com/fr", "https://example.com/unknown"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, url string) (string, error) {
+			return url, nil
+		}
+		m.Extractor.ExtractFn = func(html string) (*locdoc.ExtractResult, error) {
+			return &locdoc.ExtractResult{
+				Title:       "Page",
+				ContentHTML: "<p>Content</p>",
+				Language:    langs[html],
+			}, nil
+		}
+
+		var mu sync.Mutex
+		saved := make(map[string]string)
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			mu.Lock()
+			defer mu.Unlock()
+			saved[doc.SourceURL] = doc.Language
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, map[string]string{
+			"https://example.com/en":      "en-US",
+			"https://example.com/unknown": "",
+		}, saved)
+	})
+
+	t.Run("skips unchanged pages and replaces changed ones when Existing is set", func(t *testing.T) {
+		t.Parallel()
+
+		stored := map[string]*locdoc.Document{
+			"https://example.com/same":    {ID: "doc-same", ContentHash: crawl.ComputeHash("Content")},
+			"https://example.com/changed": {ID: "doc-changed", ContentHash: crawl.ComputeHash("Old content")},
+		}
+
+		c, m := newTestCrawler()
+		var mu sync.Mutex
+		var deleted []string
+		c.Existing = &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				assert.Equal(t, "proj-123", *filter.ProjectID)
+				if doc, ok := stored[*filter.SourceURL]; ok {
+					return []*locdoc.Document{doc}, nil
+				}
+				return nil, nil
+			},
+			BeginTxFn: func(_ context.Context) (locdoc.DocumentTx, error) {
+				return &mock.DocumentTx{
+					DocumentService: mock.DocumentService{
+						DeleteDocumentFn: func(_ context.Context, id string) error {
+							mu.Lock()
+							defer mu.Unlock()
+							deleted = append(deleted, id)
+							return nil
+						},
+					},
+					CommitFn: func() error { return nil },
+				}, nil
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/same", "https://example.com/changed", "https://example.com/new"}, nil
+		}
+
+		var created []string
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			mu.Lock()
+			defer mu.Unlock()
+			created = append(created, doc.SourceURL)
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []crawl.SkippedPage{{URL: "https://example.com/same", Reason: "unchanged"}}, result.SkippedPages)
+		assert.Equal(t, []string{"https://example.com/changed", "https://example.com/new"}, created)
+		assert.Equal(t, []string{"doc-changed"}, deleted)
+	})
+
+	t.Run("keeps replaced documents in sqlite when the crawl fails", func(t *testing.T) {
+		t.Parallel()
+
+		db := sqlite.NewDB(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, db.Open())
+		t.Cleanup(func() { _ = db.Close() })
+		documents := sqlite.NewDocumentService(db)
+		project := &locdoc.Project{
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+		require.NoError(t, sqlite.NewProjectService(db).CreateProject(context.Background(), project))
+		require.NoError(t, documents.CreateDocument(context.Background(), &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/page",
+			Content:   "Old content",
+		}))
+
+		c, m := newTestCrawler()
+		c.Documents = documents
+		// Replacing the old document fails after the new one was saved
+		c.Existing = &mock.DocumentService{
+			FindDocumentsFn: documents.FindDocuments,
+			BeginTxFn: func(_ context.Context) (locdoc.DocumentTx, error) {
+				return nil, errors.New("database is locked")
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page"}, nil
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.Error(t, err)
+		docs, err := documents.FindDocuments(context.Background(), locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "Old content", docs[0].Content)
+	})
+
+	t.Run("upserts changed pages instead of deleting them when UseUpsert is set", func(t *testing.T) {
+		t.Parallel()
+
+		stored := map[string]*locdoc.Document{
+			"https://example.com/same":    {ID: "doc-same", ContentHash: crawl.ComputeHash("Content")},
+			"https://example.com/changed": {ID: "doc-changed", ContentHash: crawl.ComputeHash("Old content")},
+		}
+
+		c, m := newTestCrawler()
+		c.UseUpsert = true
+		c.Existing = &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				if doc, ok := stored[*filter.SourceURL]; ok {
+					return []*locdoc.Document{doc}, nil
+				}
+				return nil, nil
+			},
+			DeleteDocumentFn: func(_ context.Context, id string) error {
+				t.Errorf("DeleteDocument called for %s", id)
+				return nil
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/same", "https://example.com/changed", "https://example.com/new"}, nil
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			t.Errorf("CreateDocument called for %s", doc.SourceURL)
+			return nil
+		}
+		var upserted []string
+		m.Documents.UpsertDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			upserted = append(upserted, doc.SourceURL)
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []string{"https://example.com/changed", "https://example.com/new"}, upserted)
+	})
+
+	t.Run("calls OnDocumentSaved for each saved document", func(t *testing.T) {
+		t.Parallel()
+
+		var urls []string
+		for i := 0; i < 10; i++ {
+			urls = append(urls, fmt.Sprintf("https://example.com/page%d", i))
+		}
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return urls, nil
+		}
+		var notified []*locdoc.Document
+		c.OnDocumentSaved = func(doc *locdoc.Document) {
+			notified = append(notified, doc)
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 10, result.Saved)
+		require.Len(t, notified, 10)
+		for i, doc := range notified {
+			assert.Equal(t, urls[i], doc.SourceURL)
+			assert.Equal(t, "proj-123", doc.ProjectID)
+			assert.Equal(t, "Content", doc.Content)
+		}
+	})
+
+	t.Run("passes documents through DocumentTransformer before saving", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}, nil
+		}
+		var saved []*locdoc.Document
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			saved = append(saved, doc)
+			return nil
+		}
+		c.DocumentTransformer = func(doc *locdoc.Document) *locdoc.Document {
+			doc.Title = strings.ToUpper(doc.Title)
+			doc.Content += "\n\n-- footer"
+			return doc
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Saved)
+		require.Len(t, saved, 3)
+		for _, doc := range saved {
+			assert.Equal(t, "TEST", doc.Title)
+			assert.Equal(t, "Content\n\n-- footer", doc.Content)
+		}
+		assert.Equal(t, 3*len("Content\n\n-- footer"), result.Bytes)
+	})
+
+	t.Run("skips documents the DocumentTransformer drops", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/a", "https://example.com/internal"}, nil
+		}
+		var saved []string
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			saved = append(saved, doc.SourceURL)
+			return nil
+		}
+		c.DocumentTransformer = func(doc *locdoc.Document) *locdoc.Document {
+			if strings.HasSuffix(doc.SourceURL, "/internal") {
+				return nil
+			}
+			return doc
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []string{"https://example.com/a"}, saved)
+	})
+
+	t.Run("lists pages without saving them when DryRun is set", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		c.DryRun = true
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/a", "https://example.com/b"}, nil
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			t.Errorf("CreateDocument called for %s in dry run", doc.SourceURL)
+			return nil
+		}
+		m.TokenCounter.CountTokensFn = func(_ context.Context, _ string) (int, error) {
+			return 2, nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Saved)
+		assert.Equal(t, []crawl.PreviewDocument{
+			{URL: "https://example.com/a", Title: "Test", Bytes: len("Content"), Tokens: 2},
+			{URL: "https://example.com/b", Title: "Test", Bytes: len("Content"), Tokens: 2},
+		}, result.WouldSave)
+		assert.Equal(t, 2*len("Content"), result.Bytes)
+		assert.Equal(t, 4, result.Tokens)
+	})
+
+	t.Run("recursive crawl lists pages without saving them when DryRun is set", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		c.DryRun = true
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			t.Errorf("CreateDocument called for %s in dry run", doc.SourceURL)
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Saved)
+		require.Len(t, result.WouldSave, 1)
+		assert.Equal(t, "https://example.com/docs/", result.WouldSave[0].URL)
+	})
+
+	t.Run("skips pages scoring below MinQualityScore", func(t *testing.T) {
+		t.Parallel()
+
+		pages := map[string]string{
+			"https://example.com/nav":   `<nav><a href="/">Home</a><a href="/docs">Docs</a><a href="/blog">Blog</a></nav>`,
+			"https://example.com/guide": `<h1>Guide</h1><p>Install the package first. Then import it in your project. Configure it with a file.</p><pre><code>go get example.com/pkg</code></pre>`,
+		}
+		markdown := map[string]string{
+			pages["https://example.com/nav"]:   "- [Home](/)\n- [Docs](/docs)\n- [Blog](/blog)\n",
+			pages["https://example.com/guide"]: "# Guide\n\nInstall the package first. Then import it in your project. Configure it with a file.\n\n```\ngo get example.com/pkg\n```\n",
+		}
+
+		var mu sync.Mutex
+		var events []crawl.ProgressEvent
+		var savedURLs []string
+
+		c, m := newTestCrawler()
+		c.ContentScorer = crawl.NewBasicContentScorer()
+		c.MinQualityScore = crawl.DefaultMinQualityScore
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/nav", "https://example.com/guide"}, nil
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, url string) (string, error) {
+			return pages[url], nil
+		}
+		m.Extractor.ExtractFn = func(html string) (*locdoc.ExtractResult, error) {
+			return &locdoc.ExtractResult{Title: "Page", ContentHTML: html}, nil
+		}
+		m.Converter.ConvertFn = func(html string) (string, error) {
+			return markdown[html], nil
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			mu.Lock()
+			savedURLs = append(savedURLs, doc.SourceURL)
+			mu.Unlock()
+			return nil
+		}
+
+		project := &locdoc.Project{
+			ID:        "proj-123",
+			Name:      "test",
+			SourceURL: "https://example.com",
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, func(e crawl.ProgressEvent) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []string{"https://example.com/guide"}, savedURLs)
+
+		var skipped []crawl.ProgressEvent
+		for _, e := range events {
+			if e.Type == crawl.ProgressSkipped {
+				skipped = append(skipped, e)
+			}
+		}
+		require.Len(t, skipped, 1)
+		assert.Equal(t, "https://example.com/nav", skipped[0].URL)
+		assert.Equal(t, "low quality", skipped[0].Reason)
+	})
+
+	t.Run("counts failed URLs when fetch fails", func(t *testing.T) {
+		t.Parallel()
+
+		fetchFn := func(_ context.Context, url string) (string, error) {
+			if url == "https://example.com/page1" {
+				return "", locdoc.Errorf(locdoc.EINTERNAL, "fetch failed")
+			}
+			return "<html><body>Page 2</body></html>", nil
+		}
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+		m.HTTPFetcher.FetchFn = fetchFn
+		m.RodFetcher.FetchFn = fetchFn
 		m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
 			return &locdoc.ExtractResult{
 				Title:       "Page 2",
@@ -521,6 +1565,115 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		assert.Equal(t, 1, result.Failed)
 	})
 
+	t.Run("retries extraction up to ExtractRetries times", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		c, m := newTestCrawler()
+		c.ExtractRetries = 2
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1"}, nil
+		}
+		m.Extractor.ExtractFn = func(_ string) (*locdoc.ExtractResult, error) {
+			if calls.Add(1) == 1 {
+				return nil, locdoc.Errorf(locdoc.EINTERNAL, "extractor timed out")
+			}
+			return &locdoc.ExtractResult{Title: "Page 1", ContentHTML: "<p>Page 1 content</p>"}, nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved)
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("counts pages failing extraction or conversion after all retries", func(t *testing.T) {
+		t.Parallel()
+
+		var convertCalls atomic.Int32
+		c, m := newTestCrawler()
+		c.ExtractRetries = 2
+		c.ConvertRetries = 3
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/bad-extract", "https://example.com/bad-convert"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, url string) (string, error) {
+			return url, nil // the page body is its URL
+		}
+		m.Extractor.ExtractFn = func(html string) (*locdoc.ExtractResult, error) {
+			if strings.HasSuffix(html, "bad-extract") {
+				return nil, locdoc.Errorf(locdoc.EINTERNAL, "extraction failed")
+			}
+			return &locdoc.ExtractResult{Title: "Page", ContentHTML: "<p>Page content</p>"}, nil
+		}
+		m.Converter.ConvertFn = func(_ string) (string, error) {
+			convertCalls.Add(1)
+			return "", locdoc.Errorf(locdoc.EINTERNAL, "conversion failed")
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, 1, result.ExtractFailed)
+		assert.Equal(t, 1, result.ConvertFailed)
+		assert.Equal(t, int32(3), convertCalls.Load())
+	})
+
+	t.Run("records each failed URL in Errors", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/a", "https://example.com/ok", "https://example.com/b"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, url string) (string, error) {
+			if url == "https://example.com/ok" {
+				return "<html><body>OK</body></html>", nil
+			}
+			return "", locdoc.Errorf(locdoc.EINTERNAL, "fetch failed: %s", url)
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Failed)
+		require.Len(t, result.Errors, 2)
+
+		messages := make(map[string]string)
+		for _, e := range result.Errors {
+			messages[e.URL] = locdoc.ErrorMessage(e.Err)
+			assert.Equal(t, 2, e.Attempt, "one attempt plus one retry")
+		}
+		assert.Equal(t, map[string]string{
+			"https://example.com/a": "fetch failed: https://example.com/a",
+			"https://example.com/b": "fetch failed: https://example.com/b",
+		}, messages)
+	})
+
 	t.Run("counts failed URLs when CreateDocument fails", func(t *testing.T) {
 		t.Parallel()
 
@@ -620,7 +1773,75 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					if baseURL == "https://example.com/docs/" {
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
+						}, nil
+					}
+					return nil, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		project := &locdoc.Project{
+			ID:        "proj-123",
+			Name:      "test",
+			SourceURL: "https://example.com/docs/",
+		}
+
+		var events []crawl.ProgressEvent
+		progress := func(e crawl.ProgressEvent) {
+			events = append(events, e)
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, progress)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Saved, "should save 2 pages")
+
+		// Recursive crawling doesn't emit ProgressStarted (no known total)
+		// It should emit ProgressCompleted events with incrementing Completed count
+		var completedEvents []crawl.ProgressEvent
+		for _, e := range events {
+			if e.Type == crawl.ProgressCompleted {
+				completedEvents = append(completedEvents, e)
+			}
+		}
+
+		require.Len(t, completedEvents, 2, "should have 2 completed events")
+
+		// Completed count should increment: 1, 2
+		assert.Equal(t, 1, completedEvents[0].Completed, "first completed should be 1")
+		assert.Equal(t, 2, completedEvents[1].Completed, "second completed should be 2")
+
+		// Total should be 0 (unknown) for recursive crawling
+		assert.Equal(t, 0, completedEvents[0].Total, "total should be 0 (unknown)")
+		assert.Equal(t, 0, completedEvents[1].Total, "total should be 0 (unknown)")
+
+		// Should have a Finished event at the end
+		lastEvent := events[len(events)-1]
+		assert.Equal(t, crawl.ProgressFinished, lastEvent.Type, "last event should be Finished")
+	})
+
+	// Verifies that when CreateDocument fails during recursive crawling,
+	// a ProgressFailed event is emitted with the URL and error. This ensures
+	// users see feedback about save failures (same as fetch/extract failures).
+	t.Run("recursive crawl emits ProgressFailed when CreateDocument fails", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			// Fail on one specific URL
+			if doc.SourceURL == "https://example.com/docs/page1" {
+				return locdoc.Errorf(locdoc.EINTERNAL, "database error")
+			}
+			return nil
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -638,160 +1859,263 @@ func TestCrawler_CrawlProject(t *testing.T) {
 			SourceURL: "https://example.com/docs/",
 		}
 
-		var events []crawl.ProgressEvent
-		progress := func(e crawl.ProgressEvent) {
-			events = append(events, e)
+		var events []crawl.ProgressEvent
+		progress := func(e crawl.ProgressEvent) {
+			events = append(events, e)
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, progress)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved, "should save seed URL only")
+		assert.Equal(t, 1, result.Failed, "should count page1 as failed")
+
+		// Find the ProgressFailed event
+		var failedEvents []crawl.ProgressEvent
+		for _, e := range events {
+			if e.Type == crawl.ProgressFailed {
+				failedEvents = append(failedEvents, e)
+			}
+		}
+
+		require.Len(t, failedEvents, 1, "should emit exactly one ProgressFailed event")
+		assert.Equal(t, "https://example.com/docs/page1", failedEvents[0].URL, "failed event should have correct URL")
+		require.Error(t, failedEvents[0].Error, "failed event should have error")
+		assert.Contains(t, failedEvents[0].Error.Error(), "database error", "error should contain original message")
+	})
+
+	t.Run("probe uses HTTP fetcher for known HTTP-only framework", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><p>HTTP Content</p></body></html>`, nil
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><p>Rod Content</p></body></html>`, nil
+		}
+		m.Prober.DetectFn = func(_ string) locdoc.Framework {
+			return locdoc.FrameworkSphinx // Known HTTP-only framework
+		}
+		m.Prober.RequiresJSForFrameworkFn = func(f locdoc.Framework) (bool, bool) {
+			return false, true // Doesn't require JS, is known
+		}
+
+		project := &locdoc.Project{
+			ID:        "proj-123",
+			Name:      "test",
+			SourceURL: "https://example.com",
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, 2, result.Saved)
+		// Probe uses HTTP once, then HTTP for both pages = 3 total
+		assert.Equal(t, 3, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe and all pages")
+		assert.Equal(t, 0, m.RodFetcher.CallCount("Fetch"), "should not use Rod fetcher")
+		assert.Equal(t, locdoc.FetcherTypeHTTP, result.FetcherType)
+	})
+
+	t.Run("skips probe when project has cached fetcher type", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><p>HTTP Content</p></body></html>`, nil
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><p>Rod Content</p></body></html>`, nil
+		}
+		m.Prober.DetectFn = func(_ string) locdoc.Framework {
+			t.Error("Detect should not be called when fetcher type is cached")
+			return locdoc.FrameworkUnknown
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 2, m.HTTPFetcher.CallCount("Fetch"), "should fetch each page once without probing")
+		assert.Equal(t, 0, m.RodFetcher.CallCount("Fetch"))
+		assert.Equal(t, locdoc.FetcherTypeHTTP, result.FetcherType)
+	})
+
+	t.Run("tracks transferred HTML bytes separately from stored bytes", func(t *testing.T) {
+		t.Parallel()
+
+		pages := map[string]string{
+			"https://example.com/page1": "<html><body><p>" + strings.Repeat("a", 100) + "</p></body></html>",
+			"https://example.com/page2": "<html><body><p>" + strings.Repeat("b", 300) + "</p></body></html>",
+		}
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, url string) (string, error) {
+			return pages[url], nil
+		}
+
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
-		result, err := c.CrawlProject(context.Background(), project, progress)
+		result, err := c.CrawlProject(context.Background(), project, nil)
 
 		require.NoError(t, err)
-		require.Equal(t, 2, result.Saved, "should save 2 pages")
+		assert.Equal(t, len(pages["https://example.com/page1"])+len(pages["https://example.com/page2"]), result.TransferBytes)
+		assert.Equal(t, 2*len("Content"), result.Bytes)
+	})
 
-		// Recursive crawling doesn't emit ProgressStarted (no known total)
-		// It should emit ProgressCompleted events with incrementing Completed count
-		var completedEvents []crawl.ProgressEvent
-		for _, e := range events {
-			if e.Type == crawl.ProgressCompleted {
-				completedEvents = append(completedEvents, e)
-			}
-		}
+	t.Run("stores readability score of converted markdown", func(t *testing.T) {
+		t.Parallel()
 
-		require.Len(t, completedEvents, 2, "should have 2 completed events")
+		markdown := "Install the package with your favorite package manager. Then import the client."
 
-		// Completed count should increment: 1, 2
-		assert.Equal(t, 1, completedEvents[0].Completed, "first completed should be 1")
-		assert.Equal(t, 2, completedEvents[1].Completed, "second completed should be 2")
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1"}, nil
+		}
+		m.Converter.ConvertFn = func(_ string) (string, error) {
+			return markdown, nil
+		}
+		var saved []*locdoc.Document
+		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
+			saved = append(saved, doc)
+			return nil
+		}
 
-		// Total should be 0 (unknown) for recursive crawling
-		assert.Equal(t, 0, completedEvents[0].Total, "total should be 0 (unknown)")
-		assert.Equal(t, 0, completedEvents[1].Total, "total should be 0 (unknown)")
+		project := &locdoc.Project{
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
 
-		// Should have a Finished event at the end
-		lastEvent := events[len(events)-1]
-		assert.Equal(t, crawl.ProgressFinished, lastEvent.Type, "last event should be Finished")
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+		assert.Equal(t, crawl.ReadabilityScore(markdown), saved[0].ReadabilityScore)
+		assert.Positive(t, saved[0].ReadabilityScore)
 	})
 
-	// Verifies that when CreateDocument fails during recursive crawling,
-	// a ProgressFailed event is emitted with the URL and error. This ensures
-	// users see feedback about save failures (same as fetch/extract failures).
-	t.Run("recursive crawl emits ProgressFailed when CreateDocument fails", func(t *testing.T) {
+	t.Run("retries failed HTTP fetch with Rod when RetryWithAlternate is set", func(t *testing.T) {
 		t.Parallel()
 
+		var events []crawl.ProgressEvent
+		var saved []*locdoc.Document
+
 		c, m := newTestCrawler()
+		c.RetryWithAlternate = true
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return "", locdoc.Errorf(locdoc.EINTERNAL, "tls handshake failed")
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><p>Rod Content</p></body></html>`, nil
+		}
 		m.Documents.CreateDocumentFn = func(_ context.Context, doc *locdoc.Document) error {
-			// Fail on one specific URL
-			if doc.SourceURL == "https://example.com/docs/page1" {
-				return locdoc.Errorf(locdoc.EINTERNAL, "database error")
-			}
+			saved = append(saved, doc)
 			return nil
 		}
-		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
-			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
-					if baseURL == "https://example.com/docs/" {
-						return []locdoc.DiscoveredLink{
-							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
-						}, nil
-					}
-					return nil, nil
-				},
-				NameFn: func() string { return "test" },
-			}
-		}
 
 		project := &locdoc.Project{
-			ID:        "proj-123",
-			Name:      "test",
-			SourceURL: "https://example.com/docs/",
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
-		var events []crawl.ProgressEvent
-		progress := func(e crawl.ProgressEvent) {
+		result, err := c.CrawlProject(context.Background(), project, func(e crawl.ProgressEvent) {
 			events = append(events, e)
-		}
-
-		result, err := c.CrawlProject(context.Background(), project, progress)
+		})
 
 		require.NoError(t, err)
-		assert.Equal(t, 1, result.Saved, "should save seed URL only")
-		assert.Equal(t, 1, result.Failed, "should count page1 as failed")
+		assert.Equal(t, 1, result.Saved)
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, 2, m.HTTPFetcher.CallCount("Fetch"), "should exhaust HTTP retries first")
+		assert.Equal(t, 1, m.RodFetcher.CallCount("Fetch"))
+		require.Len(t, saved, 1)
+		assert.Equal(t, "https://example.com/page1", saved[0].SourceURL)
 
-		// Find the ProgressFailed event
-		var failedEvents []crawl.ProgressEvent
+		var alternates []crawl.ProgressEvent
 		for _, e := range events {
-			if e.Type == crawl.ProgressFailed {
-				failedEvents = append(failedEvents, e)
+			if e.Type == crawl.ProgressRetryAlternate {
+				alternates = append(alternates, e)
 			}
 		}
-
-		require.Len(t, failedEvents, 1, "should emit exactly one ProgressFailed event")
-		assert.Equal(t, "https://example.com/docs/page1", failedEvents[0].URL, "failed event should have correct URL")
-		require.Error(t, failedEvents[0].Error, "failed event should have error")
-		assert.Contains(t, failedEvents[0].Error.Error(), "database error", "error should contain original message")
+		require.Len(t, alternates, 1)
+		assert.Equal(t, "https://example.com/page1", alternates[0].URL)
+		assert.Contains(t, alternates[0].Reason, "tls handshake failed")
 	})
 
-	t.Run("probe uses HTTP fetcher for known HTTP-only framework", func(t *testing.T) {
+	t.Run("does not retry with Rod when RetryWithAlternate is unset", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
-
 		c, m := newTestCrawler()
 		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
-			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+			return []string{"https://example.com/page1"}, nil
 		}
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
-			return `<html><body><p>HTTP Content</p></body></html>`, nil
+			return "", locdoc.Errorf(locdoc.EINTERNAL, "connection refused")
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
-			return `<html><body><p>Rod Content</p></body></html>`, nil
-		}
-		m.Prober.DetectFn = func(_ string) locdoc.Framework {
-			return locdoc.FrameworkSphinx // Known HTTP-only framework
-		}
-		m.Prober.RequiresJSFn = func(f locdoc.Framework) (bool, bool) {
-			return false, true // Doesn't require JS, is known
+			t.Error("Rod fetcher should not be called")
+			return "", nil
 		}
 
 		project := &locdoc.Project{
-			ID:        "proj-123",
-			Name:      "test",
-			SourceURL: "https://example.com",
+			ID:          "proj-123",
+			Name:        "test",
+			SourceURL:   "https://example.com",
+			FetcherType: locdoc.FetcherTypeHTTP,
 		}
 
 		result, err := c.CrawlProject(context.Background(), project, nil)
 
 		require.NoError(t, err)
-		require.NotNil(t, result)
-		assert.Equal(t, 2, result.Saved)
-		// Probe uses HTTP once, then HTTP for both pages = 3 total
-		assert.Equal(t, 3, httpFetchCalls, "should use HTTP fetcher for probe and all pages")
-		assert.Equal(t, 0, rodFetchCalls, "should not use Rod fetcher")
+		assert.Equal(t, 0, result.Saved)
+		assert.Equal(t, 1, result.Failed)
 	})
 
 	t.Run("probe uses Rod fetcher for known JS framework", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
-
 		c, m := newTestCrawler()
 		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
 			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
 		}
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return `<html><body><p>HTTP Content</p></body></html>`, nil
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return `<html><body><p>Rod Content</p></body></html>`, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkGitBook // Known JS framework
 		}
-		m.Prober.RequiresJSFn = func(f locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(f locdoc.Framework) (bool, bool) {
 			return true, true // Requires JS, is known
 		}
 
@@ -807,14 +2131,13 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		require.NotNil(t, result)
 		assert.Equal(t, 2, result.Saved)
 		// Probe uses HTTP once, but then Rod for both pages = 2 Rod fetches
-		assert.Equal(t, 1, httpFetchCalls, "should use HTTP fetcher for probe only")
-		assert.Equal(t, 2, rodFetchCalls, "should use Rod fetcher for all pages")
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe only")
+		assert.Equal(t, 2, m.RodFetcher.CallCount("Fetch"), "should use Rod fetcher for all pages")
 	})
 
 	t.Run("probe uses Rod fetcher for unknown framework with different content", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
 		httpHTML := `<html><body><p>Short</p></body></html>`
 		rodHTML := `<html><body><p>Short plus lots more JavaScript-rendered content that makes this much much longer</p></body></html>`
 
@@ -823,11 +2146,9 @@ func TestCrawler_CrawlProject(t *testing.T) {
 			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
 		}
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return httpHTML, nil
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return rodHTML, nil
 		}
 		// Make extractor return the actual HTML content for comparison
@@ -847,7 +2168,7 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkUnknown
 		}
-		m.Prober.RequiresJSFn = func(f locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(f locdoc.Framework) (bool, bool) {
 			return false, false // Unknown framework
 		}
 
@@ -863,31 +2184,27 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		require.NotNil(t, result)
 		assert.Equal(t, 2, result.Saved)
 		// Probe: HTTP once, Rod once (for comparison), then Rod for pages = 1+1+2
-		assert.Equal(t, 1, httpFetchCalls, "should use HTTP fetcher for probe only")
-		assert.Equal(t, 3, rodFetchCalls, "should use Rod fetcher for comparison probe and all pages")
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe only")
+		assert.Equal(t, 3, m.RodFetcher.CallCount("Fetch"), "should use Rod fetcher for comparison probe and all pages")
 	})
 
 	t.Run("probe falls back to Rod when HTTP probe fails", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
-
 		c, m := newTestCrawler()
 		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
 			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
 		}
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return "", locdoc.Errorf(locdoc.EINTERNAL, "connection refused")
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return `<html><body><p>Rod Content</p></body></html>`, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkUnknown
 		}
-		m.Prober.RequiresJSFn = func(f locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(f locdoc.Framework) (bool, bool) {
 			return false, false
 		}
 
@@ -903,8 +2220,135 @@ func TestCrawler_CrawlProject(t *testing.T) {
 		require.NotNil(t, result)
 		assert.Equal(t, 2, result.Saved)
 		// HTTP fails, fall back to Rod for everything = 2 pages
-		assert.Equal(t, 1, httpFetchCalls, "should attempt HTTP probe once")
-		assert.Equal(t, 2, rodFetchCalls, "should fall back to Rod for all pages")
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should attempt HTTP probe once")
+		assert.Equal(t, 2, m.RodFetcher.CallCount("Fetch"), "should fall back to Rod for all pages")
+	})
+
+	t.Run("probe falls back to Rod and warns when HTTP content is unusable", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><head><title>Just a moment...</title></head><body></body></html>`, nil
+		}
+		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><p>Rod Content</p></body></html>`, nil
+		}
+		m.Prober.IsUsableContentFn = func(html string) bool {
+			return !strings.Contains(html, "Just a moment...")
+		}
+		m.Prober.DetectFn = func(_ string) locdoc.Framework {
+			t.Error("framework detection should be skipped for unusable content")
+			return locdoc.FrameworkUnknown
+		}
+
+		project := &locdoc.Project{
+			ID:        "proj-123",
+			Name:      "test",
+			SourceURL: "https://example.com",
+		}
+
+		var warnings []crawl.ProgressEvent
+		result, err := c.CrawlProject(context.Background(), project, func(e crawl.ProgressEvent) {
+			if e.Type == crawl.ProgressRetryAlternate {
+				warnings = append(warnings, e)
+			}
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, locdoc.FetcherTypeRod, result.FetcherType)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe only")
+		assert.Equal(t, 2, m.RodFetcher.CallCount("Fetch"))
+		require.Len(t, warnings, 1)
+		assert.Equal(t, "https://example.com/page1", warnings[0].URL)
+		assert.Contains(t, warnings[0].Reason, "challenge")
+	})
+}
+
+func TestCrawler_DiscoverURLs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns sitemap URLs without saving documents", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/docs/a", "https://example.com/docs/b"}, nil
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, _ *locdoc.Document) error {
+			t.Fatal("CreateDocument should not be called during discovery")
+			return nil
+		}
+
+		var streamed []string
+		urls, err := c.DiscoverURLs(
+			context.Background(),
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
+			nil,
+			crawl.WithOnURL(func(url string) { streamed = append(streamed, url) }),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.com/docs/a", "https://example.com/docs/b"}, urls)
+		assert.Equal(t, urls, streamed)
+	})
+
+	t.Run("falls back to recursive discovery when sitemap is empty", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			return `<html><body><nav><a href="/docs/page1">Page 1</a></nav></body></html>`, nil
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					if baseURL == "https://example.com/docs/" {
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
+						}, nil
+					}
+					return nil, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+		m.Prober.DetectFn = func(_ string) locdoc.Framework {
+			return locdoc.FrameworkSphinx
+		}
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
+			return false, true
+		}
+		m.Documents.CreateDocumentFn = func(_ context.Context, _ *locdoc.Document) error {
+			t.Fatal("CreateDocument should not be called during discovery")
+			return nil
+		}
+
+		urls, err := c.DiscoverURLs(
+			context.Background(),
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
+			nil,
+		)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"https://example.com/docs/", "https://example.com/docs/page1"}, urls)
+	})
+
+	t.Run("returns sitemap errors", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return nil, errors.New("sitemap unavailable")
+		}
+
+		_, err := c.DiscoverURLs(context.Background(), &locdoc.Project{SourceURL: "https://example.com/docs/"}, nil)
+
+		require.ErrorContains(t, err, "sitemap unavailable")
 	})
 }
 
@@ -923,13 +2367,11 @@ func TestCrawler_AcceptsDocumentWriter(t *testing.T) {
 
 		// Crawler should accept DocumentWriter for its Documents field
 		c := &crawl.Crawler{
-			Discoverer: &crawl.Discoverer{
-				HTTPFetcher: &mock.Fetcher{},
-				RodFetcher:  &mock.Fetcher{},
-				Extractor:   &mock.Extractor{},
-				Concurrency: 1,
-				RetryDelays: []time.Duration{0},
-			},
+			HTTPFetcher:  &mock.Fetcher{},
+			RodFetcher:   &mock.Fetcher{},
+			Extractor:    &mock.Extractor{},
+			Concurrency:  1,
+			RetryDelays:  []time.Duration{0},
 			Sitemaps:     &mock.SitemapService{},
 			Converter:    &mock.Converter{},
 			Documents:    writer, // Should compile with DocumentWriter
@@ -940,3 +2382,112 @@ func TestCrawler_AcceptsDocumentWriter(t *testing.T) {
 		assert.NotNil(t, c.Documents)
 	})
 }
+
+func TestCrawler_CrawlProject_Transaction(t *testing.T) {
+	t.Parallel()
+
+	project := &locdoc.Project{
+		ID:        "proj-123",
+		Name:      "test",
+		SourceURL: "https://example.com",
+	}
+
+	t.Run("commits each page in its own transaction", func(t *testing.T) {
+		t.Parallel()
+
+		var begun, committed, rolledBack, txSaved int
+
+		c, m := newTestCrawler()
+		c.Documents = &mock.DocumentService{
+			CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
+				t.Error("CreateDocument should be called on the transaction")
+				return nil
+			},
+			BeginTxFn: func(_ context.Context) (locdoc.DocumentTx, error) {
+				begun++
+				return &mock.DocumentTx{
+					DocumentService: mock.DocumentService{
+						CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error {
+							txSaved++
+							return nil
+						},
+					},
+					CommitFn:   func() error { committed++; return nil },
+					RollbackFn: func() error { rolledBack++; return nil },
+				}, nil
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, 2, txSaved)
+		assert.Equal(t, 2, begun)
+		assert.Equal(t, 2, committed)
+		assert.Equal(t, 0, rolledBack)
+	})
+
+	t.Run("rolls back a failed page and keeps the others", func(t *testing.T) {
+		t.Parallel()
+
+		var committed []string
+		var rolledBack int
+
+		c, m := newTestCrawler()
+		c.Documents = &mock.DocumentService{
+			BeginTxFn: func(_ context.Context) (locdoc.DocumentTx, error) {
+				var url string
+				return &mock.DocumentTx{
+					DocumentService: mock.DocumentService{
+						CreateDocumentFn: func(_ context.Context, doc *locdoc.Document) error {
+							url = doc.SourceURL
+							if doc.SourceURL == "https://example.com/page2" {
+								return errors.New("disk full")
+							}
+							return nil
+						},
+					},
+					CommitFn:   func() error { committed = append(committed, url); return nil },
+					RollbackFn: func() error { rolledBack++; return nil },
+				}, nil
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return []string{"https://example.com/page1", "https://example.com/page2"}, nil
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved)
+		assert.Equal(t, []string{"https://example.com/page1"}, committed)
+		assert.Equal(t, 1, rolledBack)
+	})
+
+	t.Run("begins no transaction when discovery fails", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		c.Documents = &mock.DocumentService{
+			BeginTxFn: func(_ context.Context) (locdoc.DocumentTx, error) {
+				t.Error("BeginTx should not be called")
+				return nil, nil
+			},
+			BulkDeleteDocumentsFn: func(_ context.Context, _ []string) error {
+				t.Error("BulkDeleteDocuments should not be called")
+				return nil
+			},
+		}
+		m.Sitemaps.DiscoverURLsFn = func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return nil, locdoc.Errorf(locdoc.EINTERNAL, "sitemap unavailable")
+		}
+
+		_, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.Error(t, err)
+	})
+}
@@ -1,14 +1,22 @@
 package crawl
 
-import "time"
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fwojciec/locdoc"
+)
 
 // DiscoverOption configures DiscoverURLs behavior.
 type DiscoverOption func(*discoverConfig)
 
 type discoverConfig struct {
-	concurrency int
-	retryDelays []time.Duration
-	onURL       func(string)
+	concurrency  int
+	retryDelays  []time.Duration
+	drainTimeout time.Duration
+	onURL        func(string)
 }
 
 // WithConcurrency sets the number of concurrent workers for URL discovery.
@@ -27,6 +35,14 @@ func WithRetryDelays(delays []time.Duration) DiscoverOption {
 	}
 }
 
+// WithDrainTimeout sets how long to wait for in-flight URLs to finish when
+// discovery stops early. Defaults to the Crawler's DrainTimeout.
+func WithDrainTimeout(d time.Duration) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.drainTimeout = d
+	}
+}
+
 // WithOnURL sets a callback that is invoked for each URL as it is discovered.
 // This enables streaming output instead of waiting for all URLs to be collected.
 func WithOnURL(fn func(string)) DiscoverOption {
@@ -34,3 +50,128 @@ func WithOnURL(fn func(string)) DiscoverOption {
 		c.onURL = fn
 	}
 }
+
+// discoverRecursive discovers URLs by walking a documentation site from
+// sourceURL, following links within the path prefix scope of the source
+// URL.
+//
+// Discovery stops after processing MaxCrawlURLs URLs (1000 by default)
+// to prevent runaway crawls on large sites.
+//
+// URLs are processed concurrently using walkFrontier for improved performance.
+func (c *Crawler) discoverRecursive(
+	ctx context.Context,
+	sourceURL string,
+	urlFilter *locdoc.URLFilter,
+	opts ...DiscoverOption,
+) ([]string, error) {
+	// Apply options
+	cfg := &discoverConfig{
+		concurrency:  c.Concurrency,
+		retryDelays:  c.RetryDelays,
+		drainTimeout: c.DrainTimeout,
+	}
+	if cfg.concurrency <= 0 {
+		cfg.concurrency = 3 // Lower default for preview mode
+	}
+	if cfg.retryDelays == nil {
+		cfg.retryDelays = DefaultRetryDelays()
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Probe to determine which fetcher to use
+	probeCfg := probeConfig{
+		HTTPFetcher: c.HTTPFetcher,
+		RodFetcher:  c.RodFetcher,
+		Prober:      c.Prober,
+		Extractor:   c.Extractor,
+	}
+	activeFetcher, _ := probeFetcher(ctx, sourceURL, probeCfg)
+
+	// Collected URLs (handleResult is called sequentially from coordinator)
+	var urls []string
+
+	// Discovery processor: fetch page and extract links (no content extraction)
+	processURL := func(ctx context.Context, link locdoc.DiscoveredLink, f locdoc.Fetcher) crawlResult {
+		result := crawlResult{
+			url:   link.URL,
+			depth: link.Depth,
+		}
+
+		// Parse URL for rate limiting
+		linkURL, err := url.Parse(link.URL)
+		if err != nil {
+			result.err = err
+			return result
+		}
+
+		// Rate limit
+		if err := c.RateLimiter.Wait(ctx, linkURL.Host); err != nil {
+			result.err = err
+			return result
+		}
+
+		// Fetch page with retry
+		fetchFn := func(ctx context.Context, url string) (string, error) {
+			return f.Fetch(ctx, url)
+		}
+		delays := JitteredRetryDelays(cfg.retryDelays, c.RetryJitter)
+		html, err := FetchWithRetryDelays(ctx, link.URL, fetchFn, nil, delays)
+		if err != nil {
+			result.err = err
+			return result
+		}
+
+		// Extract links for frontier
+		selector := c.LinkSelectors.GetForHTML(html)
+		links, err := selector.ExtractLinks(html, link.URL, c.selectorOptions())
+		if err == nil {
+			result.discovered = links
+		}
+
+		return result
+	}
+
+	// Discovery handler: collect URLs and add links to frontier
+	handleResult := func(_ context.Context, result *crawlResult, frontier *Frontier, parsedSourceURL *url.URL, pathPrefix string, filter *locdoc.URLFilter) bool {
+		// Add discovered links to frontier (after scope and depth filtering)
+		if !c.followLinks(result.depth) {
+			result.discovered = nil
+		}
+		for _, discovered := range result.discovered {
+			discoveredURL, err := url.Parse(discovered.URL)
+			if err != nil {
+				continue
+			}
+			if discoveredURL.Host != parsedSourceURL.Host {
+				continue
+			}
+			if !strings.HasPrefix(discoveredURL.Path, pathPrefix) {
+				continue
+			}
+			if !filter.Match(discovered.URL) {
+				continue
+			}
+			discovered.Depth = result.depth + 1
+			frontier.Push(discovered)
+		}
+
+		// Collect successfully fetched URLs
+		if result.err == nil {
+			urls = append(urls, result.url)
+			if cfg.onURL != nil {
+				cfg.onURL(result.url)
+			}
+		}
+		return false
+	}
+
+	_, err := walkFrontier(ctx, sourceURL, urlFilter, activeFetcher, cfg.concurrency, c.FrontierSize, c.MaxCrawlURLs, cfg.drainTimeout, &c.activeWorkers, processURL, handleResult)
+	if err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
@@ -17,10 +17,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// newTestDiscoverer creates a Discoverer with sensible test defaults.
+// newTestDiscoverer creates a Crawler set up for recursive URL discovery
+// only, with no Sitemaps, and sensible test defaults.
 // All mocks return minimal successful responses by default.
 // Use the returned mocks struct to customize behavior for specific tests.
-func newTestDiscoverer() (*crawl.Discoverer, *discovererMocks) {
+func newTestDiscoverer() (*crawl.Crawler, *discovererMocks) {
 	m := &discovererMocks{
 		HTTPFetcher: &mock.Fetcher{
 			FetchFn: func(_ context.Context, _ string) (string, error) {
@@ -36,7 +37,7 @@ func newTestDiscoverer() (*crawl.Discoverer, *discovererMocks) {
 			DetectFn: func(_ string) locdoc.Framework {
 				return locdoc.FrameworkUnknown
 			},
-			RequiresJSFn: func(_ locdoc.Framework) (bool, bool) {
+			RequiresJSForFrameworkFn: func(_ locdoc.Framework) (bool, bool) {
 				return false, false
 			},
 		},
@@ -51,7 +52,7 @@ func newTestDiscoverer() (*crawl.Discoverer, *discovererMocks) {
 		LinkSelectors: &mock.LinkSelectorRegistry{
 			GetForHTMLFn: func(_ string) locdoc.LinkSelector {
 				return &mock.LinkSelector{
-					ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+					ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 						return nil, nil
 					},
 					NameFn: func() string { return "test" },
@@ -65,7 +66,7 @@ func newTestDiscoverer() (*crawl.Discoverer, *discovererMocks) {
 		},
 	}
 
-	d := &crawl.Discoverer{
+	d := &crawl.Crawler{
 		HTTPFetcher:   m.HTTPFetcher,
 		RodFetcher:    m.RodFetcher,
 		Prober:        m.Prober,
@@ -90,7 +91,7 @@ type discovererMocks struct {
 	RateLimiter   *mock.DomainLimiter
 }
 
-func TestDiscoverer_DiscoverURLs(t *testing.T) {
+func TestCrawler_DiscoverURLs_Recursive(t *testing.T) {
 	t.Parallel()
 
 	t.Run("discovers URLs recursively from source", func(t *testing.T) {
@@ -110,7 +111,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -131,13 +132,13 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
@@ -157,7 +158,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -172,13 +173,13 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
@@ -195,13 +196,13 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
@@ -212,33 +213,30 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 	t.Run("uses default concurrency of 3 when not specified", func(t *testing.T) {
 		t.Parallel()
 
-		// Track concurrent fetch count using atomics
-		var maxConcurrent atomic.Int32
-		var currentConcurrent atomic.Int32
-
 		const numPages = 10
 
 		d, m := newTestDiscoverer()
 		d.Concurrency = 0 // trigger default
 
-		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			current := currentConcurrent.Add(1)
-			for {
-				max := maxConcurrent.Load()
-				if current <= max || maxConcurrent.CompareAndSwap(max, current) {
-					break
+		// Fetches of discovered pages block until released so the workers
+		// pile up and ActiveWorkers can be observed at its peak.
+		release := make(chan struct{})
+		var maxActive atomic.Int32
+		m.HTTPFetcher.FetchFn = func(ctx context.Context, url string) (string, error) {
+			if url != "https://example.com/docs/" {
+				recordMax(&maxActive, int32(d.ActiveWorkers()))
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return "", ctx.Err()
 				}
 			}
-
-			// Simulate work to allow concurrency to build up
-			time.Sleep(20 * time.Millisecond)
-			currentConcurrent.Add(-1)
 			return `<html><body></body></html>`, nil
 		}
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						var links []locdoc.DiscoveredLink
 						for i := 1; i <= numPages; i++ {
@@ -258,54 +256,59 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx // Known HTTP-only framework
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true // Doesn't require JS, is known
 		}
 
-		urls, err := d.DiscoverURLs(
-			context.Background(),
-			"https://example.com/docs/",
-			nil,
-		)
-
-		require.NoError(t, err)
-		assert.Len(t, urls, numPages+1)
-
-		// Default concurrency is 3, so we should never see more than 3 concurrent fetches
-		assert.LessOrEqual(t, maxConcurrent.Load(), int32(3),
-			"default concurrency should be 3, got max concurrent of %d", maxConcurrent.Load())
+		done := make(chan discoverOutcome, 1)
+		go func() {
+			urls, err := d.DiscoverURLs(
+				context.Background(),
+				&locdoc.Project{SourceURL: "https://example.com/docs/"},
+				nil,
+			)
+			done <- discoverOutcome{urls: urls, err: err}
+		}()
+
+		require.Eventually(t, func() bool {
+			return d.ActiveWorkers() == 3
+		}, 5*time.Second, time.Millisecond, "default concurrency should be 3")
+		close(release)
+
+		outcome := <-done
+		require.NoError(t, outcome.err)
+		assert.Len(t, outcome.urls, numPages+1)
+		assert.LessOrEqual(t, maxActive.Load(), int32(3))
+		assert.Zero(t, d.ActiveWorkers())
 	})
 
 	t.Run("respects concurrency limit with tracking", func(t *testing.T) {
 		t.Parallel()
 
-		// Track concurrent fetch count using atomics
-		var maxConcurrent atomic.Int32
-		var currentConcurrent atomic.Int32
-
 		const numPages = 10
 		const concurrency = 2
 
 		d, m := newTestDiscoverer()
 
-		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			current := currentConcurrent.Add(1)
-			for {
-				max := maxConcurrent.Load()
-				if current <= max || maxConcurrent.CompareAndSwap(max, current) {
-					break
+		// Fetches of discovered pages block until released so the workers
+		// pile up and ActiveWorkers can be observed at its peak.
+		release := make(chan struct{})
+		var maxActive atomic.Int32
+		m.HTTPFetcher.FetchFn = func(ctx context.Context, url string) (string, error) {
+			if url != "https://example.com/docs/" {
+				recordMax(&maxActive, int32(d.ActiveWorkers()))
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return "", ctx.Err()
 				}
 			}
-
-			// Simulate work to allow concurrency to build up
-			time.Sleep(20 * time.Millisecond)
-			currentConcurrent.Add(-1)
 			return `<html><body></body></html>`, nil
 		}
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						var links []locdoc.DiscoveredLink
 						for i := 1; i <= numPages; i++ {
@@ -325,23 +328,32 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
-		urls, err := d.DiscoverURLs(
-			context.Background(),
-			"https://example.com/docs/",
-			nil,
-			crawl.WithConcurrency(concurrency),
-		)
-
-		require.NoError(t, err)
-		assert.Len(t, urls, numPages+1)
-
-		// With concurrency=2, we should never see more than 2 concurrent fetches
-		assert.LessOrEqual(t, maxConcurrent.Load(), int32(concurrency),
-			"should not exceed concurrency limit of %d, got %d", concurrency, maxConcurrent.Load())
+		done := make(chan discoverOutcome, 1)
+		go func() {
+			urls, err := d.DiscoverURLs(
+				context.Background(),
+				&locdoc.Project{SourceURL: "https://example.com/docs/"},
+				nil,
+				crawl.WithConcurrency(concurrency),
+			)
+			done <- discoverOutcome{urls: urls, err: err}
+		}()
+
+		require.Eventually(t, func() bool {
+			return d.ActiveWorkers() == concurrency
+		}, 5*time.Second, time.Millisecond, "expected %d workers to be active at once", concurrency)
+		close(release)
+
+		outcome := <-done
+		require.NoError(t, outcome.err)
+		assert.Len(t, outcome.urls, numPages+1)
+		assert.LessOrEqual(t, maxActive.Load(), int32(concurrency),
+			"should not exceed concurrency limit of %d", concurrency)
+		assert.Zero(t, d.ActiveWorkers())
 	})
 
 	t.Run("retries failed fetches", func(t *testing.T) {
@@ -367,7 +379,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -382,14 +394,14 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		// Use zero delays for fast tests
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 			crawl.WithRetryDelays([]time.Duration{0, 0, 0}),
 		)
@@ -414,7 +426,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					// Return links both inside and outside scope
 					return []locdoc.DiscoveredLink{
 						{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -429,13 +441,13 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
@@ -455,7 +467,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					return []locdoc.DiscoveredLink{
 						{URL: "https://example.com/docs/api/v1", Priority: locdoc.PriorityNavigation},
 						{URL: "https://example.com/docs/guide/intro", Priority: locdoc.PriorityNavigation},
@@ -468,7 +480,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
@@ -478,7 +490,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			filter,
 		)
 
@@ -504,7 +516,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					return []locdoc.DiscoveredLink{
 						{URL: "https://example.com/docs/missing", Priority: locdoc.PriorityNavigation},
 					}, nil
@@ -516,13 +528,13 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 			crawl.WithRetryDelays([]time.Duration{0, 0, 0}),
 		)
@@ -542,7 +554,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					// Generate many links to ensure we'd normally continue
 					return []locdoc.DiscoveredLink{
 						{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -562,13 +574,13 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
 		urls, err := d.DiscoverURLs(
 			ctx,
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
@@ -584,7 +596,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -600,7 +612,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true
 		}
 
@@ -610,7 +622,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 			crawl.WithOnURL(func(url string) {
 				mu.Lock()
@@ -630,27 +642,23 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 	t.Run("probe uses HTTP fetcher for known HTTP-only framework", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
-
 		d, m := newTestDiscoverer()
 
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return `<html><body><p>HTTP Content</p></body></html>`, nil
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return `<html><body><p>Rod Content</p></body></html>`, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkSphinx // Known HTTP-only framework
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, true // Doesn't require JS, is known
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -664,41 +672,37 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
 		require.NoError(t, err)
 		assert.Len(t, urls, 2)
 		// Probe uses HTTP once, then HTTP for both pages = 3 total
-		assert.Equal(t, 3, httpFetchCalls, "should use HTTP fetcher for probe and all pages")
-		assert.Equal(t, 0, rodFetchCalls, "should not use Rod fetcher")
+		assert.Equal(t, 3, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe and all pages")
+		assert.Equal(t, 0, m.RodFetcher.CallCount("Fetch"), "should not use Rod fetcher")
 	})
 
 	t.Run("probe uses Rod fetcher for known JS framework", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
-
 		d, m := newTestDiscoverer()
 
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return `<html><body><p>HTTP Content</p></body></html>`, nil
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return `<html><body><p>Rod Content</p></body></html>`, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkGitBook // Known JS framework
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return true, true // Requires JS, is known
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -712,38 +716,35 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
 		require.NoError(t, err)
 		assert.Len(t, urls, 2)
 		// Probe uses HTTP once, but then Rod for both pages = 2 Rod fetches
-		assert.Equal(t, 1, httpFetchCalls, "should use HTTP fetcher for probe only")
-		assert.Equal(t, 2, rodFetchCalls, "should use Rod fetcher for all pages")
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe only")
+		assert.Equal(t, 2, m.RodFetcher.CallCount("Fetch"), "should use Rod fetcher for all pages")
 	})
 
 	t.Run("probe uses Rod fetcher for unknown framework with different content", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
 		httpHTML := `<html><body><p>Short</p></body></html>`
 		rodHTML := `<html><body><p>Short plus lots more JavaScript-rendered content that makes this much much longer</p></body></html>`
 
 		d, m := newTestDiscoverer()
 
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return httpHTML, nil
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return rodHTML, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkUnknown
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, false // Unknown framework
 		}
 		// Extractor returns different content for HTTP vs Rod HTML
@@ -761,7 +762,7 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -775,43 +776,40 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
 		require.NoError(t, err)
 		assert.Len(t, urls, 2)
 		// Probe: HTTP once, Rod once (for comparison), then Rod for pages = 1+1+2
-		assert.Equal(t, 1, httpFetchCalls, "should use HTTP fetcher for probe only")
-		assert.Equal(t, 3, rodFetchCalls, "should use Rod fetcher for comparison probe and all pages")
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe only")
+		assert.Equal(t, 3, m.RodFetcher.CallCount("Fetch"), "should use Rod fetcher for comparison probe and all pages")
 	})
 
 	t.Run("probe uses HTTP fetcher for unknown framework with similar content", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
 		// Both fetchers return similar content
 		html := `<html><body><p>Same content from both fetchers</p></body></html>`
 
 		d, m := newTestDiscoverer()
 
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return html, nil
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return html, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkUnknown
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, false // Unknown framework
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -825,41 +823,37 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
 		require.NoError(t, err)
 		assert.Len(t, urls, 2)
 		// Probe: HTTP once, Rod once (for comparison), content is similar so use HTTP for pages
-		assert.Equal(t, 3, httpFetchCalls, "should use HTTP fetcher for probe, comparison, and all pages")
-		assert.Equal(t, 1, rodFetchCalls, "should use Rod fetcher only for comparison")
+		assert.Equal(t, 3, m.HTTPFetcher.CallCount("Fetch"), "should use HTTP fetcher for probe, comparison, and all pages")
+		assert.Equal(t, 1, m.RodFetcher.CallCount("Fetch"), "should use Rod fetcher only for comparison")
 	})
 
 	t.Run("probe falls back to Rod when HTTP probe fails", func(t *testing.T) {
 		t.Parallel()
 
-		var httpFetchCalls, rodFetchCalls int
-
 		d, m := newTestDiscoverer()
 
 		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			httpFetchCalls++
 			return "", locdoc.Errorf(locdoc.EINTERNAL, "connection refused")
 		}
 		m.RodFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
-			rodFetchCalls++
 			return `<html><body><p>Rod Content</p></body></html>`, nil
 		}
 		m.Prober.DetectFn = func(_ string) locdoc.Framework {
 			return locdoc.FrameworkUnknown
 		}
-		m.Prober.RequiresJSFn = func(_ locdoc.Framework) (bool, bool) {
+		m.Prober.RequiresJSForFrameworkFn = func(_ locdoc.Framework) (bool, bool) {
 			return false, false
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -873,14 +867,56 @@ func TestDiscoverer_DiscoverURLs(t *testing.T) {
 
 		urls, err := d.DiscoverURLs(
 			context.Background(),
-			"https://example.com/docs/",
+			&locdoc.Project{SourceURL: "https://example.com/docs/"},
 			nil,
 		)
 
 		require.NoError(t, err)
 		assert.Len(t, urls, 2)
 		// HTTP fails, fall back to Rod for everything = 2 pages
-		assert.Equal(t, 1, httpFetchCalls, "should attempt HTTP probe once")
-		assert.Equal(t, 2, rodFetchCalls, "should fall back to Rod for all pages")
+		assert.Equal(t, 1, m.HTTPFetcher.CallCount("Fetch"), "should attempt HTTP probe once")
+		assert.Equal(t, 2, m.RodFetcher.CallCount("Fetch"), "should fall back to Rod for all pages")
 	})
+
+	t.Run("includes hidden content unless ExcludeHiddenContent is set", func(t *testing.T) {
+		t.Parallel()
+
+		for _, exclude := range []bool{false, true} {
+			d, m := newTestDiscoverer()
+			d.ExcludeHiddenContent = exclude
+
+			var got []locdoc.SelectorOptions
+			m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+				return &mock.LinkSelector{
+					ExtractLinksFn: func(_ string, _ string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+						got = append(got, opts)
+						return nil, nil
+					},
+					NameFn: func() string { return "test" },
+				}
+			}
+
+			_, err := d.DiscoverURLs(context.Background(), &locdoc.Project{SourceURL: "https://example.com/docs/"}, nil)
+
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, !exclude, got[0].IncludeHiddenContent)
+		}
+	})
+}
+
+// discoverOutcome carries the result of a DiscoverURLs call run in a goroutine.
+type discoverOutcome struct {
+	urls []string
+	err  error
+}
+
+// recordMax raises max to v if v is larger.
+func recordMax(max *atomic.Int32, v int32) {
+	for {
+		cur := max.Load()
+		if v <= cur || max.CompareAndSwap(cur, v) {
+			return
+		}
+	}
 }
@@ -0,0 +1,31 @@
+package crawl
+
+import (
+	"context"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Ensure DiscovererAdapter implements RecursiveDiscoverer.
+var _ RecursiveDiscoverer = (*DiscovererAdapter)(nil)
+
+// RecursiveDiscoverer discovers URLs by recursively crawling a site.
+type RecursiveDiscoverer interface {
+	DiscoverURLs(ctx context.Context, sourceURL string, filter *locdoc.URLFilter) ([]string, error)
+}
+
+// DiscovererAdapter adapts Crawler.DiscoverURLs to the RecursiveDiscoverer
+// interface. It intentionally omits the variadic DiscoverOption parameters -
+// configuration decisions (like concurrency, retry delays) are made on the
+// Crawler when it is wired up, not here.
+//
+// The Crawler should have no Sitemaps so that it only walks the site; the
+// sitemap is the other half of CompositeURLSource.
+type DiscovererAdapter struct {
+	Crawler *Crawler
+}
+
+// DiscoverURLs calls Crawler.DiscoverURLs for sourceURL with default options.
+func (a *DiscovererAdapter) DiscoverURLs(ctx context.Context, sourceURL string, filter *locdoc.URLFilter) ([]string, error) {
+	return a.Crawler.DiscoverURLs(ctx, &locdoc.Project{SourceURL: sourceURL}, filter)
+}
@@ -0,0 +1,56 @@
+package crawl_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/crawl"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscovererAdapter_DiscoverURLs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delegates to the crawler", func(t *testing.T) {
+		t.Parallel()
+
+		d, m := newTestDiscoverer()
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					if baseURL == "https://example.com/docs/" {
+						return []locdoc.DiscoveredLink{
+							{URL: "https://example.com/docs/intro", Priority: locdoc.PriorityNavigation},
+							{URL: "https://example.com/docs/guide", Priority: locdoc.PriorityNavigation},
+						}, nil
+					}
+					return nil, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+		filter, err := locdoc.ParseURLFilter("-/guide")
+		require.NoError(t, err)
+
+		adapter := &crawl.DiscovererAdapter{Crawler: d}
+		urls, err := adapter.DiscoverURLs(context.Background(), "https://example.com/docs/", filter)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"https://example.com/docs/", "https://example.com/docs/intro"}, urls)
+	})
+
+	t.Run("returns crawler errors", func(t *testing.T) {
+		t.Parallel()
+
+		d, _ := newTestDiscoverer()
+
+		adapter := &crawl.DiscovererAdapter{Crawler: d}
+		_, err := adapter.DiscoverURLs(context.Background(), "https://[::1", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid source URL")
+	})
+}
@@ -15,26 +15,35 @@ var _ locdoc.URLFrontier = (*Frontier)(nil)
 // Frontier is an in-memory URL frontier with priority queue and Bloom filter deduplication.
 // It is safe for concurrent use by multiple goroutines.
 type Frontier struct {
-	mu    sync.Mutex
-	seen  *bloom.Filter
-	queue *linkHeap
+	mu       sync.Mutex
+	seen     *bloom.Filter
+	queue    *linkHeap
+	maxSize  int
+	overflow int
 }
 
 // NewFrontier creates a new Frontier sized for n expected URLs
 // with the given false positive rate for deduplication.
-func NewFrontier(n uint, fpRate float64) *Frontier {
+// The queue holds at most maxSize links; a maxSize of 0 or less means no limit.
+func NewFrontier(n uint, fpRate float64, maxSize int) *Frontier {
 	h := &linkHeap{}
 	heap.Init(h)
 	return &Frontier{
-		seen:  bloom.NewFilter(n, fpRate),
-		queue: h,
+		seen:    bloom.NewFilter(n, fpRate),
+		queue:   h,
+		maxSize: maxSize,
 	}
 }
 
 // Push adds a link to the frontier.
-// Returns false if the URL has already been seen.
+// Returns false if the URL has already been seen or was dropped because the
+// queue is full.
 // URL fragments are stripped before deduplication - URLs differing only by fragment
 // are considered duplicates.
+//
+// When the queue is at its maximum size, the lowest-priority link among the
+// queued ones and the new one is dropped and counted in OverflowCount.
+// Dropped URLs stay marked as seen, so they are not queued again later.
 func (f *Frontier) Push(link locdoc.DiscoveredLink) bool {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -52,10 +61,33 @@ func (f *Frontier) Push(link locdoc.DiscoveredLink) bool {
 
 	// Store the URL without fragment
 	link.URL = url
+
+	if f.maxSize > 0 && f.queue.Len() >= f.maxSize {
+		f.overflow++
+		lowest := f.queue.lowest()
+		if (*f.queue)[lowest].Priority >= link.Priority {
+			return false
+		}
+		heap.Remove(f.queue, lowest)
+	}
+
 	heap.Push(f.queue, link)
 	return true
 }
 
+// MarkSeen marks a URL as seen without queuing it, so later pushes of the
+// URL are rejected. URL fragments are stripped first.
+func (f *Frontier) MarkSeen(rawURL string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	url := rawURL
+	if idx := strings.Index(url, "#"); idx != -1 {
+		url = url[:idx]
+	}
+	f.seen.Add(url)
+}
+
 // Pop returns the next link by priority.
 // The bool result is false if the frontier is empty.
 func (f *Frontier) Pop() (locdoc.DiscoveredLink, bool) {
@@ -76,6 +108,27 @@ func (f *Frontier) Len() int {
 	return f.queue.Len()
 }
 
+// Drain removes and returns all queued links in priority order.
+// The links stay marked as seen.
+func (f *Frontier) Drain() []locdoc.DiscoveredLink {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	links := make([]locdoc.DiscoveredLink, 0, f.queue.Len())
+	for f.queue.Len() > 0 {
+		link, _ := heap.Pop(f.queue).(locdoc.DiscoveredLink)
+		links = append(links, link)
+	}
+	return links
+}
+
+// OverflowCount returns the number of links dropped because the queue was full.
+func (f *Frontier) OverflowCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.overflow
+}
+
 // Seen returns true if the URL has been processed or queued.
 // URL fragments are stripped before checking.
 func (f *Frontier) Seen(rawURL string) bool {
@@ -102,6 +155,19 @@ func (h linkHeap) Less(i, j int) bool {
 
 func (h linkHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
 
+// lowest returns the index of the lowest-priority link. The heap must not be
+// empty. Only leaves are scanned, since a parent never has lower priority
+// than its children.
+func (h linkHeap) lowest() int {
+	idx := len(h) / 2
+	for i := idx + 1; i < len(h); i++ {
+		if h[i].Priority < h[idx].Priority {
+			idx = i
+		}
+	}
+	return idx
+}
+
 func (h *linkHeap) Push(x any) {
 	link, _ := x.(locdoc.DiscoveredLink)
 	*h = append(*h, link)
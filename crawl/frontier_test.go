@@ -2,18 +2,20 @@ package crawl_test
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/crawl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFrontier_Push_rejects_duplicate_URLs(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	link := locdoc.DiscoveredLink{
 		URL:      "https://example.com/docs/page1",
@@ -32,7 +34,7 @@ func TestFrontier_Push_rejects_duplicate_URLs(t *testing.T) {
 func TestFrontier_Push_deduplicates_URLs_with_different_fragments(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	// First push without fragment should succeed
 	ok := f.Push(locdoc.DiscoveredLink{
@@ -67,7 +69,7 @@ func TestFrontier_Push_deduplicates_URLs_with_different_fragments(t *testing.T)
 func TestFrontier_Push_strips_fragment_from_first_URL(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	// First push with fragment should succeed but store without fragment
 	ok := f.Push(locdoc.DiscoveredLink{
@@ -85,7 +87,7 @@ func TestFrontier_Push_strips_fragment_from_first_URL(t *testing.T) {
 func TestFrontier_Pop_returns_highest_priority_first(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	// Push links in random priority order
 	f.Push(locdoc.DiscoveredLink{URL: "https://example.com/footer", Priority: locdoc.PriorityFooter})
@@ -116,10 +118,53 @@ func TestFrontier_Pop_returns_highest_priority_first(t *testing.T) {
 	assert.False(t, ok, "pop on empty frontier should return false")
 }
 
+func TestFrontier_Push_drops_lowest_priority_links_when_full(t *testing.T) {
+	t.Parallel()
+
+	f := crawl.NewFrontier(1000, 0.01, 5)
+
+	// Interleave high and low priorities so both the new link and queued
+	// links get evicted.
+	priorities := []locdoc.LinkPriority{30, 80, 10, 100, 50, 20, 90, 40, 70, 60}
+	for i, p := range priorities {
+		f.Push(locdoc.DiscoveredLink{
+			URL:      fmt.Sprintf("https://example.com/page%d", i),
+			Priority: p,
+		})
+		assert.LessOrEqual(t, f.Len(), 5, "queue should never exceed max size")
+	}
+
+	assert.Equal(t, 5, f.OverflowCount())
+
+	var got []locdoc.LinkPriority
+	for {
+		link, ok := f.Pop()
+		if !ok {
+			break
+		}
+		got = append(got, link.Priority)
+	}
+	assert.Equal(t, []locdoc.LinkPriority{100, 90, 80, 70, 60}, got)
+}
+
+func TestFrontier_Push_returns_false_when_link_is_dropped(t *testing.T) {
+	t.Parallel()
+
+	f := crawl.NewFrontier(1000, 0.01, 1)
+
+	assert.True(t, f.Push(locdoc.DiscoveredLink{URL: "https://example.com/nav", Priority: locdoc.PriorityNavigation}))
+	assert.False(t, f.Push(locdoc.DiscoveredLink{URL: "https://example.com/footer", Priority: locdoc.PriorityFooter}))
+	assert.True(t, f.Push(locdoc.DiscoveredLink{URL: "https://example.com/toc", Priority: locdoc.PriorityTOC}))
+
+	assert.Equal(t, 1, f.Len())
+	assert.Equal(t, 2, f.OverflowCount())
+	assert.True(t, f.Seen("https://example.com/footer"), "dropped URL should stay seen")
+}
+
 func TestFrontier_Len_tracks_queue_size(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	assert.Equal(t, 0, f.Len(), "new frontier should be empty")
 
@@ -136,10 +181,40 @@ func TestFrontier_Len_tracks_queue_size(t *testing.T) {
 	assert.Equal(t, 0, f.Len())
 }
 
+func TestFrontier_Drain_empties_queue_in_priority_order(t *testing.T) {
+	t.Parallel()
+
+	f := crawl.NewFrontier(1000, 0.01, 0)
+	f.Push(locdoc.DiscoveredLink{URL: "https://example.com/footer", Priority: locdoc.PriorityFooter})
+	f.Push(locdoc.DiscoveredLink{URL: "https://example.com/toc", Priority: locdoc.PriorityTOC})
+	f.Push(locdoc.DiscoveredLink{URL: "https://example.com/nav", Priority: locdoc.PriorityNavigation})
+
+	links := f.Drain()
+
+	require.Len(t, links, 3)
+	assert.Equal(t, "https://example.com/toc", links[0].URL)
+	assert.Equal(t, "https://example.com/nav", links[1].URL)
+	assert.Equal(t, "https://example.com/footer", links[2].URL)
+	assert.Equal(t, 0, f.Len())
+	assert.Empty(t, f.Drain(), "second drain should return nothing")
+	assert.False(t, f.Push(locdoc.DiscoveredLink{URL: "https://example.com/toc"}), "drained URLs should stay seen")
+}
+
+func TestFrontier_MarkSeen_rejects_later_pushes(t *testing.T) {
+	t.Parallel()
+
+	f := crawl.NewFrontier(1000, 0.01, 0)
+	f.MarkSeen("https://example.com/page#section")
+
+	assert.True(t, f.Seen("https://example.com/page"))
+	assert.Equal(t, 0, f.Len(), "marked URL should not be queued")
+	assert.False(t, f.Push(locdoc.DiscoveredLink{URL: "https://example.com/page", Priority: locdoc.PriorityNavigation}))
+}
+
 func TestFrontier_Seen_tracks_all_pushed_URLs(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	assert.False(t, f.Seen("https://example.com/page"), "unseen URL should return false")
 
@@ -155,7 +230,7 @@ func TestFrontier_Seen_tracks_all_pushed_URLs(t *testing.T) {
 func TestFrontier_Seen_ignores_fragments(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(1000, 0.01)
+	f := crawl.NewFrontier(1000, 0.01, 0)
 
 	// Push URL without fragment
 	f.Push(locdoc.DiscoveredLink{URL: "https://example.com/page", Priority: locdoc.PriorityContent})
@@ -164,7 +239,7 @@ func TestFrontier_Seen_ignores_fragments(t *testing.T) {
 	assert.True(t, f.Seen("https://example.com/page#section"), "URL with fragment should be seen if base URL was pushed")
 
 	// Push URL with fragment
-	f2 := crawl.NewFrontier(1000, 0.01)
+	f2 := crawl.NewFrontier(1000, 0.01, 0)
 	f2.Push(locdoc.DiscoveredLink{URL: "https://example.com/page#section", Priority: locdoc.PriorityContent})
 
 	// Seen should return true for URL without fragment
@@ -177,7 +252,7 @@ func TestFrontier_fragment_edge_cases(t *testing.T) {
 	t.Run("handles URL with empty fragment", func(t *testing.T) {
 		t.Parallel()
 
-		f := crawl.NewFrontier(1000, 0.01)
+		f := crawl.NewFrontier(1000, 0.01, 0)
 
 		// URL with empty fragment should be treated as base URL
 		ok := f.Push(locdoc.DiscoveredLink{URL: "https://example.com/page#", Priority: locdoc.PriorityContent})
@@ -192,7 +267,7 @@ func TestFrontier_fragment_edge_cases(t *testing.T) {
 	t.Run("handles URL with multiple hash characters", func(t *testing.T) {
 		t.Parallel()
 
-		f := crawl.NewFrontier(1000, 0.01)
+		f := crawl.NewFrontier(1000, 0.01, 0)
 
 		// Only the first # should be the fragment delimiter
 		// "page#a#b" should become "page"
@@ -207,7 +282,7 @@ func TestFrontier_fragment_edge_cases(t *testing.T) {
 	t.Run("handles fragment with special characters", func(t *testing.T) {
 		t.Parallel()
 
-		f := crawl.NewFrontier(1000, 0.01)
+		f := crawl.NewFrontier(1000, 0.01, 0)
 
 		// Fragment with special characters should be stripped
 		ok := f.Push(locdoc.DiscoveredLink{URL: "https://example.com/page#section%20with%20spaces", Priority: locdoc.PriorityContent})
@@ -221,7 +296,7 @@ func TestFrontier_fragment_edge_cases(t *testing.T) {
 	t.Run("preserves query params when stripping fragment", func(t *testing.T) {
 		t.Parallel()
 
-		f := crawl.NewFrontier(1000, 0.01)
+		f := crawl.NewFrontier(1000, 0.01, 0)
 
 		// Query params should be preserved, only fragment stripped
 		ok := f.Push(locdoc.DiscoveredLink{URL: "https://example.com/page?q=test#section", Priority: locdoc.PriorityContent})
@@ -236,7 +311,7 @@ func TestFrontier_fragment_edge_cases(t *testing.T) {
 func TestFrontier_concurrent_access(t *testing.T) {
 	t.Parallel()
 
-	f := crawl.NewFrontier(10000, 0.01)
+	f := crawl.NewFrontier(10000, 0.01, 0)
 
 	const numGoroutines = 10
 	const numOpsPerGoroutine = 100
@@ -280,3 +355,30 @@ func TestFrontier_concurrent_access(t *testing.T) {
 		}
 	}
 }
+
+func FuzzFrontier_Push(f *testing.F) {
+	for _, seed := range []string{
+		"https://example.com/docs/intro",
+		"https://example.com/page#section",
+		"#",
+		"",
+		"://missing-scheme",
+		"https://example.com/\x00\xff",
+	} {
+		f.Add(seed, 50)
+	}
+
+	f.Fuzz(func(t *testing.T, rawURL string, priority int) {
+		fr := crawl.NewFrontier(100, 0.01, 2)
+
+		fr.Push(locdoc.DiscoveredLink{URL: rawURL, Priority: locdoc.LinkPriority(priority)})
+
+		require.True(t, fr.Seen(rawURL), "pushed URL should be seen")
+		require.False(t, fr.Push(locdoc.DiscoveredLink{URL: rawURL}), "duplicate push should be rejected")
+
+		link, ok := fr.Pop()
+		require.True(t, ok)
+		require.NotContains(t, link.URL, "#", "fragment should be stripped")
+		require.True(t, strings.HasPrefix(rawURL, link.URL))
+	})
+}
@@ -13,22 +13,41 @@ var _ locdoc.DomainLimiter = (*DomainLimiter)(nil)
 // DomainLimiter provides per-domain rate limiting using token buckets.
 // It creates a separate rate limiter for each domain, allowing concurrent
 // requests to different domains while enforcing rate limits within each domain.
+// An optional global limit caps the combined rate across all domains.
 type DomainLimiter struct {
 	mu       sync.Mutex
 	limiters map[string]*rate.Limiter
 	rps      float64
+	global   *rate.Limiter
+}
+
+// LimiterOption configures a DomainLimiter.
+type LimiterOption func(*DomainLimiter)
+
+// WithGlobalRateLimit caps the combined request rate across all domains,
+// e.g. for sites that spread content over many CDN subdomains. The global
+// limiter has a burst of 1, like the per-domain ones.
+func WithGlobalRateLimit(rps float64) LimiterOption {
+	return func(d *DomainLimiter) {
+		d.global = rate.NewLimiter(rate.Limit(rps), 1)
+	}
 }
 
 // NewDomainLimiter creates a new DomainLimiter with the specified requests per second limit.
 // Each domain gets its own limiter with a burst of 1 (no bursting allowed).
-func NewDomainLimiter(rps float64) *DomainLimiter {
-	return &DomainLimiter{
+func NewDomainLimiter(rps float64, opts ...LimiterOption) *DomainLimiter {
+	d := &DomainLimiter{
 		limiters: make(map[string]*rate.Limiter),
 		rps:      rps,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
-// Wait blocks until the rate limit allows a request to the domain.
+// Wait blocks until the rate limit allows a request to the domain and, when
+// a global limit is set, until the global limit allows it too.
 // Returns an error if the context is canceled before the wait completes.
 func (d *DomainLimiter) Wait(ctx context.Context, domain string) error {
 	d.mu.Lock()
@@ -39,5 +58,11 @@ func (d *DomainLimiter) Wait(ctx context.Context, domain string) error {
 	}
 	d.mu.Unlock()
 
-	return limiter.Wait(ctx)
+	if err := limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if d.global == nil {
+		return nil
+	}
+	return d.global.Wait(ctx)
 }
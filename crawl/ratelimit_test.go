@@ -2,6 +2,7 @@ package crawl_test
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -110,4 +111,36 @@ func TestDomainLimiter(t *testing.T) {
 		wg.Wait()
 		assert.Equal(t, int32(5), completed.Load(), "all requests should complete")
 	})
+	t.Run("global limit caps the combined rate across domains", func(t *testing.T) {
+		t.Parallel()
+
+		// Per-domain limit is generous; the 5 req/sec global limit governs
+		limiter := crawl.NewDomainLimiter(100, crawl.WithGlobalRateLimit(5))
+
+		var wg sync.WaitGroup
+		var completed atomic.Int32
+
+		start := time.Now()
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := limiter.Wait(context.Background(), fmt.Sprintf("cdn%d.example.com", i))
+				if err == nil {
+					completed.Add(1)
+				}
+			}()
+		}
+
+		// Burst of 1 plus 5 req/sec: at most 4 requests by 500ms
+		time.Sleep(500 * time.Millisecond)
+		assert.LessOrEqual(t, completed.Load(), int32(4), "global limit exceeded")
+
+		wg.Wait()
+		elapsed := time.Since(start)
+
+		assert.Equal(t, int32(10), completed.Load(), "all requests should complete")
+		// 10 requests at 5 req/sec with a burst of 1 take at least 1.8s
+		assert.GreaterOrEqual(t, elapsed, 1700*time.Millisecond, "should wait for global limit")
+	})
 }
@@ -0,0 +1,59 @@
+package crawl
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// codeBlockPattern matches fenced code blocks, which are not prose.
+	codeBlockPattern = regexp.MustCompile("(?s)```.*?```")
+	// sentenceEndPattern matches a run of sentence-ending punctuation
+	// followed by whitespace or the end of the text.
+	sentenceEndPattern = regexp.MustCompile(`[.?!]+(\s|$)`)
+)
+
+// ReadabilityScore returns the Flesch-Kincaid Grade Level of Markdown
+// content, roughly the US school grade needed to understand it. Words are
+// whitespace-delimited tokens containing a letter, sentences end in . ? or !,
+// and syllables are approximated by groups of consecutive vowels. Code blocks
+// and link targets are ignored. Returns 0 for content without words; very
+// simple text is clamped to 0 rather than going negative.
+func ReadabilityScore(markdown string) float32 {
+	text := codeBlockPattern.ReplaceAllString(markdown, "")
+	text = linkTargetPattern.ReplaceAllString(text, "]")
+
+	var words, syllables int
+	for _, token := range strings.Fields(text) {
+		n := countSyllables(token)
+		if n == 0 && !strings.ContainsFunc(token, unicode.IsLetter) {
+			continue
+		}
+		words++
+		syllables += max(n, 1)
+	}
+	if words == 0 {
+		return 0
+	}
+
+	sentences := max(len(sentenceEndPattern.FindAllString(text, -1)), 1)
+
+	grade := 0.39*float64(words)/float64(sentences) + 11.8*float64(syllables)/float64(words) - 15.59
+	return float32(max(grade, 0))
+}
+
+// countSyllables approximates the number of syllables in a word by counting
+// groups of consecutive vowels.
+func countSyllables(word string) int {
+	var count int
+	var inVowel bool
+	for _, r := range strings.ToLower(word) {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !inVowel {
+			count++
+		}
+		inVowel = vowel
+	}
+	return count
+}
@@ -0,0 +1,59 @@
+package crawl_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc/crawl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadabilityScore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns zero for empty content", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Zero(t, crawl.ReadabilityScore(""))
+		assert.Zero(t, crawl.ReadabilityScore("```\ncode only\n```"))
+	})
+
+	t.Run("scores short simple sentences low", func(t *testing.T) {
+		t.Parallel()
+
+		score := crawl.ReadabilityScore("The cat sat on the mat. The dog ran. It was fun!")
+
+		assert.InDelta(t, 0, score, 1)
+	})
+
+	t.Run("scores typical documentation prose as mid grade", func(t *testing.T) {
+		t.Parallel()
+
+		markdown := "# Getting Started\n\n" +
+			"Install the package with your favorite package manager. " +
+			"Then import the client and call the connect method to open a session. " +
+			"The session stays open until you close it or the server times out."
+
+		score := crawl.ReadabilityScore(markdown)
+
+		assert.Greater(t, score, float32(4))
+		assert.Less(t, score, float32(12))
+	})
+
+	t.Run("scores long polysyllabic sentences high", func(t *testing.T) {
+		t.Parallel()
+
+		score := crawl.ReadabilityScore("Comprehensive documentation facilitates understanding of sophisticated architectural considerations.")
+
+		assert.Greater(t, score, float32(20))
+	})
+
+	t.Run("ignores code blocks and link targets", func(t *testing.T) {
+		t.Parallel()
+
+		plain := crawl.ReadabilityScore("See the guide for details. It is short.")
+		withMarkup := crawl.ReadabilityScore("See the [guide](https://example.com/internationalization/configuration) for details. It is short.\n\n" +
+			"```go\nfunc initializeApplicationConfiguration() {}\n```")
+
+		assert.InDelta(t, plain, withMarkup, 0.001)
+	})
+}
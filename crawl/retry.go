@@ -2,30 +2,71 @@ package crawl
 
 import (
 	"context"
+	"math"
+	"math/rand/v2"
 	"time"
 )
 
 // FetchFunc is the signature for a fetch function.
 type FetchFunc func(ctx context.Context, url string) (string, error)
 
-// LogFunc is the signature for a logging function.
-type LogFunc func(format string, args ...any)
+// RetryFunc is called before each retry of a failed fetch. attempt is the
+// number of the attempt about to be made, starting at 2 for the first retry,
+// and err is the error that caused the retry.
+type RetryFunc func(attempt int, err error)
 
 // DefaultRetryDelays returns the backoff delays for fetch retries: 1s, 2s, 4s.
 func DefaultRetryDelays() []time.Duration {
 	return []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
 }
 
+// DefaultRetryJitter is the default maximum jitter applied to retry delays,
+// as a fraction of each delay.
+const DefaultRetryJitter = 0.1
+
+// ExponentialRetryDelays returns maxRetries delays starting at base and
+// growing by factor: base, base*factor, base*factor^2, ...
+// Returns an empty (non-nil) slice when maxRetries is 0, disabling retries.
+func ExponentialRetryDelays(base time.Duration, maxRetries int, factor float64) []time.Duration {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	delays := make([]time.Duration, maxRetries)
+	for i := range delays {
+		delays[i] = time.Duration(float64(base) * math.Pow(factor, float64(i)))
+	}
+	return delays
+}
+
+// JitteredRetryDelays returns a copy of delays with each entry randomly
+// adjusted by up to ±maxJitter*delay, so that concurrent workers failing at
+// the same time don't retry in lockstep. A maxJitter of 0 returns delays
+// unchanged.
+func JitteredRetryDelays(delays []time.Duration, maxJitter float64) []time.Duration {
+	if maxJitter <= 0 {
+		return delays
+	}
+	jittered := make([]time.Duration, len(delays))
+	for i, d := range delays {
+		offset := (rand.Float64()*2 - 1) * maxJitter * float64(d)
+		jittered[i] = d + time.Duration(offset)
+	}
+	return jittered
+}
+
 // FetchWithRetry attempts to fetch a URL with exponential backoff retry logic.
 // It retries up to 3 times (4 total attempts) with delays of 1s, 2s, 4s.
-// The logger function, if provided, is called for each retry attempt.
-func FetchWithRetry(ctx context.Context, url string, fetch FetchFunc, logger LogFunc) (string, error) {
-	return FetchWithRetryDelays(ctx, url, fetch, logger, DefaultRetryDelays())
+// The onRetry function, if provided, is called before each retry, e.g. to
+// log it.
+func FetchWithRetry(ctx context.Context, url string, fetch FetchFunc, onRetry RetryFunc) (string, error) {
+	return FetchWithRetryDelays(ctx, url, fetch, onRetry, DefaultRetryDelays())
 }
 
-// FetchWithRetryDelays is like FetchWithRetry but allows configurable delays.
-// This is useful for testing without waiting for real delays.
-func FetchWithRetryDelays(ctx context.Context, url string, fetch FetchFunc, logger LogFunc, delays []time.Duration) (string, error) {
+// FetchWithRetryDelays is like FetchWithRetry but waits delays[i] before
+// retry i+1, so len(delays)+1 attempts are made in total. It returns the
+// last error once all attempts fail, or the context error if ctx is done
+// while waiting.
+func FetchWithRetryDelays(ctx context.Context, url string, fetch FetchFunc, onRetry RetryFunc, delays []time.Duration) (string, error) {
 	maxAttempts := len(delays) + 1 // 1 initial + N retries
 
 	var lastErr error
@@ -48,9 +89,8 @@ func FetchWithRetryDelays(ctx context.Context, url string, fetch FetchFunc, logg
 		default:
 		}
 
-		// Log retry
-		if logger != nil {
-			logger("  retry %s (attempt %d): %v", url, attempt+2, err)
+		if onRetry != nil {
+			onRetry(attempt+2, err)
 		}
 
 		// Wait before next attempt
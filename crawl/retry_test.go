@@ -3,6 +3,7 @@ package crawl_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -90,53 +91,56 @@ func TestFetchWithRetry(t *testing.T) {
 		assert.True(t, errors.Is(err, context.Canceled) || attempts <= 2, "should stop on context cancellation")
 	})
 
-	t.Run("logs retry attempts", func(t *testing.T) {
+	t.Run("calls onRetry before a retry", func(t *testing.T) {
 		t.Parallel()
 
+		transient := errors.New("transient error")
 		var attempts int
 		fetcher := func(ctx context.Context, url string) (string, error) {
 			attempts++
 			if attempts < 2 {
-				return "", errors.New("transient error")
+				return "", transient
 			}
 			return "<html>success</html>", nil
 		}
 
-		var logs []string
-		logger := func(format string, args ...any) {
-			logs = append(logs, format)
+		var retries []int
+		var errs []error
+		onRetry := func(attempt int, err error) {
+			retries = append(retries, attempt)
+			errs = append(errs, err)
 		}
 
-		html, err := crawl.FetchWithRetryDelays(context.Background(), "https://example.com/page", fetcher, logger, noDelays)
+		html, err := crawl.FetchWithRetryDelays(context.Background(), "https://example.com/page", fetcher, onRetry, noDelays)
 
 		require.NoError(t, err)
 		assert.Equal(t, "<html>success</html>", html)
-		assert.Len(t, logs, 1, "should log one retry")
+		assert.Equal(t, []int{2}, retries)
+		assert.Equal(t, []error{transient}, errs)
 	})
 
-	t.Run("logs multiple retry attempts", func(t *testing.T) {
+	t.Run("numbers each retry attempt", func(t *testing.T) {
 		t.Parallel()
 
-		maxAttempts := len(noDelays) + 1
 		var attempts int
 		fetcher := func(ctx context.Context, url string) (string, error) {
 			attempts++
-			if attempts < maxAttempts {
-				return "", errors.New("transient error")
-			}
-			return "<html>success</html>", nil
+			return "", fmt.Errorf("failure %d", attempts)
 		}
 
-		var logs []string
-		logger := func(format string, args ...any) {
-			logs = append(logs, format)
+		var retries []int
+		var msgs []string
+		onRetry := func(attempt int, err error) {
+			retries = append(retries, attempt)
+			msgs = append(msgs, err.Error())
 		}
 
-		html, err := crawl.FetchWithRetryDelays(context.Background(), "https://example.com/page", fetcher, logger, noDelays)
+		_, err := crawl.FetchWithRetryDelays(context.Background(), "https://example.com/page", fetcher, onRetry, noDelays)
 
-		require.NoError(t, err)
-		assert.Equal(t, "<html>success</html>", html)
-		assert.Len(t, logs, len(noDelays), "should log N retries for N delays")
+		require.Error(t, err)
+		assert.Equal(t, []int{2, 3, 4}, retries, "one call per delay, numbered by the attempt about to start")
+		assert.Equal(t, []string{"failure 1", "failure 2", "failure 3"}, msgs)
+		assert.Equal(t, "failure 4", err.Error(), "no retry after the last attempt")
 	})
 
 	t.Run("number of retries matches delay count", func(t *testing.T) {
@@ -167,3 +171,51 @@ func TestDefaultRetryDelays(t *testing.T) {
 	assert.Equal(t, 2*time.Second, delays[1])
 	assert.Equal(t, 4*time.Second, delays[2])
 }
+
+func TestExponentialRetryDelays(t *testing.T) {
+	t.Parallel()
+
+	t.Run("multiplies delay by factor for each retry", func(t *testing.T) {
+		t.Parallel()
+
+		delays := crawl.ExponentialRetryDelays(time.Second, 3, 2.0)
+
+		assert.Equal(t, []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}, delays)
+	})
+
+	t.Run("returns empty non-nil slice when retries are disabled", func(t *testing.T) {
+		t.Parallel()
+
+		delays := crawl.ExponentialRetryDelays(time.Second, 0, 2.0)
+
+		assert.NotNil(t, delays)
+		assert.Empty(t, delays)
+	})
+}
+
+func TestJitteredRetryDelays(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps delays within jitter range", func(t *testing.T) {
+		t.Parallel()
+
+		delays := crawl.ExponentialRetryDelays(time.Second, 3, 2.0)
+
+		for i := 0; i < 100; i++ {
+			jittered := crawl.JitteredRetryDelays(delays, 0.25)
+			require.Len(t, jittered, len(delays))
+			for j, d := range delays {
+				assert.GreaterOrEqual(t, jittered[j], d-d/4)
+				assert.LessOrEqual(t, jittered[j], d+d/4)
+			}
+		}
+	})
+
+	t.Run("returns delays unchanged without jitter", func(t *testing.T) {
+		t.Parallel()
+
+		delays := crawl.DefaultRetryDelays()
+
+		assert.Equal(t, delays, crawl.JitteredRetryDelays(delays, 0))
+	})
+}
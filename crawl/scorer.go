@@ -0,0 +1,71 @@
+package crawl
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// DefaultMinQualityScore is the quality score below which pages are skipped.
+// Low enough that any page with a sentence of prose passes.
+const DefaultMinQualityScore = 0.1
+
+// Ensure BasicContentScorer implements locdoc.ContentScorer at compile time.
+var _ locdoc.ContentScorer = (*BasicContentScorer)(nil)
+
+var (
+	// linkTargetPattern matches the target part of Markdown links and images.
+	linkTargetPattern = regexp.MustCompile(`\]\([^)]*\)`)
+	// sentencePattern matches a sentence ending: a letter or digit followed
+	// by a period, exclamation or question mark, or a full-width (CJK)
+	// terminator, which is not followed by a space.
+	sentencePattern = regexp.MustCompile(`[\p{L}\p{N}][.!?](\s|$)|[。！？]`)
+	// tableSeparatorPattern matches the delimiter row of a Markdown pipe table.
+	tableSeparatorPattern = regexp.MustCompile(`(?m)^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)+\|?\s*$`)
+)
+
+// BasicContentScorer scores Markdown content using simple heuristics:
+// the share of prose characters (link targets and Markdown syntax count as
+// markup), the number of sentences, and the presence of fenced code blocks
+// or tables. Each signal adds to the score on its own, so a page of CJK prose
+// or a reference page made of tables passes without Latin sentences.
+// Navigation-only pages are mostly link markup with no sentences or
+// structure, so they score close to zero.
+type BasicContentScorer struct{}
+
+// NewBasicContentScorer creates a new BasicContentScorer.
+func NewBasicContentScorer() *BasicContentScorer {
+	return &BasicContentScorer{}
+}
+
+// Score returns a quality score between 0.0 and 1.0.
+func (s *BasicContentScorer) Score(content string) float64 {
+	if strings.TrimSpace(content) == "" {
+		return 0
+	}
+
+	// Share of letters, digits and spaces once link targets are removed
+	text := linkTargetPattern.ReplaceAllString(content, "")
+	var prose int
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' {
+			prose++
+		}
+	}
+	textRatio := float64(prose) / float64(len([]rune(content)))
+
+	// Five sentences are enough to count as real prose
+	sentences := float64(len(sentencePattern.FindAllString(content, -1))) / 5
+	if sentences > 1 {
+		sentences = 1
+	}
+
+	var structure float64
+	if strings.Contains(content, "```") || tableSeparatorPattern.MatchString(content) {
+		structure = 1
+	}
+
+	return 0.1*textRatio + 0.6*sentences + 0.3*structure
+}
@@ -0,0 +1,71 @@
+package crawl_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc/crawl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicContentScorer_Score(t *testing.T) {
+	t.Parallel()
+
+	scorer := crawl.NewBasicContentScorer()
+
+	t.Run("scores empty content as zero", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Zero(t, scorer.Score("  \n"))
+	})
+
+	t.Run("scores navigation-only content below default threshold", func(t *testing.T) {
+		t.Parallel()
+
+		content := "- [Home](/)\n- [Getting Started](/docs/start)\n- [API](/docs/api)\n- [Blog](/blog)\n"
+
+		assert.Less(t, scorer.Score(content), crawl.DefaultMinQualityScore)
+	})
+
+	t.Run("scores loading placeholder below default threshold", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Less(t, scorer.Score("Loading..."), crawl.DefaultMinQualityScore)
+	})
+
+	t.Run("scores prose with code above default threshold", func(t *testing.T) {
+		t.Parallel()
+
+		content := "# Installation\n\nInstall the CLI with Go. It requires Go 1.22 or later. " +
+			"The binary is placed in your GOPATH. Add it to your PATH. Then run the setup command.\n\n" +
+			"```\ngo install example.com/cli@latest\n```\n"
+
+		score := scorer.Score(content)
+
+		assert.Greater(t, score, crawl.DefaultMinQualityScore)
+		assert.LessOrEqual(t, score, 1.0)
+	})
+
+	t.Run("scores CJK prose above default threshold", func(t *testing.T) {
+		t.Parallel()
+
+		content := "# 安装\n\n使用 Go 安装命令行工具。需要 Go 1.22 或更高版本。安装后将其添加到 PATH 中。\n"
+
+		assert.Greater(t, scorer.Score(content), crawl.DefaultMinQualityScore)
+	})
+
+	t.Run("scores table-only content above default threshold", func(t *testing.T) {
+		t.Parallel()
+
+		content := "| Option | Type | Default |\n|---|---|---|\n| timeout | duration | 30s |\n| retries | int | 3 |\n"
+
+		assert.Greater(t, scorer.Score(content), crawl.DefaultMinQualityScore)
+	})
+
+	t.Run("scores code blocks higher than the same prose without code", func(t *testing.T) {
+		t.Parallel()
+
+		prose := "Run the command to start the server.\n\n"
+
+		assert.Greater(t, scorer.Score(prose+"```\nserve\n```\n"), scorer.Score(prose))
+	})
+}
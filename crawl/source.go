@@ -0,0 +1,48 @@
+package crawl
+
+import (
+	"context"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Ensure CompositeURLSource implements locdoc.URLSource.
+var _ locdoc.URLSource = (*CompositeURLSource)(nil)
+
+// CompositeURLSource implements locdoc.URLSource by trying sitemap discovery
+// first and falling back to recursive crawling if the site has no sitemap
+// or the sitemap is empty.
+type CompositeURLSource struct {
+	sitemap   locdoc.SitemapService
+	recursive RecursiveDiscoverer
+}
+
+// NewCompositeURLSource creates a new CompositeURLSource.
+// The sitemap parameter is used for sitemap-based discovery.
+// The recursive parameter is used when the site has no sitemap or the
+// sitemap returns no URLs; it may be nil to disable the fallback.
+func NewCompositeURLSource(sitemap locdoc.SitemapService, recursive RecursiveDiscoverer) *CompositeURLSource {
+	return &CompositeURLSource{
+		sitemap:   sitemap,
+		recursive: recursive,
+	}
+}
+
+// Discover implements locdoc.URLSource.
+func (s *CompositeURLSource) Discover(ctx context.Context, sourceURL string) ([]string, error) {
+	urls, err := s.sitemap.DiscoverURLs(ctx, sourceURL, nil)
+	if err != nil && locdoc.ErrorCode(err) != locdoc.ENOTFOUND {
+		return nil, err
+	}
+
+	if len(urls) > 0 {
+		return urls, nil
+	}
+
+	// Fallback to recursive discovery
+	if s.recursive != nil {
+		return s.recursive.DiscoverURLs(ctx, sourceURL, nil)
+	}
+
+	return urls, nil
+}
@@ -1,11 +1,11 @@
-package main_test
+package crawl_test
 
 import (
 	"context"
 	"testing"
 
 	"github.com/fwojciec/locdoc"
-	main "github.com/fwojciec/locdoc/cmd/docfetch"
+	"github.com/fwojciec/locdoc/crawl"
 	"github.com/fwojciec/locdoc/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,7 +14,7 @@ import (
 // Story: Composite URL Discovery
 // The source tries multiple strategies to find documentation URLs
 
-func TestCompositeSource_UsesSitemapWhenAvailable(t *testing.T) {
+func TestCompositeURLSource_UsesSitemapWhenAvailable(t *testing.T) {
 	t.Parallel()
 
 	// Given a sitemap service returns URLs
@@ -23,7 +23,7 @@ func TestCompositeSource_UsesSitemapWhenAvailable(t *testing.T) {
 			return []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}, nil
 		},
 	}
-	source := main.NewCompositeSource(sitemap, nil)
+	source := crawl.NewCompositeURLSource(sitemap, nil)
 
 	// When I discover URLs
 	urls, err := source.Discover(context.Background(), "https://example.com")
@@ -33,7 +33,7 @@ func TestCompositeSource_UsesSitemapWhenAvailable(t *testing.T) {
 	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}, urls)
 }
 
-func TestCompositeSource_FallsBackToRecursiveWhenSitemapEmpty(t *testing.T) {
+func TestCompositeURLSource_FallsBackToRecursiveWhenSitemapEmpty(t *testing.T) {
 	t.Parallel()
 
 	// Given sitemap returns no URLs
@@ -46,7 +46,7 @@ func TestCompositeSource_FallsBackToRecursiveWhenSitemapEmpty(t *testing.T) {
 	recursive := &mockRecursiveDiscoverer{
 		urls: []string{"https://example.com/x", "https://example.com/y"},
 	}
-	source := main.NewCompositeSource(sitemap, recursive)
+	source := crawl.NewCompositeURLSource(sitemap, recursive)
 
 	// When I discover URLs
 	urls, err := source.Discover(context.Background(), "https://example.com")
@@ -56,7 +56,30 @@ func TestCompositeSource_FallsBackToRecursiveWhenSitemapEmpty(t *testing.T) {
 	assert.Equal(t, []string{"https://example.com/x", "https://example.com/y"}, urls)
 }
 
-// mockRecursiveDiscoverer is a test helper that implements main.RecursiveDiscoverer.
+func TestCompositeURLSource_FallsBackToRecursiveWhenNoSitemap(t *testing.T) {
+	t.Parallel()
+
+	// Given the site has no sitemap
+	sitemap := &mock.SitemapService{
+		DiscoverURLsFn: func(_ context.Context, _ string, _ *locdoc.URLFilter) ([]string, error) {
+			return nil, locdoc.ErrSitemapNotFound
+		},
+	}
+	// And recursive discoverer finds some
+	recursive := &mockRecursiveDiscoverer{
+		urls: []string{"https://example.com/x"},
+	}
+	source := crawl.NewCompositeURLSource(sitemap, recursive)
+
+	// When I discover URLs
+	urls, err := source.Discover(context.Background(), "https://example.com")
+
+	// Then recursive URLs are returned
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/x"}, urls)
+}
+
+// mockRecursiveDiscoverer is a test helper that implements crawl.RecursiveDiscoverer.
 type mockRecursiveDiscoverer struct {
 	urls []string
 	err  error
@@ -66,7 +89,7 @@ func (m *mockRecursiveDiscoverer) DiscoverURLs(_ context.Context, _ string, _ *l
 	return m.urls, m.err
 }
 
-func TestCompositeSource_ReturnsEmptyWhenBothFail(t *testing.T) {
+func TestCompositeURLSource_ReturnsEmptyWhenBothFail(t *testing.T) {
 	t.Parallel()
 
 	// Given both discovery methods find nothing
@@ -78,7 +101,7 @@ func TestCompositeSource_ReturnsEmptyWhenBothFail(t *testing.T) {
 	recursive := &mockRecursiveDiscoverer{
 		urls: []string{},
 	}
-	source := main.NewCompositeSource(sitemap, recursive)
+	source := crawl.NewCompositeURLSource(sitemap, recursive)
 
 	// When I discover URLs
 	urls, err := source.Discover(context.Background(), "https://example.com")
@@ -88,7 +111,7 @@ func TestCompositeSource_ReturnsEmptyWhenBothFail(t *testing.T) {
 	assert.Empty(t, urls)
 }
 
-func TestCompositeSource_PropagatesSitemapError(t *testing.T) {
+func TestCompositeURLSource_PropagatesSitemapError(t *testing.T) {
 	t.Parallel()
 
 	// Given sitemap service returns an error
@@ -97,7 +120,7 @@ func TestCompositeSource_PropagatesSitemapError(t *testing.T) {
 			return nil, assert.AnError
 		},
 	}
-	source := main.NewCompositeSource(sitemap, nil)
+	source := crawl.NewCompositeURLSource(sitemap, nil)
 
 	// When I discover URLs
 	_, err := source.Discover(context.Background(), "https://example.com")
@@ -106,7 +129,7 @@ func TestCompositeSource_PropagatesSitemapError(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestCompositeSource_PropagatesRecursiveError(t *testing.T) {
+func TestCompositeURLSource_PropagatesRecursiveError(t *testing.T) {
 	t.Parallel()
 
 	// Given sitemap returns empty
@@ -119,7 +142,7 @@ func TestCompositeSource_PropagatesRecursiveError(t *testing.T) {
 	recursive := &mockRecursiveDiscoverer{
 		err: assert.AnError,
 	}
-	source := main.NewCompositeSource(sitemap, recursive)
+	source := crawl.NewCompositeURLSource(sitemap, recursive)
 
 	// When I discover URLs
 	_, err := source.Discover(context.Background(), "https://example.com")
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fwojciec/locdoc"
@@ -17,16 +18,25 @@ const (
 	frontierExpectedURLs = 10000
 	// frontierFalsePositiveRate is the acceptable false positive rate for deduplication.
 	frontierFalsePositiveRate = 0.01
-	// maxRecursiveCrawlURLs limits the number of URLs processed to prevent runaway crawls.
+	// maxRecursiveCrawlURLs limits the number of URLs processed to prevent
+	// runaway crawls when no MaxCrawlURLs is configured.
 	maxRecursiveCrawlURLs = 1000
 )
 
+// DefaultDrainTimeout is how long a walk waits for in-flight URLs to finish
+// after it stops dispatching work.
+const DefaultDrainTimeout = 30 * time.Second
+
 // walkProcessor processes a URL and returns a crawlResult.
 type walkProcessor func(ctx context.Context, link locdoc.DiscoveredLink, fetcher locdoc.Fetcher) crawlResult
 
 // walkResultHandler handles a completed crawlResult.
 // It should add discovered links to the frontier (after filtering) and handle the result.
-type walkResultHandler func(result *crawlResult, frontier *Frontier, parsedSourceURL *url.URL, pathPrefix string, urlFilter *locdoc.URLFilter)
+// Returning true stops the walk from dispatching further URLs; URLs already
+// being processed still finish and are handled. Results drained after the
+// walk's context is canceled are handled with a context that is not canceled
+// but expires with the drain timeout, so they can still be saved.
+type walkResultHandler func(ctx context.Context, result *crawlResult, frontier *Frontier, parsedSourceURL *url.URL, pathPrefix string, urlFilter *locdoc.URLFilter) bool
 
 // walkFrontier manages concurrent URL processing starting from sourceURL.
 // It handles the shared logic between DiscoverURLs and recursiveCrawl:
@@ -36,24 +46,40 @@ type walkResultHandler func(result *crawlResult, frontier *Frontier, parsedSourc
 //
 // The processURL function is called for each URL to fetch and process it.
 // The handleResult function is called for each result to filter links and handle the outcome.
+//
+// At most maxURLs URLs are dispatched; zero uses maxRecursiveCrawlURLs.
+// When the walk stops early, URLs already being processed are given up to
+// drainTimeout to finish so their results are still handled. A drainTimeout
+// of zero uses DefaultDrainTimeout.
+//
+// active is incremented while a worker processes a URL and decremented
+// when it finishes.
+//
+// Returns the links that were discovered but never processed, because
+// maxURLs was reached, handleResult stopped the walk or ctx was canceled
+// (including links already handed to a worker that had not started them).
 func walkFrontier(
 	ctx context.Context,
 	sourceURL string,
 	urlFilter *locdoc.URLFilter,
 	fetcher locdoc.Fetcher,
 	concurrency int,
+	frontierSize int,
+	maxURLs int,
+	drainTimeout time.Duration,
+	active *atomic.Int32,
 	processURL walkProcessor,
 	handleResult walkResultHandler,
-) error {
+) ([]locdoc.DiscoveredLink, error) {
 	// Parse source URL to get base path for scope limiting
 	parsedSourceURL, err := url.Parse(sourceURL)
 	if err != nil {
-		return fmt.Errorf("invalid source URL: %w", err)
+		return nil, fmt.Errorf("invalid source URL: %w", err)
 	}
 	pathPrefix := parsedSourceURL.Path
 
 	// Create frontier and seed with source URL
-	frontier := NewFrontier(frontierExpectedURLs, frontierFalsePositiveRate)
+	frontier := NewFrontier(frontierExpectedURLs, frontierFalsePositiveRate, frontierSize)
 	frontier.Push(locdoc.DiscoveredLink{
 		URL:      sourceURL,
 		Priority: locdoc.PriorityNavigation,
@@ -63,11 +89,26 @@ func walkFrontier(
 	if concurrency <= 0 {
 		concurrency = 3
 	}
+	if maxURLs <= 0 {
+		maxURLs = maxRecursiveCrawlURLs
+	}
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
 
 	// Channels for worker coordination
 	workCh := make(chan locdoc.DiscoveredLink, concurrency)
 	resultCh := make(chan crawlResult)
 
+	// Closed on return so workers stop waiting to deliver results that
+	// arrived after the drain timeout
+	done := make(chan struct{})
+	defer close(done)
+
+	// Links handed to workers after cancellation are never started
+	var droppedMu sync.Mutex
+	var dropped []locdoc.DiscoveredLink
+
 	// Start worker pool
 	var wg sync.WaitGroup
 	for i := 0; i < concurrency; i++ {
@@ -75,10 +116,18 @@ func walkFrontier(
 		go func() {
 			defer wg.Done()
 			for link := range workCh {
+				if ctx.Err() != nil {
+					droppedMu.Lock()
+					dropped = append(dropped, link)
+					droppedMu.Unlock()
+					continue
+				}
+				active.Add(1)
 				result := processURL(ctx, link, fetcher)
+				active.Add(-1)
 				select {
 				case resultCh <- result:
-				case <-ctx.Done():
+				case <-done:
 					return
 				}
 			}
@@ -94,6 +143,7 @@ func walkFrontier(
 	// Coordinator loop
 	processedCount := 0 // URLs dispatched to workers
 	pending := 0        // URLs currently being processed
+	stopped := false    // Set once handleResult asks to stop dispatching
 	var nextLink *locdoc.DiscoveredLink
 
 	// Get first link
@@ -104,7 +154,7 @@ func walkFrontier(
 coordinatorLoop:
 	for {
 		// Check termination conditions
-		if nextLink == nil && pending == 0 {
+		if (nextLink == nil || stopped) && pending == 0 {
 			break coordinatorLoop
 		}
 
@@ -114,7 +164,7 @@ coordinatorLoop:
 		}
 
 		// Try to dispatch work or receive results
-		if nextLink != nil && processedCount < maxRecursiveCrawlURLs {
+		if nextLink != nil && processedCount < maxURLs && !stopped {
 			select {
 			case <-ctx.Done():
 				break coordinatorLoop
@@ -124,7 +174,9 @@ coordinatorLoop:
 				nextLink = nil
 			case crawlRes := <-resultCh:
 				pending--
-				handleResult(&crawlRes, frontier, parsedSourceURL, pathPrefix, urlFilter)
+				if handleResult(ctx, &crawlRes, frontier, parsedSourceURL, pathPrefix, urlFilter) {
+					stopped = true
+				}
 			}
 		} else {
 			// No more work to dispatch, just receive results
@@ -136,12 +188,14 @@ coordinatorLoop:
 					break coordinatorLoop
 				}
 				pending--
-				handleResult(&crawlRes, frontier, parsedSourceURL, pathPrefix, urlFilter)
+				if handleResult(ctx, &crawlRes, frontier, parsedSourceURL, pathPrefix, urlFilter) {
+					stopped = true
+				}
 			}
 		}
 
 		// Try to get next link if we don't have one
-		if nextLink == nil && processedCount < maxRecursiveCrawlURLs {
+		if nextLink == nil && processedCount < maxURLs && !stopped {
 			if link, ok := frontier.Pop(); ok {
 				nextLink = &link
 			}
@@ -151,8 +205,10 @@ coordinatorLoop:
 	// Signal workers to stop and drain remaining results
 	close(workCh)
 
-	// Drain any remaining results with timeout
-	drainTimeout := time.After(5 * time.Second)
+	// Drain any remaining results with timeout. The walk's context may
+	// already be canceled, which would make saving the drained results fail.
+	drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), drainTimeout)
+	defer cancel()
 drainLoop:
 	for {
 		select {
@@ -160,32 +216,52 @@ drainLoop:
 			if !ok {
 				break drainLoop
 			}
-			handleResult(&crawlRes, frontier, parsedSourceURL, pathPrefix, urlFilter)
-		case <-drainTimeout:
+			_ = handleResult(drainCtx, &crawlRes, frontier, parsedSourceURL, pathPrefix, urlFilter)
+		case <-drainCtx.Done():
 			break drainLoop
 		}
 	}
 
-	return nil
+	// Links left in the frontier were discovered but never crawled
+	unvisited := frontier.Drain()
+	if nextLink != nil {
+		unvisited = append([]locdoc.DiscoveredLink{*nextLink}, unvisited...)
+	}
+	droppedMu.Lock()
+	unvisited = append(unvisited, dropped...)
+	droppedMu.Unlock()
+	return unvisited, nil
 }
 
 // recursiveCrawl performs recursive link-following when sitemap discovery fails.
 // It starts from the project's source URL and follows links within the path prefix scope.
 // URLs are processed concurrently using walkFrontier.
-func (c *Crawler) recursiveCrawl(ctx context.Context, project *locdoc.Project, urlFilter *locdoc.URLFilter, fetcher locdoc.Fetcher, progress ProgressFunc) (*Result, error) {
+func (c *Crawler) recursiveCrawl(ctx context.Context, project *locdoc.Project, urlFilter *locdoc.URLFilter, fetcher locdoc.Fetcher, writes *crawlWrites, progress ProgressFunc) (*Result, error) {
 	var result Result
 	var position int
 	completedCount := 0
 
-	// Result handler that saves documents and reports progress
-	handleResult := func(crawlRes *crawlResult, frontier *Frontier, sourceURL *url.URL, pathPrefix string, filter *locdoc.URLFilter) {
-		c.processRecursiveResult(ctx, crawlRes, &result, &position, &completedCount, project, progress, frontier, sourceURL, pathPrefix, filter)
+	// Result handler that saves documents and reports progress. The walk
+	// stops dispatching once MaxBytes is reached.
+	handleResult := func(ctx context.Context, crawlRes *crawlResult, frontier *Frontier, sourceURL *url.URL, pathPrefix string, filter *locdoc.URLFilter) bool {
+		c.processRecursiveResult(ctx, crawlRes, &result, &position, &completedCount, project, writes, progress, frontier, sourceURL, pathPrefix, filter)
+		return c.byteLimitReached(result.Bytes)
 	}
 
-	err := walkFrontier(ctx, project.SourceURL, urlFilter, fetcher, c.Concurrency, c.processRecursiveURL, handleResult)
+	unvisited, err := walkFrontier(ctx, project.SourceURL, urlFilter, fetcher, c.Concurrency, c.FrontierSize, c.MaxCrawlURLs, c.DrainTimeout, &c.activeWorkers, c.processRecursiveURL, handleResult)
 	if err != nil {
 		return nil, err
 	}
+	for _, link := range unvisited {
+		result.Unvisited = append(result.Unvisited, link.URL)
+	}
+
+	if c.byteLimitReached(result.Bytes) && progress != nil {
+		progress(ProgressEvent{
+			Type:   ProgressLimitReached,
+			Reason: "byte limit",
+		})
+	}
 
 	if progress != nil {
 		progress(ProgressEvent{
@@ -199,7 +275,8 @@ func (c *Crawler) recursiveCrawl(ctx context.Context, project *locdoc.Project, u
 // processRecursiveURL fetches and processes a single URL for recursive crawling.
 func (c *Crawler) processRecursiveURL(ctx context.Context, link locdoc.DiscoveredLink, fetcher locdoc.Fetcher) crawlResult {
 	result := crawlResult{
-		url: link.URL,
+		url:   link.URL,
+		depth: link.Depth,
 	}
 
 	// Parse URL for rate limiting
@@ -220,41 +297,45 @@ func (c *Crawler) processRecursiveURL(ctx context.Context, link locdoc.Discovere
 	if delays == nil {
 		delays = DefaultRetryDelays()
 	}
-	fetchFn := func(ctx context.Context, url string) (string, error) {
-		return fetcher.Fetch(ctx, url)
-	}
-	html, err := FetchWithRetryDelays(ctx, link.URL, fetchFn, nil, delays)
+	delays = JitteredRetryDelays(delays, c.RetryJitter)
+	html, err := c.fetchWithRetry(ctx, link.URL, fetcher, delays, &result)
 	if err != nil {
 		result.err = err
 		return result
 	}
+	result.transfer = len(html)
 
 	// Extract links (coordinator will filter for scope)
 	selector := c.LinkSelectors.GetForHTML(html)
-	links, err := selector.ExtractLinks(html, link.URL)
+	links, err := selector.ExtractLinks(html, link.URL, c.selectorOptions())
 	if err == nil {
 		result.discovered = links
 	}
-
-	// Extract content
-	extracted, err := c.Extractor.Extract(html)
-	if err != nil {
-		result.err = err
-		return result
+	for _, l := range links {
+		if l.CanonicalURL != "" && l.CanonicalURL != link.URL {
+			result.canonical = l.CanonicalURL
+		}
 	}
 
-	// Convert to markdown
-	markdown, err := c.Converter.Convert(extracted.ContentHTML)
+	c.convertPage(html, &result)
+	return result
+}
+
+// inScope reports whether rawURL belongs to the crawl: on the source host
+// (unless AllowedDomains is set), under pathPrefix, on an allowed domain and
+// matching urlFilter.
+func (c *Crawler) inScope(rawURL string, sourceURL *url.URL, pathPrefix string, urlFilter *locdoc.URLFilter) bool {
+	u, err := url.Parse(rawURL)
 	if err != nil {
-		result.err = err
-		return result
+		return false
 	}
-
-	result.title = extracted.Title
-	result.markdown = markdown
-	result.hash = computeHash(markdown)
-
-	return result
+	if len(c.AllowedDomains) == 0 && u.Host != sourceURL.Host {
+		return false
+	}
+	if !strings.HasPrefix(u.Path, pathPrefix) {
+		return false
+	}
+	return c.domainAllowed(u) && urlFilter.Match(rawURL)
 }
 
 // processRecursiveResult handles a completed crawl result from a worker.
@@ -265,32 +346,60 @@ func (c *Crawler) processRecursiveResult(
 	position *int,
 	completedCount *int,
 	project *locdoc.Project,
+	writes *crawlWrites,
 	progress ProgressFunc,
 	frontier *Frontier,
 	sourceURL *url.URL,
 	pathPrefix string,
 	urlFilter *locdoc.URLFilter,
 ) {
-	// Add discovered links to frontier (after scope filtering)
+	// Add discovered links to frontier (after scope and depth filtering)
+	if !c.followLinks(crawlRes.depth) {
+		crawlRes.discovered = nil
+	}
 	for _, discovered := range crawlRes.discovered {
-		discoveredURL, err := url.Parse(discovered.URL)
-		if err != nil {
-			continue
-		}
-		if discoveredURL.Host != sourceURL.Host {
-			continue
-		}
-		if !strings.HasPrefix(discoveredURL.Path, pathPrefix) {
-			continue
-		}
-		if urlFilter != nil && !matchesFilter(discovered.URL, urlFilter) {
+		if !c.inScope(discovered.URL, sourceURL, pathPrefix, urlFilter) {
 			continue
 		}
+		discovered.Depth = crawlRes.depth + 1
 		frontier.Push(discovered)
 	}
 
+	// A page with a canonical URL is stored under that URL. If the canonical
+	// URL has already been seen, it is (or will be) crawled in its own right,
+	// so this copy is skipped; otherwise it is marked seen to avoid fetching
+	// the same page twice. A canonical URL outside the crawl's scope is
+	// ignored and the page is stored under the URL it was fetched from.
+	docURL := crawlRes.url
+	if crawlRes.canonical != "" && crawlRes.err == nil && crawlRes.skipReason == "" &&
+		c.inScope(crawlRes.canonical, sourceURL, pathPrefix, urlFilter) {
+		if frontier.Seen(crawlRes.canonical) {
+			crawlRes.skipReason = "duplicate of " + crawlRes.canonical
+		} else {
+			frontier.MarkSeen(crawlRes.canonical)
+			docURL = crawlRes.canonical
+		}
+	}
+
+	// Pages still in flight when MaxBytes was reached are not saved
+	if crawlRes.err == nil && crawlRes.skipReason == "" && c.byteLimitReached(result.Bytes) {
+		crawlRes.skipReason = "byte limit reached"
+	}
+
+	result.TransferBytes += crawlRes.transfer
+
+	if crawlRes.altReason != "" && progress != nil {
+		progress(ProgressEvent{
+			Type:   ProgressRetryAlternate,
+			URL:    crawlRes.url,
+			Reason: crawlRes.altReason,
+		})
+	}
+
 	if crawlRes.err != nil {
 		result.Failed++
+		result.countFailedStep(crawlRes)
+		result.Errors = append(result.Errors, CrawlError{URL: crawlRes.url, Err: crawlRes.err, Attempt: crawlRes.attempts})
 		*completedCount++
 		if progress != nil {
 			progress(ProgressEvent{
@@ -303,19 +412,52 @@ func (c *Crawler) processRecursiveResult(
 		return
 	}
 
+	if crawlRes.skipReason != "" {
+		result.skip(crawlRes.url, crawlRes.skipReason)
+		*completedCount++
+		if progress != nil {
+			progress(ProgressEvent{
+				Type:      ProgressSkipped,
+				Completed: *completedCount,
+				URL:       crawlRes.url,
+				Reason:    crawlRes.skipReason,
+			})
+		}
+		return
+	}
+
 	// Save document
 	doc := &locdoc.Document{
-		ProjectID:   project.ID,
-		SourceURL:   crawlRes.url,
-		Title:       crawlRes.title,
-		Content:     crawlRes.markdown,
-		ContentHash: crawlRes.hash,
-		Position:    *position,
+		ProjectID:        project.ID,
+		SourceURL:        docURL,
+		Title:            crawlRes.title,
+		Content:          crawlRes.markdown,
+		ContentHash:      crawlRes.hash,
+		Position:         *position,
+		ReadabilityScore: crawlRes.readability,
+		Language:         crawlRes.language,
+		Summary:          crawlRes.summary,
+		ExtractedHTML:    crawlRes.extracted,
 	}
 	*position++
+	if doc = c.transformDocument(doc); doc == nil {
+		result.skip(crawlRes.url, "dropped by transformer")
+		*completedCount++
+		if progress != nil {
+			progress(ProgressEvent{
+				Type:      ProgressSkipped,
+				Completed: *completedCount,
+				URL:       crawlRes.url,
+				Reason:    "dropped by transformer",
+			})
+		}
+		return
+	}
 
-	if err := c.Documents.CreateDocument(ctx, doc); err != nil {
+	saved, err := c.saveDocument(ctx, writes, doc)
+	if err != nil {
 		result.Failed++
+		result.Errors = append(result.Errors, CrawlError{URL: crawlRes.url, Err: err, Attempt: crawlRes.attempts})
 		*completedCount++
 		if progress != nil {
 			progress(ProgressEvent{
@@ -327,15 +469,34 @@ func (c *Crawler) processRecursiveResult(
 		}
 		return
 	}
-
-	result.Saved++
-	result.Bytes += len(crawlRes.markdown)
-	if c.TokenCounter != nil {
-		if tokens, err := c.TokenCounter.CountTokens(ctx, crawlRes.markdown); err == nil {
-			result.Tokens += tokens
+	if !saved {
+		result.skip(crawlRes.url, "unchanged")
+		*completedCount++
+		if progress != nil {
+			progress(ProgressEvent{
+				Type:      ProgressSkipped,
+				Completed: *completedCount,
+				URL:       crawlRes.url,
+				Reason:    "unchanged",
+			})
 		}
+		return
 	}
 
+	tokens := c.countTokens(ctx, doc.Content)
+	if c.DryRun {
+		result.WouldSave = append(result.WouldSave, PreviewDocument{
+			URL:    doc.SourceURL,
+			Title:  doc.Title,
+			Bytes:  len(doc.Content),
+			Tokens: tokens,
+		})
+	} else {
+		result.Saved++
+	}
+	result.Bytes += len(doc.Content)
+	result.Tokens += tokens
+
 	*completedCount++
 	if progress != nil {
 		progress(ProgressEvent{
@@ -346,13 +507,21 @@ func (c *Crawler) processRecursiveResult(
 	}
 }
 
-// matchesFilter checks if a URL matches the include patterns.
-func matchesFilter(rawURL string, filter *locdoc.URLFilter) bool {
-	if filter == nil || len(filter.Include) == 0 {
-		return true
+// domainAllowed reports whether links to u's host may be followed according
+// to AllowedDomains and BlockedDomains. Hosts are compared case-insensitively
+// and without port.
+func (c *Crawler) domainAllowed(u *url.URL) bool {
+	host := u.Hostname()
+	if len(c.AllowedDomains) > 0 && !containsDomain(c.AllowedDomains, host) {
+		return false
 	}
-	for _, re := range filter.Include {
-		if re.MatchString(rawURL) {
+	return !containsDomain(c.BlockedDomains, host)
+}
+
+// containsDomain checks if host is in domains.
+func containsDomain(domains []string, host string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(d, host) {
 			return true
 		}
 	}
@@ -3,12 +3,15 @@ package crawl_test
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/crawl"
 	"github.com/fwojciec/locdoc/mock"
+	"github.com/fwojciec/locdoc/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,38 +22,32 @@ func TestRecursiveCrawl_Concurrency(t *testing.T) {
 	t.Run("processes URLs in parallel with multiple workers", func(t *testing.T) {
 		t.Parallel()
 
-		// Track concurrent fetch count using atomics to avoid data races
-		var maxConcurrent atomic.Int32
-		var currentConcurrent atomic.Int32
-
-		// Create enough URLs to see parallelism
 		const numPages = 10
 		const concurrency = 3
 
-		fetchFn := func(_ context.Context, _ string) (string, error) {
-			// Track concurrent fetches using atomic compare-and-swap for max
-			current := currentConcurrent.Add(1)
-			for {
-				max := maxConcurrent.Load()
-				if current <= max || maxConcurrent.CompareAndSwap(max, current) {
-					break
+		// Fetches of discovered pages block until released so the workers
+		// pile up and ActiveWorkers can be observed at its peak.
+		release := make(chan struct{})
+		var maxActive atomic.Int32
+
+		c, m := newTestCrawler()
+		c.Concurrency = concurrency
+		fetchFn := func(ctx context.Context, url string) (string, error) {
+			if url != "https://example.com/docs/" {
+				recordMax(&maxActive, int32(c.ActiveWorkers()))
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return "", ctx.Err()
 				}
 			}
-
-			// Simulate work to allow concurrency to build up
-			time.Sleep(50 * time.Millisecond)
-
-			currentConcurrent.Add(-1)
 			return `<html><body><p>Content</p></body></html>`, nil
 		}
-
-		c, m := newTestCrawler()
-		c.Concurrency = concurrency
 		m.HTTPFetcher.FetchFn = fetchFn
 		m.RodFetcher.FetchFn = fetchFn
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					// Only the seed page discovers links
 					if baseURL == "https://example.com/docs/" {
 						var links []locdoc.DiscoveredLink
@@ -74,17 +71,27 @@ func TestRecursiveCrawl_Concurrency(t *testing.T) {
 			SourceURL: "https://example.com/docs/",
 		}
 
-		result, err := c.CrawlProject(context.Background(), project, nil)
+		type crawlOutcome struct {
+			result *crawl.Result
+			err    error
+		}
+		done := make(chan crawlOutcome, 1)
+		go func() {
+			result, err := c.CrawlProject(context.Background(), project, nil)
+			done <- crawlOutcome{result: result, err: err}
+		}()
 
-		require.NoError(t, err)
-		require.NotNil(t, result)
-		assert.Equal(t, numPages+1, result.Saved, "should save seed URL and all discovered pages")
+		require.Eventually(t, func() bool {
+			return c.ActiveWorkers() == concurrency
+		}, 5*time.Second, time.Millisecond, "expected %d workers to be active at once", concurrency)
+		close(release)
 
-		// The key assertion: we should see concurrent processing
-		// With concurrency=3, we should see at least 2 concurrent fetches at some point
-		assert.GreaterOrEqual(t, maxConcurrent.Load(), int32(2),
-			"expected at least 2 concurrent fetches, got %d (should see parallelism with concurrency=%d)",
-			maxConcurrent.Load(), concurrency)
+		outcome := <-done
+		require.NoError(t, outcome.err)
+		require.NotNil(t, outcome.result)
+		assert.Equal(t, numPages+1, outcome.result.Saved, "should save seed URL and all discovered pages")
+		assert.LessOrEqual(t, maxActive.Load(), int32(concurrency))
+		assert.Zero(t, c.ActiveWorkers(), "all workers should be idle after the crawl")
 	})
 
 	t.Run("respects max URL limit with concurrent workers", func(t *testing.T) {
@@ -100,7 +107,7 @@ func TestRecursiveCrawl_Concurrency(t *testing.T) {
 		}
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, _ string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					// Always return more links than the max URL limit
 					// This would cause infinite crawling without the limit
 					var links []locdoc.DiscoveredLink
@@ -135,6 +142,98 @@ func TestRecursiveCrawl_Concurrency(t *testing.T) {
 			"should not save more than maxRecursiveCrawlURLs (1000)")
 	})
 
+	t.Run("reports URLs left unvisited by MaxCrawlURLs", func(t *testing.T) {
+		t.Parallel()
+
+		c, m := newTestCrawler()
+		c.MaxCrawlURLs = 3
+		// Every page links to the same 10-page site
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					var links []locdoc.DiscoveredLink
+					for i := 1; i < 10; i++ {
+						links = append(links, locdoc.DiscoveredLink{
+							URL:      fmt.Sprintf("https://example.com/docs/page%d", i),
+							Priority: locdoc.PriorityNavigation,
+						})
+					}
+					return links, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(context.Background(), project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Saved)
+		assert.Len(t, result.Unvisited, 7)
+		assert.Len(t, m.HTTPFetcher.FetchedURLs(), 3)
+		assert.Equal(t, "crawl limit reached", result.StopReason)
+	})
+
+	t.Run("reports URLs handed to workers after cancellation as unvisited", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var links []locdoc.DiscoveredLink
+		for i := 1; i <= 5; i++ {
+			links = append(links, locdoc.DiscoveredLink{
+				URL:      fmt.Sprintf("https://example.com/docs/page%d", i),
+				Priority: locdoc.PriorityNavigation,
+			})
+		}
+
+		c, m := newTestCrawler()
+		c.Concurrency = 3
+		m.HTTPFetcher.FetchFn = func(_ context.Context, url string) (string, error) {
+			if url != "https://example.com/docs/" {
+				cancel()
+			}
+			return `<html><body><p>Content</p></body></html>`, nil
+		}
+		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
+			return &mock.LinkSelector{
+				ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+					return links, nil
+				},
+				NameFn: func() string { return "test" },
+			}
+		}
+
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(ctx, project, nil)
+
+		require.NoError(t, err)
+		// Every linked page was either fetched or reported as unvisited
+		accounted := append([]string{}, result.Unvisited...)
+		for _, url := range m.HTTPFetcher.FetchedURLs() {
+			if url != "https://example.com/docs/" {
+				accounted = append(accounted, url)
+			}
+		}
+		var want []string
+		for _, link := range links {
+			want = append(want, link.URL)
+		}
+		assert.ElementsMatch(t, want, accounted)
+	})
+
 	t.Run("rate limiter enforced per worker", func(t *testing.T) {
 		t.Parallel()
 
@@ -144,7 +243,7 @@ func TestRecursiveCrawl_Concurrency(t *testing.T) {
 		c.Concurrency = 3
 		m.LinkSelectors.GetForHTMLFn = func(_ string) locdoc.LinkSelector {
 			return &mock.LinkSelector{
-				ExtractLinksFn: func(_ string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+				ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 					if baseURL == "https://example.com/docs/" {
 						return []locdoc.DiscoveredLink{
 							{URL: "https://example.com/docs/page1", Priority: locdoc.PriorityNavigation},
@@ -179,3 +278,89 @@ func TestRecursiveCrawl_Concurrency(t *testing.T) {
 			"rate limiter should be called once per URL")
 	})
 }
+
+func TestRecursiveCrawl_DrainTimeout(t *testing.T) {
+	t.Parallel()
+
+	// slowCrawl cancels the crawl while the seed page is being fetched and
+	// returns the result. The fetch ignores cancellation and takes 200ms.
+	slowCrawl := func(t *testing.T, drainTimeout time.Duration) *crawl.Result {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		started := make(chan struct{})
+
+		c, m := newTestCrawler()
+		c.DrainTimeout = drainTimeout
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			return `<html><body><p>Content</p></body></html>`, nil
+		}
+		go func() {
+			<-started
+			cancel()
+		}()
+
+		project := &locdoc.Project{
+			ID:          "test-id",
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+
+		result, err := c.CrawlProject(ctx, project, nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("saves in-flight URL that finishes within the drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		result := slowCrawl(t, 5*time.Second)
+
+		assert.Equal(t, 1, result.Saved)
+	})
+
+	t.Run("saves in-flight URL to sqlite after cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		db := sqlite.NewDB(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, db.Open())
+		t.Cleanup(func() { _ = db.Close() })
+		documents := sqlite.NewDocumentService(db)
+		project := &locdoc.Project{
+			Name:        "test",
+			SourceURL:   "https://example.com/docs/",
+			FetcherType: locdoc.FetcherTypeHTTP,
+		}
+		require.NoError(t, sqlite.NewProjectService(db).CreateProject(context.Background(), project))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		c, m := newTestCrawler()
+		c.Documents = documents
+		m.HTTPFetcher.FetchFn = func(_ context.Context, _ string) (string, error) {
+			cancel()
+			return `<html><body><p>Content</p></body></html>`, nil
+		}
+
+		result, err := c.CrawlProject(ctx, project, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Saved)
+		docs, err := documents.FindDocuments(context.Background(), locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "https://example.com/docs/", docs[0].SourceURL)
+	})
+
+	t.Run("drops in-flight URL that outlasts the drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		result := slowCrawl(t, 10*time.Millisecond)
+
+		assert.Equal(t, 0, result.Saved)
+	})
+}
@@ -16,6 +16,37 @@ type Document struct {
 	ContentHash string    `json:"contentHash"`
 	Position    int       `json:"position"`
 	FetchedAt   time.Time `json:"fetchedAt"`
+
+	// ReadabilityScore is the Flesch-Kincaid Grade Level of Content,
+	// computed at crawl time. Higher values indicate harder text.
+	ReadabilityScore float32 `json:"readabilityScore"`
+
+	// Language is the BCP 47 tag the page declared, e.g. "en" or "fr".
+	// Empty if the page did not declare one.
+	Language string `json:"language,omitempty"`
+
+	// Summary is a short description of the page, taken from its Open
+	// Graph description. Empty if the page did not provide one.
+	Summary string `json:"summary,omitempty"`
+
+	// ExtractedHTML is the main content HTML the extractor produced, before
+	// conversion to markdown. Only stored when the crawl was asked to keep
+	// it, for debugging extraction.
+	ExtractedHTML string `json:"extractedHtml,omitempty"`
+
+	// Embedding is the vector a VectorEncoder produced for Content, used
+	// for similarity search. Nil if the page was crawled without one.
+	// FindDocuments only loads it for NearVector searches.
+	Embedding []float32 `json:"embedding,omitempty"`
+
+	// Score is the relevance of the document to DocumentFilter.Query.
+	// Higher scores are more relevant. Zero when no query was given.
+	Score float64 `json:"score,omitempty"`
+
+	// Snippet is an excerpt of the document around the terms matching
+	// DocumentFilter.Query, with each match wrapped in <b> and </b>.
+	// Empty when no query was given.
+	Snippet string `json:"snippet,omitempty"`
 }
 
 // Validate returns an error if the document contains invalid fields.
@@ -32,6 +63,11 @@ func (d *Document) Validate() error {
 // DocumentWriter writes documents to storage.
 type DocumentWriter interface {
 	CreateDocument(ctx context.Context, doc *Document) error
+
+	// UpsertDocument stores doc, replacing the document with the same
+	// project and source URL if there is one. A replaced document keeps
+	// its ID.
+	UpsertDocument(ctx context.Context, doc *Document) error
 }
 
 // DocumentService represents a service for managing documents.
@@ -51,15 +87,49 @@ type DocumentService interface {
 
 	// DeleteDocumentsByProject removes all documents for a project.
 	DeleteDocumentsByProject(ctx context.Context, projectID string) error
+
+	// UpdateDocumentPosition moves a document to a new position within its
+	// project. Other documents keep their positions.
+	// Returns ENOTFOUND if document does not exist.
+	UpdateDocumentPosition(ctx context.Context, id string, position int) error
+
+	// SwapDocumentPositions exchanges the positions of two documents.
+	// Returns ENOTFOUND if either document does not exist.
+	SwapDocumentPositions(ctx context.Context, id1, id2 string) error
+
+	// BulkCreateDocuments creates multiple documents atomically.
+	// If any document fails, none of them are stored.
+	BulkCreateDocuments(ctx context.Context, docs []*Document) error
+
+	// BulkDeleteDocuments removes the documents with the given IDs.
+	// IDs that do not exist are ignored.
+	BulkDeleteDocuments(ctx context.Context, ids []string) error
+
+	// BeginTx starts a transaction. Operations on the returned DocumentTx
+	// are not visible to others until Commit is called.
+	BeginTx(ctx context.Context) (DocumentTx, error)
 }
 
-// SortOrder represents the sort order for document queries.
+// DocumentTx is a DocumentService scoped to a single transaction.
+// Exactly one of Commit or Rollback must be called to release it.
+type DocumentTx interface {
+	DocumentService
+
+	// Commit makes all changes made in the transaction permanent.
+	Commit() error
+
+	// Rollback discards all changes made in the transaction.
+	Rollback() error
+}
+
+// SortOrder represents the sort order for document and project queries.
 type SortOrder string
 
 // SortOrder constants for DocumentFilter.
 const (
 	SortByFetchedAt SortOrder = "fetched_at"
 	SortByPosition  SortOrder = "position"
+	SortByRelevance SortOrder = "relevance"
 )
 
 // DocumentFilter represents a filter for FindDocuments.
@@ -68,8 +138,54 @@ type DocumentFilter struct {
 	ProjectID *string `json:"projectId"`
 	SourceURL *string `json:"sourceUrl"`
 
+	// Query restricts results to documents whose title or content contain
+	// all of its terms. Use SortByRelevance to rank the matches.
+	Query string `json:"query"`
+
+	// ContentContains restricts results to documents whose content contains
+	// this substring. Matching is a plain scan, case-insensitive for ASCII.
+	// Prefer Query for ranked search; when both are set the full-text index
+	// selects the candidates and ContentContains narrows them further.
+	ContentContains *string `json:"contentContains"`
+
+	// URLPrefix restricts results to documents whose source URL starts with
+	// this prefix. A prefix beginning with "/" is matched against the URL
+	// path instead, so "/api/v2/" selects https://example.com/api/v2/...
+	URLPrefix *string `json:"urlPrefix"`
+
+	// MinReadabilityScore restricts results to documents whose
+	// ReadabilityScore is at least this value.
+	MinReadabilityScore *float32 `json:"minReadabilityScore"`
+
+	// Language restricts results to documents in this language.
+	Language *string `json:"language"`
+
+	// ExcludeURLs omits documents with any of these source URLs.
+	ExcludeURLs []string `json:"excludeUrls"`
+
+	// FromPosition and ToPosition restrict results to documents whose
+	// Position is within this inclusive range, e.g. a chapter of a book-like
+	// documentation set. Either bound may be left open.
+	FromPosition *int `json:"fromPosition"`
+	ToPosition   *int `json:"toPosition"`
+
+	// NearVector restricts results to documents with an Embedding and ranks
+	// them by cosine similarity to this vector, most similar first, with
+	// Limit as the number of neighbors to return. Each document's Score
+	// holds its similarity. Cannot be combined with Query, and overrides
+	// SortBy.
+	NearVector []float32 `json:"nearVector"`
+
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
 
+	// AfterID continues a listing after the document with this ID, for
+	// cursor-based pagination: pass the ID of the last document of the
+	// previous page. Unlike Offset, its cost does not grow with the page
+	// number. Requires SortByPosition.
+	AfterID *string `json:"afterId"`
+
+	// SortBy orders the results. SortByFetchedAt lists the most recently
+	// fetched documents first.
 	SortBy SortOrder `json:"sortBy"`
 }
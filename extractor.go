@@ -8,6 +8,22 @@ type ExtractResult struct {
 	// ContentHTML is the main content as clean HTML.
 	// Boilerplate (nav, footer, sidebar, ads) has been removed.
 	ContentHTML string
+
+	// Language is the page language as a BCP 47 tag (e.g. "en", "fr"),
+	// taken from the html element's lang attribute. Empty if not declared.
+	Language string
+
+	// OGTitle, OGDescription and OGImage are the page's Open Graph
+	// og:title, og:description and og:image properties. Empty if absent.
+	OGTitle       string
+	OGDescription string
+	OGImage       string
+
+	// SchemaType is the @type of the page's schema.org JSON-LD markup,
+	// e.g. "TechArticle", and SchemaVersion its version or softwareVersion
+	// property. Empty if absent.
+	SchemaType    string
+	SchemaVersion string
 }
 
 // Extractor extracts main content from HTML pages, removing boilerplate.
@@ -4,6 +4,8 @@ import "context"
 
 // Fetcher retrieves rendered HTML from URLs.
 // Implementations may use browser automation to handle JavaScript-rendered content.
+//
+// Fetch must be safe for concurrent use by multiple goroutines.
 type Fetcher interface {
 	// Fetch navigates to the URL, waits for JavaScript to render,
 	// and returns the rendered HTML.
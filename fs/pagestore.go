@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fwojciec/locdoc"
@@ -15,9 +16,14 @@ var _ locdoc.PageStore = (*FileStore)(nil)
 
 // FileStore implements locdoc.PageStore with atomic update semantics.
 // Pages are saved to a temporary directory, then moved atomically on Commit.
+// Each page is itself written to a temp file and renamed into place, so an
+// interrupted Save never leaves a partially written page behind.
 type FileStore struct {
 	baseDir string
 	name    string
+
+	mu      sync.Mutex
+	pending map[string]struct{} // temp files not yet renamed
 }
 
 // NewFileStore creates a new FileStore.
@@ -27,6 +33,7 @@ func NewFileStore(baseDir, name string) *FileStore {
 	return &FileStore{
 		baseDir: baseDir,
 		name:    name,
+		pending: make(map[string]struct{}),
 	}
 }
 
@@ -65,8 +72,45 @@ func (s *FileStore) Save(ctx context.Context, page *locdoc.Page) error {
 		return err
 	}
 
-	content := FormatPage(page)
-	return os.WriteFile(fullPath, []byte(content), 0644)
+	return s.writeFile(fullPath, []byte(FormatPage(page)))
+}
+
+// writeFile writes data to a temp file next to path and renames it over path.
+func (s *FileStore) writeFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".locdoc-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	s.track(tmp)
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	s.untrack(tmp)
+	return err
+}
+
+func (s *FileStore) track(tmp string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[tmp] = struct{}{}
+}
+
+func (s *FileStore) untrack(tmp string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, tmp)
 }
 
 // FormatPage formats a page with YAML frontmatter.
@@ -85,6 +129,14 @@ func FormatPage(page *locdoc.Page) string {
 }
 
 func (s *FileStore) Commit() error {
+	// Refuse to publish while a page is still being written
+	s.mu.Lock()
+	n := len(s.pending)
+	s.mu.Unlock()
+	if n > 0 {
+		return locdoc.Errorf(locdoc.ECONFLICT, "%d page writes still pending", n)
+	}
+
 	// Remove existing final directory if present
 	if err := os.RemoveAll(s.finalDir()); err != nil {
 		return err
@@ -99,5 +151,12 @@ func (s *FileStore) Commit() error {
 }
 
 func (s *FileStore) Abort() error {
+	s.mu.Lock()
+	for tmp := range s.pending {
+		_ = os.Remove(tmp)
+		delete(s.pending, tmp)
+	}
+	s.mu.Unlock()
+
 	return os.RemoveAll(s.tempDir())
 }
@@ -103,6 +103,59 @@ func TestFileStore_AbortCleansUpTempDirectory(t *testing.T) {
 	assert.True(t, os.IsNotExist(err), "final directory should not exist after abort")
 }
 
+func TestFileStore_AbortLeavesNoPartialFiles(t *testing.T) {
+	t.Parallel()
+
+	// Given a store with a saved page
+	base := t.TempDir()
+	store := fs.NewFileStore(base, "output")
+	err := store.Save(context.Background(), &locdoc.Page{
+		URL:     "https://example.com/docs/a",
+		Title:   "A",
+		Content: "# A",
+	})
+	require.NoError(t, err)
+
+	// When I abort
+	require.NoError(t, store.Abort())
+
+	// Then nothing is left in the base directory
+	entries, err := os.ReadDir(base)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStore_CommitLeavesOnlyFinalFiles(t *testing.T) {
+	t.Parallel()
+
+	// Given a store with a saved page
+	base := t.TempDir()
+	store := fs.NewFileStore(base, "output")
+	err := store.Save(context.Background(), &locdoc.Page{
+		URL:     "https://example.com/docs/a",
+		Title:   "A",
+		Content: "# A",
+	})
+	require.NoError(t, err)
+
+	// When I commit
+	require.NoError(t, store.Commit())
+
+	// Then the output holds exactly the one page and no temp files
+	var files []string
+	err = filepath.WalkDir(filepath.Join(base, "output"), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(base, "output", "docs", "a.md")}, files)
+}
+
 func TestFileStore_IncludesFrontmatter(t *testing.T) {
 	t.Parallel()
 
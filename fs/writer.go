@@ -66,6 +66,12 @@ func NewWriter(baseDir string) *Writer {
 	return &Writer{baseDir: baseDir}
 }
 
+// UpsertDocument writes a document to disk, overwriting the file of an
+// earlier document with the same URL.
+func (w *Writer) UpsertDocument(ctx context.Context, doc *locdoc.Document) error {
+	return w.CreateDocument(ctx, doc)
+}
+
 // CreateDocument writes a document to disk as a markdown file.
 func (w *Writer) CreateDocument(ctx context.Context, doc *locdoc.Document) error {
 	if err := doc.Validate(); err != nil {
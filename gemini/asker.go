@@ -2,8 +2,11 @@ package gemini
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	"google.golang.org/genai"
@@ -12,52 +15,141 @@ import (
 // Ensure Asker implements locdoc.Asker at compile time.
 var _ locdoc.Asker = (*Asker)(nil)
 
+// DefaultAskRetryDelays returns the default delays between attempts when
+// Gemini returns a transient error: 1s, 2s, 4s (4 attempts total).
+func DefaultAskRetryDelays() []time.Duration {
+	return []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
+}
+
 // Asker implements locdoc.Asker using Google Gemini.
 type Asker struct {
 	client *genai.Client
 	docs   locdoc.DocumentService
 	model  string
+
+	// AskRetryDelays are the delays between attempts when Gemini returns a
+	// transient error (429, 500, 503). Defaults to DefaultAskRetryDelays.
+	AskRetryDelays []time.Duration
+
+	// ResponseLanguage asks the model to answer in this language, e.g.
+	// "French", regardless of the language of the documentation. Empty
+	// leaves the choice to the model.
+	ResponseLanguage string
+
+	// ExcludeURLs lists documents to leave out of the prompt, e.g. very
+	// large pages that would crowd out the rest of the documentation.
+	ExcludeURLs []string
+
+	// Encoder, when set, limits the prompt to the NearestDocuments
+	// documents whose embeddings are most similar to the question, instead
+	// of sending the whole project. Documents without an embedding are left
+	// out.
+	Encoder locdoc.VectorEncoder
+
+	// NearestDocuments is the number of documents selected when Encoder is
+	// set. Defaults to locdoc.DefaultNearestDocuments.
+	NearestDocuments int
 }
 
 // NewAsker creates a new Asker.
 func NewAsker(client *genai.Client, docs locdoc.DocumentService, model string) *Asker {
-	return &Asker{client: client, docs: docs, model: model}
+	return &Asker{
+		client:         client,
+		docs:           docs,
+		model:          model,
+		AskRetryDelays: DefaultAskRetryDelays(),
+	}
 }
 
 // Ask answers a natural language question about a project's documentation.
-func (a *Asker) Ask(ctx context.Context, projectID, question string) (string, error) {
+func (a *Asker) Ask(ctx context.Context, projectID, question string) (*locdoc.AskResult, error) {
 	if projectID == "" {
-		return "", locdoc.Errorf(locdoc.EINVALID, "project ID required")
+		return nil, locdoc.Errorf(locdoc.EINVALID, "project ID required")
 	}
 	if question == "" {
-		return "", locdoc.Errorf(locdoc.EINVALID, "question required")
+		return nil, locdoc.Errorf(locdoc.EINVALID, "question required")
 	}
 
-	docs, err := a.docs.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &projectID})
+	filter, err := locdoc.NearTextFilter(ctx, a.Encoder, question, a.NearestDocuments, locdoc.DocumentFilter{
+		ProjectID:   &projectID,
+		ExcludeURLs: a.ExcludeURLs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	docs, err := a.docs.FindDocuments(ctx, filter)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if len(docs) == 0 {
-		return "", locdoc.Errorf(locdoc.ENOTFOUND, "no documents found for project %q", projectID)
+		if filter.NearVector != nil {
+			return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents with embeddings found for project %q", projectID)
+		}
+		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents found for project %q", projectID)
 	}
 
-	prompt := BuildUserPrompt(docs, question)
+	prompt := BuildUserPrompt(docs, question, a.ResponseLanguage)
 	config := BuildConfig()
 
-	result, err := a.client.Models.GenerateContent(ctx, a.model,
-		[]*genai.Content{{
-			Parts: []*genai.Part{{Text: prompt}},
-		}},
-		config,
-	)
+	contents := []*genai.Content{{
+		Parts: []*genai.Part{{Text: prompt}},
+	}}
+
+	result, err := a.generateWithRetry(ctx, contents, config)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if result == nil {
-		return "", locdoc.Errorf(locdoc.EINTERNAL, "gemini returned nil result")
+		return nil, locdoc.Errorf(locdoc.EINTERNAL, "gemini returned nil result")
 	}
 
-	return result.Text(), nil
+	answer := result.Text()
+	sources := locdoc.SourceRefs(answer, docs)
+	return &locdoc.AskResult{
+		Answer:         answer,
+		CitedDocuments: locdoc.CitedURLs(sources),
+		Sources:        sources,
+	}, nil
+}
+
+// generateWithRetry calls GenerateContent, retrying transient errors with
+// the delays in AskRetryDelays. Other errors are returned immediately.
+func (a *Asker) generateWithRetry(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	maxAttempts := len(a.AskRetryDelays) + 1 // 1 initial + N retries
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		result, err := a.client.Models.GenerateContent(ctx, a.model, contents, config)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if !isRetryable(err) || attempt >= maxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(a.AskRetryDelays[attempt]):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// isRetryable reports whether a Gemini API error is transient.
+func isRetryable(err error) bool {
+	var apiErr genai.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
 }
 
 // BuildConfig returns the GenerateContentConfig for Gemini API calls.
@@ -88,7 +180,8 @@ EPISTEMIC MARKERS:
 
 // BuildUserPrompt builds the user prompt containing documentation and question.
 // Uses the sandwich pattern: documents -> question -> instructions.
-func BuildUserPrompt(docs []*locdoc.Document, question string) string {
+// When language is set, the instructions ask for the answer in that language.
+func BuildUserPrompt(docs []*locdoc.Document, question, language string) string {
 	var sb strings.Builder
 	sb.WriteString("<documents>\n")
 	for i, doc := range docs {
@@ -140,6 +233,10 @@ NOT COVERED:
 Sources:
 - URL#anchor (when section applies)
 - URL (for general page references)
-</instructions>`)
+`)
+	if language != "" {
+		fmt.Fprintf(&sb, "\nPlease answer in %s. Translate documentation excerpts to %s where needed.\n", language, language)
+	}
+	sb.WriteString("</instructions>")
 	return sb.String()
 }
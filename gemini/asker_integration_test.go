@@ -46,9 +46,9 @@ func TestAsker_Integration_ReturnsAnswer(t *testing.T) {
 
 	asker := gemini.NewAsker(client, docs, "gemini-3-flash-preview")
 
-	answer, err := asker.Ask(ctx, "proj-1", "What is HTMX?")
+	result, err := asker.Ask(ctx, "proj-1", "What is HTMX?")
 
 	require.NoError(t, err)
-	assert.NotEmpty(t, answer)
-	assert.Contains(t, answer, "HTMX")
+	assert.NotEmpty(t, result.Answer)
+	assert.Contains(t, result.Answer, "HTMX")
 }
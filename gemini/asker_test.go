@@ -2,14 +2,19 @@ package gemini_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/gemini"
 	"github.com/fwojciec/locdoc/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genai"
 )
 
 func TestAsker_Ask_ReturnsErrorWhenNoDocuments(t *testing.T) {
@@ -30,6 +35,29 @@ func TestAsker_Ask_ReturnsErrorWhenNoDocuments(t *testing.T) {
 	assert.Contains(t, locdoc.ErrorMessage(err), "no documents")
 }
 
+func TestAsker_Ask_ReportsMissingEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	docs := &mock.DocumentService{
+		FindDocumentsFn: func(context.Context, locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{}, nil
+		},
+	}
+
+	asker := gemini.NewAsker(nil, docs, "gemini-3-flash-preview")
+	asker.Encoder = &mock.VectorEncoder{
+		EncodeTextFn: func(context.Context, string) ([]float32, error) {
+			return []float32{1, 0}, nil
+		},
+	}
+
+	_, err := asker.Ask(context.Background(), "proj-1", "what is this?")
+
+	require.Error(t, err)
+	assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	assert.Contains(t, locdoc.ErrorMessage(err), "no documents with embeddings")
+}
+
 func TestAsker_Ask_PropagatesDocumentServiceError(t *testing.T) {
 	t.Parallel()
 
@@ -136,7 +164,7 @@ func TestBuildUserPrompt_XMLDocumentStructure(t *testing.T) {
 		{Title: "Getting Started", SourceURL: "https://htmx.org/docs/", Content: "HTMX is a library."},
 	}
 
-	prompt := gemini.BuildUserPrompt(docs, "What is HTMX?")
+	prompt := gemini.BuildUserPrompt(docs, "What is HTMX?", "")
 
 	assert.Contains(t, prompt, "<documents>")
 	assert.Contains(t, prompt, "</documents>")
@@ -155,7 +183,7 @@ func TestBuildUserPrompt_IncludesDocTags(t *testing.T) {
 		{Title: "Getting Started", SourceURL: "https://htmx.org/docs/", Content: "HTMX is a library."},
 	}
 
-	prompt := gemini.BuildUserPrompt(docs, "What is HTMX?")
+	prompt := gemini.BuildUserPrompt(docs, "What is HTMX?", "")
 
 	// Research shows [DOC: title] tags create explicit anchors for citations
 	assert.Contains(t, prompt, "[DOC: Getting Started]")
@@ -168,7 +196,7 @@ func TestBuildUserPrompt_TitleFallsBackToSourceURL(t *testing.T) {
 		{Title: "", SourceURL: "https://htmx.org/docs/", Content: "Content here."},
 	}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	assert.Contains(t, prompt, "<title>https://htmx.org/docs/</title>")
 }
@@ -181,7 +209,7 @@ func TestBuildUserPrompt_MultipleDocuments(t *testing.T) {
 		{Title: "Doc Two", SourceURL: "https://example.com/2", Content: "Second content."},
 	}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	assert.Contains(t, prompt, "<index>1</index>")
 	assert.Contains(t, prompt, "<index>2</index>")
@@ -194,7 +222,7 @@ func TestBuildUserPrompt_QuestionInXMLTags(t *testing.T) {
 
 	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
 
-	prompt := gemini.BuildUserPrompt(docs, "How do I use this?")
+	prompt := gemini.BuildUserPrompt(docs, "How do I use this?", "")
 
 	assert.Contains(t, prompt, "<question>How do I use this?</question>")
 }
@@ -204,7 +232,7 @@ func TestBuildUserPrompt_TrailingInstructions(t *testing.T) {
 
 	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	assert.Contains(t, prompt, "<instructions>")
 	assert.Contains(t, prompt, "</instructions>")
@@ -215,7 +243,7 @@ func TestBuildUserPrompt_InstructionsRequireEvidenceFirstFormat(t *testing.T) {
 
 	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	// Evidence-first response structure
 	assert.Contains(t, prompt, "RELEVANT DOCUMENTATION")
@@ -228,19 +256,43 @@ func TestBuildUserPrompt_InstructionsRequireURLCitations(t *testing.T) {
 
 	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	// Citations should use URLs with anchors
 	assert.Contains(t, prompt, "Sources:")
 	assert.Contains(t, prompt, "URL#anchor")
 }
 
+func TestBuildUserPrompt_ResponseLanguage(t *testing.T) {
+	t.Parallel()
+
+	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
+
+	prompt := gemini.BuildUserPrompt(docs, "question", "French")
+
+	start := strings.Index(prompt, "<instructions>")
+	end := strings.Index(prompt, "</instructions>")
+	require.True(t, start >= 0 && end > start, "prompt should contain an instructions block")
+	instructions := prompt[start:end]
+	assert.Contains(t, instructions, "Please answer in French. Translate documentation excerpts to French where needed.")
+}
+
+func TestBuildUserPrompt_NoResponseLanguage(t *testing.T) {
+	t.Parallel()
+
+	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
+
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
+
+	assert.NotContains(t, prompt, "Please answer in")
+}
+
 func TestBuildUserPrompt_SandwichOrder(t *testing.T) {
 	t.Parallel()
 
 	docs := []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "Content"}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	// Verify sandwich pattern: documents -> question -> instructions
 	docsEnd := strings.Index(prompt, "</documents>")
@@ -256,7 +308,7 @@ func TestBuildUserPrompt_DoesNotContainSystemInstruction(t *testing.T) {
 
 	docs := []*locdoc.Document{{Title: "Doc", Content: "Content"}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	assert.NotContains(t, prompt, "You are a helpful assistant")
 }
@@ -270,7 +322,7 @@ func TestBuildUserPrompt_IncludesSectionsFromContent(t *testing.T) {
 		Content:   "# Introduction\n\nSome intro.\n\n## Getting Started\n\nFirst steps.",
 	}}
 
-	prompt := gemini.BuildUserPrompt(docs, "How do I get started?")
+	prompt := gemini.BuildUserPrompt(docs, "How do I get started?", "")
 
 	assert.Contains(t, prompt, "<sections>")
 	assert.Contains(t, prompt, "</sections>")
@@ -287,7 +339,7 @@ func TestBuildUserPrompt_SectionsIncludeAnchors(t *testing.T) {
 		Content:   "# Getting Started\n\nContent here.",
 	}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	assert.Contains(t, prompt, "getting-started")
 }
@@ -301,7 +353,131 @@ func TestBuildUserPrompt_NoSectionsTagWhenNoHeadings(t *testing.T) {
 		Content:   "Just plain text without headings.",
 	}}
 
-	prompt := gemini.BuildUserPrompt(docs, "question")
+	prompt := gemini.BuildUserPrompt(docs, "question", "")
 
 	assert.NotContains(t, prompt, "<sections>")
 }
+
+// newTestClient returns a genai client that sends requests to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *genai.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      "test-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPOptions: genai.HTTPOptions{BaseURL: srv.URL},
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestAsker_Ask_RetriesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"overloaded","status":"UNAVAILABLE"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"text":"the answer"}]}}]}`))
+	})
+
+	docs := &mock.DocumentService{
+		FindDocumentsFn: func(context.Context, locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "content"}}, nil
+		},
+	}
+
+	asker := gemini.NewAsker(client, docs, "gemini-test")
+	asker.AskRetryDelays = []time.Duration{0, 0, 0}
+
+	result, err := asker.Ask(context.Background(), "proj-1", "what is this?")
+
+	require.NoError(t, err)
+	assert.Equal(t, "the answer", result.Answer)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestAsker_Ask_TracksCitedDocuments(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"text":"The documentation states it swaps.\n\nSources:\n- https://example.com/swap"}]}}]}`))
+	})
+
+	docs := &mock.DocumentService{
+		FindDocumentsFn: func(context.Context, locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{
+				{Title: "Swap", SourceURL: "https://example.com/swap", Content: "content"},
+				{Title: "Other", SourceURL: "https://example.com/other", Content: "content"},
+			}, nil
+		},
+	}
+
+	asker := gemini.NewAsker(client, docs, "gemini-test")
+
+	result, err := asker.Ask(context.Background(), "proj-1", "what is this?")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/swap"}, result.CitedDocuments)
+}
+
+func TestAsker_Ask_TracksSources(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"text":"Use [hx-swap](https://example.com/swap#modifiers) to swap.[^1]\n\n[^1]: https://example.com/other"}]}}]}`))
+	})
+
+	docs := &mock.DocumentService{
+		FindDocumentsFn: func(context.Context, locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{
+				{Title: "Swap", SourceURL: "https://example.com/swap", Content: "# Swap\n\n## Modifiers\n\ncontent"},
+				{Title: "Other", SourceURL: "https://example.com/other", Content: "content"},
+			}, nil
+		},
+	}
+
+	asker := gemini.NewAsker(client, docs, "gemini-test")
+
+	result, err := asker.Ask(context.Background(), "proj-1", "what is this?")
+
+	require.NoError(t, err)
+	assert.Equal(t, []locdoc.SourceRef{
+		{Title: "Swap", URL: "https://example.com/swap", Anchor: "modifiers"},
+		{Title: "Other", URL: "https://example.com/other"},
+	}, result.Sources)
+}
+
+func TestAsker_Ask_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"invalid request","status":"INVALID_ARGUMENT"}}`))
+	})
+
+	docs := &mock.DocumentService{
+		FindDocumentsFn: func(context.Context, locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{{Title: "Doc", SourceURL: "https://example.com", Content: "content"}}, nil
+		},
+	}
+
+	asker := gemini.NewAsker(client, docs, "gemini-test")
+	asker.AskRetryDelays = []time.Duration{0, 0, 0}
+
+	_, err := asker.Ask(context.Background(), "proj-1", "what is this?")
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
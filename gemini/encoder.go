@@ -0,0 +1,88 @@
+package gemini
+
+import (
+	"context"
+	"time"
+
+	"github.com/fwojciec/locdoc"
+	"golang.org/x/time/rate"
+	"google.golang.org/genai"
+)
+
+// Ensure EmbeddingEncoder implements locdoc.VectorEncoder at compile time.
+var _ locdoc.VectorEncoder = (*EmbeddingEncoder)(nil)
+
+// DefaultEmbeddingModel is the embedding model used when none is given. It
+// returns 768-dimensional vectors.
+const DefaultEmbeddingModel = "models/text-embedding-004"
+
+// DefaultEmbeddingRate is the default number of embedding requests per
+// second. The Embeddings API has a lower quota than the generative API, so
+// crawls that embed every page are throttled to stay under it.
+const DefaultEmbeddingRate = 5
+
+// EmbeddingEncoder implements locdoc.VectorEncoder using the Gemini
+// Embeddings API.
+type EmbeddingEncoder struct {
+	client *genai.Client
+	model  string
+
+	// Limiter throttles embedding requests. Defaults to DefaultEmbeddingRate
+	// requests per second; nil disables throttling.
+	Limiter *rate.Limiter
+
+	// RetryDelays are the delays between attempts when the API returns a
+	// transient error, such as an exhausted quota. Defaults to
+	// DefaultAskRetryDelays.
+	RetryDelays []time.Duration
+}
+
+// NewEmbeddingEncoder creates a new EmbeddingEncoder. An empty model uses
+// DefaultEmbeddingModel.
+func NewEmbeddingEncoder(client *genai.Client, model string) *EmbeddingEncoder {
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+	return &EmbeddingEncoder{
+		client:      client,
+		model:       model,
+		Limiter:     rate.NewLimiter(DefaultEmbeddingRate, 1),
+		RetryDelays: DefaultAskRetryDelays(),
+	}
+}
+
+// EncodeText returns the embedding of text.
+func (e *EmbeddingEncoder) EncodeText(ctx context.Context, text string) ([]float32, error) {
+	contents := []*genai.Content{genai.NewContentFromText(text, genai.RoleUser)}
+	maxAttempts := len(e.RetryDelays) + 1 // 1 initial + N retries
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if e.Limiter != nil {
+			if err := e.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		result, err := e.client.Models.EmbedContent(ctx, e.model, contents, nil)
+		if err == nil {
+			if len(result.Embeddings) == 0 || len(result.Embeddings[0].Values) == 0 {
+				return nil, locdoc.Errorf(locdoc.EINTERNAL, "gemini returned no embedding for model %q", e.model)
+			}
+			return result.Embeddings[0].Values, nil
+		}
+		lastErr = err
+
+		if !isRetryable(err) || attempt >= maxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(e.RetryDelays[attempt]):
+		}
+	}
+
+	return nil, lastErr
+}
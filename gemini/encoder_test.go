@@ -0,0 +1,107 @@
+package gemini_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/locdoc/gemini"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// embedResponse returns an embedding response with a vector of dim values.
+func embedResponse(dim int) string {
+	values := make([]string, dim)
+	for i := range values {
+		values[i] = fmt.Sprintf("%g", float32(i)/float32(dim))
+	}
+	return `{"embeddings":[{"values":[` + strings.Join(values, ",") + `]}]}`
+}
+
+func TestEmbeddingEncoder_EncodeText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns 768-dimensional vector for text-embedding-004", func(t *testing.T) {
+		t.Parallel()
+
+		var path string
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(embedResponse(768)))
+		})
+
+		encoder := gemini.NewEmbeddingEncoder(client, "")
+
+		vector, err := encoder.EncodeText(context.Background(), "hello")
+
+		require.NoError(t, err)
+		assert.Len(t, vector, 768)
+		assert.InDelta(t, 0.5, vector[384], 1e-6)
+		assert.Contains(t, path, "text-embedding-004")
+	})
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":{"code":429,"message":"quota exceeded","status":"RESOURCE_EXHAUSTED"}}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(embedResponse(4)))
+		})
+
+		encoder := gemini.NewEmbeddingEncoder(client, "")
+		encoder.RetryDelays = []time.Duration{0}
+
+		vector, err := encoder.EncodeText(context.Background(), "hello")
+
+		require.NoError(t, err)
+		assert.Len(t, vector, 4)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("waits for the rate limiter", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(embedResponse(4)))
+		})
+
+		encoder := gemini.NewEmbeddingEncoder(client, "")
+		encoder.Limiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+
+		_, err := encoder.EncodeText(context.Background(), "first")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = encoder.EncodeText(ctx, "second")
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns error when no embedding is returned", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"embeddings":[]}`))
+		})
+
+		_, err := gemini.NewEmbeddingEncoder(client, "").EncodeText(context.Background(), "hello")
+
+		require.Error(t, err)
+	})
+}
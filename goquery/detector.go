@@ -32,7 +32,7 @@ func (d *Detector) Detect(html string) locdoc.Framework {
 
 	// Check meta generator tags first - most reliable when present
 	if framework := d.detectFromMetaGenerator(doc); framework != locdoc.FrameworkUnknown {
-		return framework
+		return d.refineAntora(doc, framework)
 	}
 
 	// Check for Docusaurus markers
@@ -89,6 +89,12 @@ func (d *Detector) Detect(html string) locdoc.Framework {
 		return locdoc.FrameworkNextra
 	}
 
+	// Check for Antora markers
+	// .pf-c-page is the PatternFly page layout used by Antora Enterprise UIs
+	if d.hasSelector(doc, ".pf-c-page") {
+		return d.refineAntora(doc, locdoc.FrameworkAntora)
+	}
+
 	// Check for zeroheight markers
 	// zeroheight uses /images/zhapp/ paths and specific styleguide structure
 	if strings.Contains(html, "/images/zhapp/") ||
@@ -123,11 +129,26 @@ func (d *Detector) detectFromMetaGenerator(doc *goquery.Document) locdoc.Framewo
 		return locdoc.FrameworkVuePress
 	case strings.Contains(generator, "nextra"):
 		return locdoc.FrameworkNextra
+	case strings.Contains(generator, "antora"):
+		return locdoc.FrameworkAntora
 	}
 
 	return locdoc.FrameworkUnknown
 }
 
+// refineAntora distinguishes static Antora builds from sites whose UI bundle
+// renders content client-side, identified by a *-ui-bundle.js script.
+// Other frameworks are returned unchanged.
+func (d *Detector) refineAntora(doc *goquery.Document, framework locdoc.Framework) locdoc.Framework {
+	if framework != locdoc.FrameworkAntora {
+		return framework
+	}
+	if d.hasSelector(doc, "script[src*='-ui-bundle.js']") {
+		return locdoc.FrameworkAntoraDynamic
+	}
+	return framework
+}
+
 // hasSelector checks if the document contains at least one element matching the selector.
 func (d *Detector) hasSelector(doc *goquery.Document, selector string) bool {
 	return doc.Find(selector).Length() > 0
@@ -154,21 +175,22 @@ func (d *Detector) hasGitBookClasses(doc *goquery.Document) bool {
 	return count >= 2
 }
 
-// RequiresJS indicates whether a framework requires JavaScript rendering.
-// Returns (requires, known) where:
-//   - requires: true if the framework needs JS to render content
-//   - known: true if the framework is recognized
+// RequiresJSForFramework indicates whether a framework requires JavaScript rendering.
+// Returns (requiresJS, isKnown) where:
+//   - requiresJS: true if the framework needs JS to render content
+//   - isKnown: true if the framework is recognized
 //
 // Unknown frameworks return (false, false).
-func (d *Detector) RequiresJS(framework locdoc.Framework) (requires bool, known bool) {
+func (d *Detector) RequiresJSForFramework(framework locdoc.Framework) (requiresJS bool, isKnown bool) {
 	switch framework {
 	// Frameworks that require JavaScript rendering (client-side SPAs)
-	case locdoc.FrameworkGitBook, locdoc.FrameworkZeroheight:
+	case locdoc.FrameworkGitBook, locdoc.FrameworkZeroheight, locdoc.FrameworkAntoraDynamic:
 		return true, true
 
 	// Frameworks that output static HTML (SSG/SSR)
 	case locdoc.FrameworkSphinx, locdoc.FrameworkMkDocs, locdoc.FrameworkDocusaurus,
-		locdoc.FrameworkVitePress, locdoc.FrameworkNextra, locdoc.FrameworkVuePress:
+		locdoc.FrameworkVitePress, locdoc.FrameworkNextra, locdoc.FrameworkVuePress,
+		locdoc.FrameworkAntora:
 		return false, true
 
 	// Unknown framework
@@ -190,3 +212,50 @@ func (d *Detector) RenderDelay(framework locdoc.Framework) time.Duration {
 		return 0
 	}
 }
+
+// minUsableTextLength is the least amount of body text, in characters, for
+// a page to count as usable content.
+const minUsableTextLength = 200
+
+// isBlockedTitle reports whether a lowercased page title is one served in
+// place of the requested page by bot protection and access control.
+func isBlockedTitle(title string) bool {
+	switch title {
+	case "access denied",
+		"just a moment...": // Cloudflare challenge
+		return true
+	default:
+		return false
+	}
+}
+
+// IsUsableContent reports whether html looks like the requested page. It
+// returns false for bot challenges, access-denied and login pages, and pages
+// with less than minUsableTextLength characters of body text.
+func (d *Detector) IsUsableContent(html string) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+
+	title := strings.ToLower(strings.TrimSpace(doc.Find("title").First().Text()))
+	if isBlockedTitle(title) {
+		return false
+	}
+
+	isLogin := false
+	doc.Find("form[action]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		action, _ := s.Attr("action")
+		action = strings.TrimSuffix(strings.SplitN(action, "?", 2)[0], "/")
+		isLogin = strings.HasSuffix(action, "/login")
+		return !isLogin
+	})
+	if isLogin {
+		return false
+	}
+
+	body := doc.Find("body")
+	body.Find("script, style, noscript, template").Remove()
+	text := strings.Join(strings.Fields(body.Text()), " ")
+	return len([]rune(text)) >= minUsableTextLength
+}
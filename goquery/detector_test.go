@@ -1,6 +1,7 @@
 package goquery_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/fwojciec/locdoc"
@@ -341,6 +342,85 @@ func TestDetector_Detect(t *testing.T) {
 		assert.Equal(t, locdoc.FrameworkNextra, framework)
 	})
 
+	t.Run("detects static Antora from meta generator tag", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head>
+<meta name="generator" content="Antora 3.1.7">
+<title>Antora Docs</title>
+</head>
+<body>
+<nav class="nav-menu"><ul class="nav-list"><li><a href="intro.html">Intro</a></li></ul></nav>
+<script src="../../_/js/site.js"></script>
+</body>
+</html>`
+
+		d := goquery.NewDetector()
+		framework := d.Detect(html)
+
+		assert.Equal(t, locdoc.FrameworkAntora, framework)
+	})
+
+	t.Run("detects Antora from pf-c-page class", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head><title>Enterprise Docs</title></head>
+<body>
+<div class="pf-c-page">
+	<main class="pf-c-page__main"><article class="doc">Content</article></main>
+</div>
+</body>
+</html>`
+
+		d := goquery.NewDetector()
+		framework := d.Detect(html)
+
+		assert.Equal(t, locdoc.FrameworkAntora, framework)
+	})
+
+	t.Run("detects dynamic Antora from ui-bundle script", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head>
+<meta name="generator" content="Antora 3.1.7">
+<title>Antora Docs</title>
+</head>
+<body>
+<div id="app"></div>
+<script src="/_/js/docs-ui-bundle.js"></script>
+</body>
+</html>`
+
+		d := goquery.NewDetector()
+		framework := d.Detect(html)
+
+		assert.Equal(t, locdoc.FrameworkAntoraDynamic, framework)
+	})
+
+	t.Run("detects dynamic Antora from pf-c-page with ui-bundle script", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head><title>Enterprise Docs</title></head>
+<body>
+<div class="pf-c-page"></div>
+<script src="/assets/enterprise-ui-bundle.js"></script>
+</body>
+</html>`
+
+		d := goquery.NewDetector()
+		framework := d.Detect(html)
+
+		assert.Equal(t, locdoc.FrameworkAntoraDynamic, framework)
+	})
+
 	// Priority order tests
 	t.Run("meta generator takes priority over CSS class markers", func(t *testing.T) {
 		t.Parallel()
@@ -434,7 +514,7 @@ func TestDetector_Detect(t *testing.T) {
 	})
 }
 
-func TestDetector_RequiresJS(t *testing.T) {
+func TestDetector_RequiresJSForFramework(t *testing.T) {
 	t.Parallel()
 
 	d := goquery.NewDetector()
@@ -443,7 +523,7 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("GitBook requires JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkGitBook)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkGitBook)
 		assert.True(t, requires, "GitBook should require JS")
 		assert.True(t, known, "GitBook should be a known framework")
 	})
@@ -452,7 +532,7 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("Sphinx does not require JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkSphinx)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkSphinx)
 		assert.False(t, requires, "Sphinx should not require JS")
 		assert.True(t, known, "Sphinx should be a known framework")
 	})
@@ -460,7 +540,7 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("MkDocs does not require JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkMkDocs)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkMkDocs)
 		assert.False(t, requires, "MkDocs should not require JS")
 		assert.True(t, known, "MkDocs should be a known framework")
 	})
@@ -468,7 +548,7 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("Docusaurus does not require JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkDocusaurus)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkDocusaurus)
 		assert.False(t, requires, "Docusaurus should not require JS")
 		assert.True(t, known, "Docusaurus should be a known framework")
 	})
@@ -476,7 +556,7 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("VitePress does not require JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkVitePress)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkVitePress)
 		assert.False(t, requires, "VitePress should not require JS")
 		assert.True(t, known, "VitePress should be a known framework")
 	})
@@ -484,7 +564,7 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("Nextra does not require JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkNextra)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkNextra)
 		assert.False(t, requires, "Nextra should not require JS")
 		assert.True(t, known, "Nextra should be a known framework")
 	})
@@ -492,17 +572,95 @@ func TestDetector_RequiresJS(t *testing.T) {
 	t.Run("VuePress does not require JS", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkVuePress)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkVuePress)
 		assert.False(t, requires, "VuePress should not require JS")
 		assert.True(t, known, "VuePress should be a known framework")
 	})
 
+	t.Run("static Antora does not require JS", func(t *testing.T) {
+		t.Parallel()
+
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkAntora)
+		assert.False(t, requires, "static Antora should not require JS")
+		assert.True(t, known, "Antora should be a known framework")
+	})
+
+	t.Run("dynamic Antora requires JS", func(t *testing.T) {
+		t.Parallel()
+
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkAntoraDynamic)
+		assert.True(t, requires, "dynamic Antora should require JS")
+		assert.True(t, known, "dynamic Antora should be a known framework")
+	})
+
 	// Unknown framework
 	t.Run("FrameworkUnknown returns known=false", func(t *testing.T) {
 		t.Parallel()
 
-		requires, known := d.RequiresJS(locdoc.FrameworkUnknown)
+		requires, known := d.RequiresJSForFramework(locdoc.FrameworkUnknown)
 		assert.False(t, requires, "Unknown framework should not require JS")
 		assert.False(t, known, "Unknown framework should have known=false")
 	})
 }
+
+func TestDetector_IsUsableContent(t *testing.T) {
+	t.Parallel()
+
+	d := goquery.NewDetector()
+	article := strings.Repeat("Install the package and import it in your project. ", 10)
+
+	t.Run("accepts a documentation page", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><head><title>Getting Started</title></head><body><main><h1>Getting Started</h1><p>` + article + `</p></main></body></html>`
+
+		assert.True(t, d.IsUsableContent(html))
+	})
+
+	t.Run("rejects a Cloudflare challenge page", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html><html lang="en-US"><head><title>Just a moment...</title>
+<meta http-equiv="refresh" content="390"></head>
+<body><div class="main-wrapper" role="main"><div class="main-content">
+<h1 class="zone-name-title h1">docs.example.com</h1>
+<h2 class="h2" id="challenge-running">Checking if the site connection is secure</h2>
+<div id="challenge-body-text" class="core-msg spacer">docs.example.com needs to review the security of your connection before proceeding.</div>
+<noscript><div id="challenge-error-title">Enable JavaScript and cookies to continue</div></noscript>
+<form id="challenge-form" action="/?__cf_chl_f_tk=abc" method="POST" enctype="application/x-www-form-urlencoded"></form>
+<p>` + article + `</p>
+</div></div></body></html>`
+
+		assert.False(t, d.IsUsableContent(html))
+	})
+
+	t.Run("rejects an access denied page", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><head><title>Access Denied</title></head><body><h1>Access Denied</h1><p>` + article + `</p></body></html>`
+
+		assert.False(t, d.IsUsableContent(html))
+	})
+
+	t.Run("rejects a login redirect", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><head><title>Docs</title></head><body><form action="/login?next=/docs" method="post"><input name="user"></form><p>` + article + `</p></body></html>`
+
+		assert.False(t, d.IsUsableContent(html))
+	})
+
+	t.Run("rejects a page with little body text", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, d.IsUsableContent(`<html><body><p>Loading...</p></body></html>`))
+	})
+
+	t.Run("does not count script text as content", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><head><title>App</title></head><body><div id="root"></div><script>window.__DATA__ = "` + article + `"</script></body></html>`
+
+		assert.False(t, d.IsUsableContent(html))
+	})
+}
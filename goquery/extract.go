@@ -13,24 +13,28 @@ type SelectorConfig struct {
 	Selector string
 	Priority locdoc.LinkPriority
 	Source   string
+	Attr     string // Attribute holding the URL; defaults to "href"
 }
 
 // ExtractLinksWithConfigs extracts links from HTML using the provided selector configurations.
 // Links are deduplicated by URL, keeping the highest priority version.
 // External links (different host than baseURL) are filtered out.
+// Links inside hidden elements are skipped unless opts.IncludeHiddenContent is set.
 // The returned links maintain document order based on first occurrence.
-func ExtractLinksWithConfigs(html string, baseURL string, configs []SelectorConfig) ([]locdoc.DiscoveredLink, error) {
-	return extractLinksWithConfigs(html, baseURL, configs, false)
+// If the page declares a canonical URL on the same host that differs from
+// baseURL, a link for the page itself carrying CanonicalURL is appended.
+func ExtractLinksWithConfigs(html string, baseURL string, configs []SelectorConfig, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	return extractLinksWithConfigs(html, baseURL, configs, opts, false)
 }
 
 // ExtractLinksWithConfigsAndFallback is like ExtractLinksWithConfigs but also extracts
 // fallback links from any anchor that matches the base URL path prefix.
 // Fallback links have PriorityFallback and won't override higher-priority duplicates.
-func ExtractLinksWithConfigsAndFallback(html string, baseURL string, configs []SelectorConfig) ([]locdoc.DiscoveredLink, error) {
-	return extractLinksWithConfigs(html, baseURL, configs, true)
+func ExtractLinksWithConfigsAndFallback(html string, baseURL string, configs []SelectorConfig, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	return extractLinksWithConfigs(html, baseURL, configs, opts, true)
 }
 
-func extractLinksWithConfigs(html string, baseURL string, configs []SelectorConfig, includeFallback bool) ([]locdoc.DiscoveredLink, error) {
+func extractLinksWithConfigs(html string, baseURL string, configs []SelectorConfig, opts locdoc.SelectorOptions, includeFallback bool) ([]locdoc.DiscoveredLink, error) {
 	base, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, locdoc.Errorf(locdoc.EINVALID, "invalid base URL: %v", err)
@@ -46,12 +50,20 @@ func extractLinksWithConfigs(html string, baseURL string, configs []SelectorConf
 	var links []locdoc.DiscoveredLink
 
 	for _, config := range configs {
+		attr := config.Attr
+		if attr == "" {
+			attr = "href"
+		}
 		doc.Find(config.Selector).Each(func(_ int, sel *goquery.Selection) {
-			href, exists := sel.Attr("href")
+			href, exists := sel.Attr(attr)
 			if !exists || href == "" {
 				return
 			}
 
+			if !opts.IncludeHiddenContent && isHidden(sel) {
+				return
+			}
+
 			// Skip non-HTTP links (javascript:, mailto:, etc.)
 			if isNonHTTPLink(href) {
 				return
@@ -99,6 +111,10 @@ func extractLinksWithConfigs(html string, baseURL string, configs []SelectorConf
 				return
 			}
 
+			if !opts.IncludeHiddenContent && isHidden(sel) {
+				return
+			}
+
 			if isNonHTTPLink(href) {
 				return
 			}
@@ -139,9 +155,51 @@ func extractLinksWithConfigs(html string, baseURL string, configs []SelectorConf
 		})
 	}
 
+	if canonical := canonicalURL(doc, base); canonical != "" {
+		page := *base
+		page.Fragment = ""
+		links = append(links, locdoc.DiscoveredLink{
+			URL:          page.String(),
+			Priority:     locdoc.PriorityIgnore,
+			Source:       "canonical",
+			CanonicalURL: canonical,
+		})
+	}
+
 	return links, nil
 }
 
+// canonicalURL returns the absolute URL from the page's
+// <link rel="canonical">. Returns "" if there is none, if it points to
+// another host, or if it is the page itself.
+func canonicalURL(doc *goquery.Document, base *url.URL) string {
+	href, ok := doc.Find(`head link[rel="canonical"][href]`).First().Attr("href")
+	if !ok || strings.TrimSpace(href) == "" {
+		return ""
+	}
+	resolved := resolveURL(base, strings.TrimSpace(href))
+	if resolved == "" || !isSameHost(base, resolved) {
+		return ""
+	}
+	return resolved
+}
+
+// isHidden reports whether the element or one of its ancestors is hidden
+// with the hidden attribute or an inline display:none style.
+func isHidden(sel *goquery.Selection) bool {
+	for s := sel; s.Length() > 0; s = s.Parent() {
+		if _, ok := s.Attr("hidden"); ok {
+			return true
+		}
+		style, _ := s.Attr("style")
+		style = strings.ReplaceAll(strings.ToLower(style), " ", "")
+		if strings.Contains(style, "display:none") {
+			return true
+		}
+	}
+	return false
+}
+
 // resolveURL resolves a relative URL against a base URL.
 // Returns empty string if the href cannot be parsed or if the resolved URL
 // is self-referential (same as base URL after stripping fragment).
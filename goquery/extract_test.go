@@ -33,7 +33,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "aside a[href]", Priority: locdoc.PriorityTOC, Source: "sidebar"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3)
@@ -70,7 +70,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "footer a[href]", Priority: locdoc.PriorityFooter, Source: "footer"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -101,7 +101,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "aside a[href]", Priority: locdoc.PriorityTOC, Source: "sidebar"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -129,7 +129,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -154,7 +154,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -177,7 +177,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -193,7 +193,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		_, err := goquery.ExtractLinksWithConfigs(html, "://invalid-url", configs)
+		_, err := goquery.ExtractLinksWithConfigs(html, "://invalid-url", configs, locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
@@ -206,7 +206,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs("", "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs("", "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -217,7 +217,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 
 		html := `<html><body><nav><a href="/docs">Docs</a></nav></body></html>`
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", nil)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", nil, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -239,7 +239,7 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -263,10 +263,59 @@ func TestExtractLinksWithConfigs(t *testing.T) {
 			{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		}
 
-		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com/current/page", configs)
+		links, err := goquery.ExtractLinksWithConfigs(html, "https://example.com/current/page", configs, locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
 		assert.Equal(t, "https://example.com/docs/guide", links[0].URL)
 	})
 }
+
+func TestExtractLinksWithConfigs_CanonicalURL(t *testing.T) {
+	t.Parallel()
+
+	configs := []goquery.SelectorConfig{
+		{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
+	}
+	page := func(canonical string) string {
+		return `<!DOCTYPE html>
+<html>
+<head><link rel="canonical" href="` + canonical + `"></head>
+<body><nav><a href="/docs/guide">Guide</a></nav></body>
+</html>`
+	}
+
+	t.Run("adds a link for the page carrying its canonical URL", func(t *testing.T) {
+		t.Parallel()
+
+		links, err := goquery.ExtractLinksWithConfigs(page("/docs/intro"), "https://example.com/docs/intro?ref=nav#top", configs, locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "https://example.com/docs/guide", links[0].URL)
+		assert.Empty(t, links[0].CanonicalURL)
+		assert.Equal(t, "https://example.com/docs/intro?ref=nav", links[1].URL)
+		assert.Equal(t, "https://example.com/docs/intro", links[1].CanonicalURL)
+		assert.Equal(t, locdoc.PriorityIgnore, links[1].Priority)
+	})
+
+	t.Run("ignores a canonical URL pointing to the page itself", func(t *testing.T) {
+		t.Parallel()
+
+		links, err := goquery.ExtractLinksWithConfigs(page("https://example.com/docs/intro"), "https://example.com/docs/intro", configs, locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Empty(t, links[0].CanonicalURL)
+	})
+
+	t.Run("ignores a canonical URL on another host", func(t *testing.T) {
+		t.Parallel()
+
+		links, err := goquery.ExtractLinksWithConfigs(page("https://mirror.example.org/docs/intro"), "https://example.com/docs/intro", configs, locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Empty(t, links[0].CanonicalURL)
+	})
+}
@@ -0,0 +1,54 @@
+package goquery
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.Extractor = (*SelectorExtractor)(nil)
+
+// SelectorExtractor narrows a page to the first element matching a CSS
+// selector before handing it to another extractor. It is meant for sites
+// whose layout defeats the general-purpose extractors.
+//
+// The page head and html attributes are kept, so the inner extractor still
+// sees the title and language metadata. Pages without a matching element
+// are passed through unchanged.
+type SelectorExtractor struct {
+	selector string
+	inner    locdoc.Extractor
+}
+
+// NewSelectorExtractor creates a SelectorExtractor that restricts pages to
+// the element matching selector and extracts content with inner.
+func NewSelectorExtractor(selector string, inner locdoc.Extractor) *SelectorExtractor {
+	return &SelectorExtractor{selector: selector, inner: inner}
+}
+
+// Extract replaces the page body with the inner HTML of the first element
+// matching the selector and extracts the result with the inner extractor.
+func (e *SelectorExtractor) Extract(html string) (*locdoc.ExtractResult, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "failed to parse HTML: %v", err)
+	}
+
+	match := doc.Find(e.selector).First()
+	if match.Length() == 0 {
+		return e.inner.Extract(html)
+	}
+
+	content, err := match.Html()
+	if err != nil {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "failed to render selected content: %v", err)
+	}
+	doc.Find("body").SetHtml(content)
+
+	narrowed, err := doc.Html()
+	if err != nil {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "failed to render HTML: %v", err)
+	}
+	return e.inner.Extract(narrowed)
+}
@@ -0,0 +1,91 @@
+package goquery_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/goquery"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	// passthrough returns the HTML it receives as the extracted content
+	passthrough := func() *mock.Extractor {
+		return &mock.Extractor{
+			ExtractFn: func(html string) (*locdoc.ExtractResult, error) {
+				return &locdoc.ExtractResult{ContentHTML: html}, nil
+			},
+		}
+	}
+
+	t.Run("passes only the matching element to the inner extractor", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html lang="en">
+<head><title>Guide</title></head>
+<body>
+<header>Site header</header>
+<nav><a href="/docs">Docs</a></nav>
+<div id="content"><h1>Guide</h1><p>Install the tool.</p></div>
+<footer>Copyright notice</footer>
+</body>
+</html>`
+
+		extractor := goquery.NewSelectorExtractor("#content", passthrough())
+		result, err := extractor.Extract(html)
+
+		require.NoError(t, err)
+		assert.Contains(t, result.ContentHTML, "<h1>Guide</h1><p>Install the tool.</p>")
+		assert.NotContains(t, result.ContentHTML, `id="content"`)
+		assert.NotContains(t, result.ContentHTML, "Site header")
+		assert.NotContains(t, result.ContentHTML, "Docs</a>")
+		assert.NotContains(t, result.ContentHTML, "Copyright notice")
+		assert.Contains(t, result.ContentHTML, "<title>Guide</title>")
+		assert.Contains(t, result.ContentHTML, `<html lang="en">`)
+	})
+
+	t.Run("uses the first matching element", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><body><div class="doc">First</div><div class="doc">Second</div></body></html>`
+
+		extractor := goquery.NewSelectorExtractor(".doc", passthrough())
+		result, err := extractor.Extract(html)
+
+		require.NoError(t, err)
+		assert.Contains(t, result.ContentHTML, "First")
+		assert.NotContains(t, result.ContentHTML, "Second")
+	})
+
+	t.Run("passes the page unchanged when nothing matches", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><body><main>Content</main></body></html>`
+
+		extractor := goquery.NewSelectorExtractor("#content", passthrough())
+		result, err := extractor.Extract(html)
+
+		require.NoError(t, err)
+		assert.Equal(t, html, result.ContentHTML)
+	})
+
+	t.Run("returns inner extractor errors", func(t *testing.T) {
+		t.Parallel()
+
+		inner := &mock.Extractor{
+			ExtractFn: func(string) (*locdoc.ExtractResult, error) {
+				return nil, locdoc.Errorf(locdoc.EINVALID, "empty HTML input")
+			},
+		}
+
+		extractor := goquery.NewSelectorExtractor("#content", inner)
+		_, err := extractor.Extract(`<div id="content"></div>`)
+
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+}
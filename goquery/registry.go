@@ -1,6 +1,10 @@
 package goquery
 
-import "github.com/fwojciec/locdoc"
+import (
+	"sort"
+
+	"github.com/fwojciec/locdoc"
+)
 
 var _ locdoc.LinkSelectorRegistry = (*Registry)(nil)
 
@@ -9,51 +13,176 @@ var _ locdoc.LinkSelectorRegistry = (*Registry)(nil)
 // the documentation framework and returns the appropriate selector,
 // falling back to a generic selector when the framework is unknown
 // or no specific selector is registered.
+//
+// Selectors registered for FrameworkUnknown are kept in priority order and
+// tried before the fallback selector, so callers can inject custom selectors
+// for sites that no detector recognizes.
 type Registry struct {
 	detector  locdoc.FrameworkDetector
 	fallback  locdoc.LinkSelector
 	selectors map[locdoc.Framework]locdoc.LinkSelector
+	unknown   []prioritizedSelector // Sorted by descending priority
+	chained   bool
+}
+
+// RegistryOption configures a Registry.
+type RegistryOption func(*Registry)
+
+// WithChainedSelectors makes GetForHTML combine the framework-specific
+// selector with the fallback selector, merging links from both, instead of
+// returning the framework-specific selector alone.
+func WithChainedSelectors(enabled bool) RegistryOption {
+	return func(r *Registry) {
+		r.chained = enabled
+	}
+}
+
+// prioritizedSelector is a selector registered for FrameworkUnknown.
+type prioritizedSelector struct {
+	selector locdoc.LinkSelector
+	priority int
 }
 
 // NewRegistry creates a new Registry with the given detector and fallback selector.
 // The fallback selector is used when GetForHTML cannot find a specific selector
 // for the detected framework.
-func NewRegistry(detector locdoc.FrameworkDetector, fallback locdoc.LinkSelector) *Registry {
-	return &Registry{
+func NewRegistry(detector locdoc.FrameworkDetector, fallback locdoc.LinkSelector, opts ...RegistryOption) *Registry {
+	r := &Registry{
 		detector:  detector,
 		fallback:  fallback,
 		selectors: make(map[locdoc.Framework]locdoc.LinkSelector),
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Get returns the selector for a specific framework.
+// For FrameworkUnknown, returns the highest-priority selector.
 // Returns nil if no selector is registered for the framework.
 func (r *Registry) Get(framework locdoc.Framework) locdoc.LinkSelector {
+	if framework == locdoc.FrameworkUnknown {
+		if len(r.unknown) == 0 {
+			return nil
+		}
+		return r.unknown[0].selector
+	}
 	return r.selectors[framework]
 }
 
 // GetForHTML detects the framework from HTML and returns the appropriate selector.
-// Falls back to the fallback selector if the framework is unknown or no selector
-// is registered for the detected framework.
+// If the framework is unknown or no selector is registered for it, returns a
+// selector that tries the FrameworkUnknown selectors in priority order and
+// then the fallback, using the first one that finds any links.
+//
+// With WithChainedSelectors enabled, a detected framework's selector is
+// combined with the fallback selector (see GetAll).
 func (r *Registry) GetForHTML(html string) locdoc.LinkSelector {
 	framework := r.detector.Detect(html)
 	if selector, ok := r.selectors[framework]; ok {
+		if r.chained {
+			return NewChainedSelector(selector, r.fallback)
+		}
 		return selector
 	}
-	return r.fallback
+	if len(r.unknown) == 0 {
+		return r.fallback
+	}
+
+	chain := make([]locdoc.LinkSelector, 0, len(r.unknown)+1)
+	for _, ps := range r.unknown {
+		chain = append(chain, ps.selector)
+	}
+	chain = append(chain, r.fallback)
+	return &prioritySelector{selectors: chain}
+}
+
+// GetAll returns the selectors applicable to the HTML in fallback order:
+// the selector for the detected framework, if one is registered, followed
+// by the fallback selector.
+func (r *Registry) GetAll(html string) []locdoc.LinkSelector {
+	framework := r.detector.Detect(html)
+	if selector, ok := r.selectors[framework]; ok {
+		return []locdoc.LinkSelector{selector, r.fallback}
+	}
+	return []locdoc.LinkSelector{r.fallback}
 }
 
-// Register adds a selector for a framework.
-// If a selector is already registered for the framework, it is replaced.
+// Register adds a selector for a framework with priority 0.
+// If a selector is already registered for a known framework, it is replaced.
 func (r *Registry) Register(framework locdoc.Framework, selector locdoc.LinkSelector) {
-	r.selectors[framework] = selector
+	r.RegisterWithPriority(framework, selector, 0)
+}
+
+// RegisterWithPriority adds a selector for a framework.
+// Selectors for FrameworkUnknown accumulate and are tried from highest to
+// lowest priority; selectors with equal priority keep registration order.
+// For known frameworks the priority is ignored and the selector replaces
+// any existing one.
+func (r *Registry) RegisterWithPriority(framework locdoc.Framework, selector locdoc.LinkSelector, priority int) {
+	if framework != locdoc.FrameworkUnknown {
+		r.selectors[framework] = selector
+		return
+	}
+
+	r.unknown = append(r.unknown, prioritizedSelector{selector: selector, priority: priority})
+	sort.SliceStable(r.unknown, func(i, j int) bool {
+		return r.unknown[i].priority > r.unknown[j].priority
+	})
 }
 
 // List returns all registered frameworks.
 func (r *Registry) List() []locdoc.Framework {
-	frameworks := make([]locdoc.Framework, 0, len(r.selectors))
+	frameworks := make([]locdoc.Framework, 0, len(r.selectors)+1)
 	for f := range r.selectors {
 		frameworks = append(frameworks, f)
 	}
+	if len(r.unknown) > 0 {
+		frameworks = append(frameworks, locdoc.FrameworkUnknown)
+	}
 	return frameworks
 }
+
+var _ locdoc.LinkSelector = (*prioritySelector)(nil)
+
+// prioritySelector tries selectors in order and returns the links from the
+// first one that finds any.
+type prioritySelector struct {
+	selectors []locdoc.LinkSelector
+}
+
+// ExtractLinks returns the links from the first selector that finds any.
+// A canonical URL entry alone does not count as finding links.
+// Errors from a selector are skipped in favor of the next one; the last
+// error is returned only if no selector finds links.
+func (s *prioritySelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	var lastErr error
+	for _, selector := range s.selectors {
+		links, err := selector.ExtractLinks(html, baseURL, opts)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if hasCrawlableLinks(links) {
+			return links, nil
+		}
+	}
+	return nil, lastErr
+}
+
+// hasCrawlableLinks reports whether links contains anything besides the
+// canonical URL entry for the page itself.
+func hasCrawlableLinks(links []locdoc.DiscoveredLink) bool {
+	for _, link := range links {
+		if link.CanonicalURL == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the highest-priority selector.
+func (s *prioritySelector) Name() string {
+	return s.selectors[0].Name()
+}
@@ -104,6 +104,170 @@ func TestRegistry_GetForHTML(t *testing.T) {
 	})
 }
 
+func TestRegistry_GetAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns framework selector followed by fallback", func(t *testing.T) {
+		t.Parallel()
+
+		detector := &mock.FrameworkDetector{
+			DetectFn: func(html string) locdoc.Framework {
+				return locdoc.FrameworkDocusaurus
+			},
+		}
+		fallback := &mock.LinkSelector{NameFn: func() string { return "generic" }}
+		docusaurus := &mock.LinkSelector{NameFn: func() string { return "docusaurus" }}
+
+		registry := goquery.NewRegistry(detector, fallback)
+		registry.Register(locdoc.FrameworkDocusaurus, docusaurus)
+
+		got := registry.GetAll("<html>docusaurus</html>")
+
+		require.Len(t, got, 2)
+		assert.Equal(t, "docusaurus", got[0].Name())
+		assert.Equal(t, "generic", got[1].Name())
+	})
+
+	t.Run("returns only fallback for unknown framework", func(t *testing.T) {
+		t.Parallel()
+
+		detector := &mock.FrameworkDetector{
+			DetectFn: func(html string) locdoc.Framework {
+				return locdoc.FrameworkUnknown
+			},
+		}
+		fallback := &mock.LinkSelector{NameFn: func() string { return "generic" }}
+
+		registry := goquery.NewRegistry(detector, fallback)
+
+		got := registry.GetAll("<html></html>")
+
+		require.Len(t, got, 1)
+		assert.Equal(t, "generic", got[0].Name())
+	})
+}
+
+func TestRegistry_WithChainedSelectors(t *testing.T) {
+	t.Parallel()
+
+	detector := &mock.FrameworkDetector{
+		DetectFn: func(html string) locdoc.Framework {
+			return locdoc.FrameworkDocusaurus
+		},
+	}
+
+	registry := goquery.NewRegistry(detector, goquery.NewGenericSelector(), goquery.WithChainedSelectors(true))
+	registry.Register(locdoc.FrameworkDocusaurus, goquery.NewDocusaurusSelector())
+
+	got := registry.GetForHTML("<html>docusaurus</html>")
+
+	require.NotNil(t, got)
+	assert.Equal(t, "docusaurus+generic", got.Name())
+}
+
+func TestRegistry_RegisterWithPriority(t *testing.T) {
+	t.Parallel()
+
+	unknown := &mock.FrameworkDetector{
+		DetectFn: func(html string) locdoc.Framework {
+			return locdoc.FrameworkUnknown
+		},
+	}
+	selectorWithLinks := func(name, url string) *mock.LinkSelector {
+		return &mock.LinkSelector{
+			NameFn: func() string { return name },
+			ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return []locdoc.DiscoveredLink{{URL: url}}, nil
+			},
+		}
+	}
+
+	t.Run("returns links from higher-priority selector", func(t *testing.T) {
+		t.Parallel()
+
+		fallback := selectorWithLinks("generic", "https://example.com/generic")
+		low := selectorWithLinks("low", "https://example.com/low")
+		high := selectorWithLinks("high", "https://example.com/high")
+
+		registry := goquery.NewRegistry(unknown, fallback)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, low, 1)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, high, 10)
+
+		got := registry.GetForHTML("<html>custom</html>")
+		require.NotNil(t, got)
+		assert.Equal(t, "high", got.Name())
+
+		links, err := got.ExtractLinks("<html>custom</html>", "https://example.com", locdoc.SelectorOptions{})
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "https://example.com/high", links[0].URL)
+	})
+
+	t.Run("tries next selector when higher-priority one finds no links", func(t *testing.T) {
+		t.Parallel()
+
+		fallback := selectorWithLinks("generic", "https://example.com/generic")
+		empty := &mock.LinkSelector{
+			NameFn: func() string { return "empty" },
+			ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return nil, nil
+			},
+		}
+		low := selectorWithLinks("low", "https://example.com/low")
+
+		registry := goquery.NewRegistry(unknown, fallback)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, low, 1)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, empty, 10)
+
+		links, err := registry.GetForHTML("<html></html>").ExtractLinks("<html></html>", "https://example.com", locdoc.SelectorOptions{})
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "https://example.com/low", links[0].URL)
+	})
+
+	t.Run("tries next selector when higher-priority one finds only a canonical URL", func(t *testing.T) {
+		t.Parallel()
+
+		fallback := selectorWithLinks("generic", "https://example.com/generic")
+		canonicalOnly := &mock.LinkSelector{
+			NameFn: func() string { return "canonical-only" },
+			ExtractLinksFn: func(_ string, baseURL string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return []locdoc.DiscoveredLink{{URL: baseURL, CanonicalURL: "https://example.com/canonical"}}, nil
+			},
+		}
+		low := selectorWithLinks("low", "https://example.com/low")
+
+		registry := goquery.NewRegistry(unknown, fallback)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, low, 1)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, canonicalOnly, 10)
+
+		links, err := registry.GetForHTML("<html></html>").ExtractLinks("<html></html>", "https://example.com", locdoc.SelectorOptions{})
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "https://example.com/low", links[0].URL)
+	})
+
+	t.Run("uses fallback when no prioritized selector finds links", func(t *testing.T) {
+		t.Parallel()
+
+		fallback := selectorWithLinks("generic", "https://example.com/generic")
+		failing := &mock.LinkSelector{
+			NameFn: func() string { return "failing" },
+			ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return nil, locdoc.Errorf(locdoc.EINTERNAL, "parse failed")
+			},
+		}
+
+		registry := goquery.NewRegistry(unknown, fallback)
+		registry.RegisterWithPriority(locdoc.FrameworkUnknown, failing, 5)
+
+		links, err := registry.GetForHTML("<html></html>").ExtractLinks("<html></html>", "https://example.com", locdoc.SelectorOptions{})
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "https://example.com/generic", links[0].URL)
+	})
+}
+
 func TestRegistry_Register(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,43 @@
+package goquery
+
+import (
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.LinkSelector = (*AntoraSelector)(nil)
+
+// AntoraSelector extracts links from Antora documentation sites.
+// Validated against the Antora default UI and PatternFly-based UIs.
+//
+// It extracts from:
+// - .nav-list for the component navigation tree
+// - .doc for links in the article body
+// - select.version for the component version dropdown
+type AntoraSelector struct{}
+
+// NewAntoraSelector creates a new AntoraSelector.
+func NewAntoraSelector() *AntoraSelector {
+	return &AntoraSelector{}
+}
+
+// Name returns the selector's identifier.
+func (s *AntoraSelector) Name() string {
+	return "antora"
+}
+
+// ExtractLinks parses HTML and returns discovered links with priority.
+// Links are deduplicated by URL, keeping the highest priority version.
+// External links (different host than baseURL) are filtered out.
+func (s *AntoraSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	configs := []SelectorConfig{
+		// Navigation tree (PriorityNavigation = 100)
+		{Selector: ".nav-list a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
+		// Content links (PriorityContent = 50)
+		{Selector: ".doc a[href]", Priority: locdoc.PriorityContent, Source: "content"},
+		// Version dropdown options carry the page URL in their value
+		{Selector: "select.version option[value]", Priority: locdoc.PriorityContent, Source: "version", Attr: "value"},
+		// Footer (PriorityFooter = 20)
+		{Selector: "footer a[href]", Priority: locdoc.PriorityFooter, Source: "footer"},
+	}
+	return ExtractLinksWithConfigs(html, baseURL, configs, opts)
+}
@@ -0,0 +1,136 @@
+package goquery_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAntoraSelector_Name(t *testing.T) {
+	t.Parallel()
+
+	s := goquery.NewAntoraSelector()
+	assert.Equal(t, "antora", s.Name())
+}
+
+func TestAntoraSelector_ExtractLinks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("extracts links from nav-list with navigation priority", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head><meta name="generator" content="Antora 3.1.7"></head>
+<body>
+<nav class="nav-menu">
+	<ul class="nav-list">
+		<li class="nav-item"><a class="nav-link" href="intro.html">Introduction</a></li>
+		<li class="nav-item"><a class="nav-link" href="install.html">Installation</a></li>
+	</ul>
+</nav>
+</body>
+</html>`
+
+		s := goquery.NewAntoraSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/1.0/index.html", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+
+		assert.Equal(t, "https://example.com/docs/1.0/intro.html", links[0].URL)
+		assert.Equal(t, locdoc.PriorityNavigation, links[0].Priority)
+		assert.Equal(t, "Introduction", links[0].Text)
+		assert.Equal(t, "nav", links[0].Source)
+
+		assert.Equal(t, "https://example.com/docs/1.0/install.html", links[1].URL)
+	})
+
+	t.Run("extracts links from doc content with content priority", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<article class="doc">
+	<p>See <a href="config.html">Configuration</a>.</p>
+</article>
+</body>
+</html>`
+
+		s := goquery.NewAntoraSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/1.0/index.html", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+
+		assert.Equal(t, "https://example.com/docs/1.0/config.html", links[0].URL)
+		assert.Equal(t, locdoc.PriorityContent, links[0].Priority)
+		assert.Equal(t, "content", links[0].Source)
+	})
+
+	t.Run("extracts version dropdown options", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<select class="version">
+	<option value="/docs/2.0/index.html">2.0</option>
+	<option value="/docs/1.0/index.html" selected>1.0</option>
+	<option>no value</option>
+</select>
+</body>
+</html>`
+
+		s := goquery.NewAntoraSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/1.0/intro.html", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+
+		assert.Equal(t, "https://example.com/docs/2.0/index.html", links[0].URL)
+		assert.Equal(t, "2.0", links[0].Text)
+		assert.Equal(t, "version", links[0].Source)
+		assert.Equal(t, "https://example.com/docs/1.0/index.html", links[1].URL)
+	})
+
+	t.Run("nav priority wins over content for duplicate links", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<ul class="nav-list"><li><a href="intro.html">Intro</a></li></ul>
+<article class="doc"><a href="intro.html">intro</a></article>
+</body>
+</html>`
+
+		s := goquery.NewAntoraSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/index.html", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, locdoc.PriorityNavigation, links[0].Priority)
+	})
+
+	t.Run("filters external links", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<article class="doc"><a href="https://github.com/example/repo">GitHub</a></article>
+</body>
+</html>`
+
+		s := goquery.NewAntoraSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/index.html", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+}
@@ -31,12 +31,12 @@ func (s *BaseSelector) Name() string {
 // Links are deduplicated by URL, keeping the highest priority version.
 // External links (different host than baseURL) are filtered out.
 // The returned links maintain document order based on first occurrence.
-func (s *BaseSelector) ExtractLinks(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+func (s *BaseSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 	configs := []SelectorConfig{
 		{Selector: "nav a[href]", Priority: locdoc.PriorityNavigation, Source: "nav"},
 		{Selector: "aside a[href]", Priority: locdoc.PriorityTOC, Source: "sidebar"},
 		{Selector: "main a[href], article a[href]", Priority: locdoc.PriorityContent, Source: "content"},
 		{Selector: "footer a[href]", Priority: locdoc.PriorityFooter, Source: "footer"},
 	}
-	return ExtractLinksWithConfigs(html, baseURL, configs)
+	return ExtractLinksWithConfigs(html, baseURL, configs, opts)
 }
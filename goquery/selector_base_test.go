@@ -34,7 +34,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -62,7 +62,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -91,7 +91,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -116,7 +116,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -142,7 +142,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -169,7 +169,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -198,7 +198,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -215,7 +215,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><nav><a href="/docs">Docs</a></nav></body></html>`
 
 		s := goquery.NewBaseSelector()
-		_, err := s.ExtractLinks(html, "://invalid-url")
+		_, err := s.ExtractLinks(html, "://invalid-url", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
@@ -225,7 +225,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -245,7 +245,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -272,7 +272,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -294,7 +294,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/current/page")
+		links, err := s.ExtractLinks(html, "https://example.com/current/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -317,7 +317,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -341,7 +341,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -365,7 +365,7 @@ func TestBaseSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewBaseSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1, "links with same base path but different fragments should be deduplicated")
@@ -0,0 +1,60 @@
+package goquery
+
+import (
+	"strings"
+
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.LinkSelector = (*ChainedSelector)(nil)
+
+// ChainedSelector runs several selectors over the same page and merges
+// their links. It lets a framework-specific selector be combined with the
+// generic selector, so links outside the framework's known containers are
+// still discovered.
+type ChainedSelector struct {
+	selectors []locdoc.LinkSelector
+}
+
+// NewChainedSelector creates a ChainedSelector that runs selectors in order.
+func NewChainedSelector(selectors ...locdoc.LinkSelector) *ChainedSelector {
+	return &ChainedSelector{selectors: selectors}
+}
+
+// Name returns the names of the chained selectors joined with "+".
+func (s *ChainedSelector) Name() string {
+	names := make([]string, len(s.selectors))
+	for i, selector := range s.selectors {
+		names[i] = selector.Name()
+	}
+	return strings.Join(names, "+")
+}
+
+// ExtractLinks runs each selector and merges the results.
+// Links are deduplicated by URL, keeping the highest priority version; on
+// equal priority the earlier selector wins. The returned links maintain
+// order of first occurrence.
+func (s *ChainedSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	seen := make(map[string]int)
+	var links []locdoc.DiscoveredLink
+
+	for _, selector := range s.selectors {
+		found, err := selector.ExtractLinks(html, baseURL, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range found {
+			if idx, ok := seen[link.URL]; ok {
+				if link.Priority > links[idx].Priority {
+					links[idx] = link
+				}
+				continue
+			}
+			seen[link.URL] = len(links)
+			links = append(links, link)
+		}
+	}
+
+	return links, nil
+}
@@ -0,0 +1,96 @@
+package goquery_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/goquery"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainedSelector_Name(t *testing.T) {
+	t.Parallel()
+
+	s := goquery.NewChainedSelector(goquery.NewDocusaurusSelector(), goquery.NewGenericSelector())
+	assert.Equal(t, "docusaurus+generic", s.Name())
+}
+
+func TestChainedSelector_ExtractLinks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merges links found by framework and generic selectors", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<div class="theme-doc-sidebar-container">
+	<a href="/docs/intro">Intro</a>
+	<a href="/docs/guide">Guide</a>
+</div>
+<div class="menu">
+	<a href="/docs/guide">Guide</a>
+	<a href="/docs/changelog">Changelog</a>
+</div>
+</body>
+</html>`
+
+		s := goquery.NewChainedSelector(goquery.NewDocusaurusSelector(), goquery.NewGenericSelector())
+		links, err := s.ExtractLinks(html, "https://example.com/docs/", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+
+		byURL := make(map[string]locdoc.DiscoveredLink)
+		for _, l := range links {
+			byURL[l.URL] = l
+		}
+		require.Len(t, byURL, len(links), "links should be deduplicated")
+
+		assert.Equal(t, "sidebar", byURL["https://example.com/docs/intro"].Source)
+		assert.Equal(t, "sidebar", byURL["https://example.com/docs/guide"].Source, "earlier selector wins on equal priority")
+		require.Contains(t, byURL, "https://example.com/docs/changelog", "generic-only link should be included")
+		assert.Equal(t, "nav", byURL["https://example.com/docs/changelog"].Source)
+	})
+
+	t.Run("keeps highest priority version of duplicate links", func(t *testing.T) {
+		t.Parallel()
+
+		low := &mock.LinkSelector{
+			NameFn: func() string { return "low" },
+			ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return []locdoc.DiscoveredLink{{URL: "https://example.com/a", Priority: locdoc.PriorityContent, Source: "content"}}, nil
+			},
+		}
+		high := &mock.LinkSelector{
+			NameFn: func() string { return "high" },
+			ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return []locdoc.DiscoveredLink{{URL: "https://example.com/a", Priority: locdoc.PriorityTOC, Source: "toc"}}, nil
+			},
+		}
+
+		links, err := goquery.NewChainedSelector(low, high).ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, locdoc.PriorityTOC, links[0].Priority)
+		assert.Equal(t, "toc", links[0].Source)
+	})
+
+	t.Run("returns selector error", func(t *testing.T) {
+		t.Parallel()
+
+		failing := &mock.LinkSelector{
+			NameFn: func() string { return "failing" },
+			ExtractLinksFn: func(_ string, _ string, _ locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+				return nil, locdoc.Errorf(locdoc.EINVALID, "bad html")
+			},
+		}
+
+		_, err := goquery.NewChainedSelector(failing).ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+}
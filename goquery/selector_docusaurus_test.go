@@ -38,7 +38,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -76,7 +76,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/docs/page")
+		links, err := s.ExtractLinks(html, "https://example.com/docs/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		// Should have sidebar link + TOC links
@@ -109,7 +109,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -137,7 +137,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -163,7 +163,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -197,7 +197,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -210,7 +210,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewDocusaurusSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -222,7 +222,7 @@ func TestDocusaurusSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><nav class="navbar"><a href="/docs">Docs</a></nav></body></html>`
 
 		s := goquery.NewDocusaurusSelector()
-		_, err := s.ExtractLinks(html, "://invalid")
+		_, err := s.ExtractLinks(html, "://invalid", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
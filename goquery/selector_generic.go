@@ -31,8 +31,21 @@ func (s *GenericSelector) Name() string {
 //   - Content: main, article, .content, .doc-content
 //   - Footer: footer, .footer
 //   - Fallback: a[href] matching base URL path (catches links in non-semantic HTML)
-func (s *GenericSelector) ExtractLinks(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
-	configs := []SelectorConfig{
+//
+// Version switchers ([data-version] links and select.version-selector
+// options) are returned with PriorityContent and Source "version-link".
+//
+// Hidden elements are not treated specially by the selectors themselves:
+// whether links inside them are kept is decided by opts.IncludeHiddenContent,
+// so tab panels that are only hidden until selected can still be crawled.
+func (s *GenericSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	// Use the fallback variant to also extract links matching the base URL path prefix
+	return ExtractLinksWithConfigsAndFallback(html, baseURL, genericConfigs(), opts)
+}
+
+// genericConfigs returns the universal selectors used by GenericSelector.
+func genericConfigs() []SelectorConfig {
+	return []SelectorConfig{
 		// TOC selectors (highest priority after sitemap)
 		{Selector: ".toc a[href], .table-of-contents a[href], .sidebar a[href], aside a[href]", Priority: locdoc.PriorityTOC, Source: "toc"},
 		// Navigation selectors
@@ -41,7 +54,8 @@ func (s *GenericSelector) ExtractLinks(html string, baseURL string) ([]locdoc.Di
 		{Selector: "main a[href], article a[href], .content a[href], .doc-content a[href]", Priority: locdoc.PriorityContent, Source: "content"},
 		// Footer selectors
 		{Selector: "footer a[href], .footer a[href]", Priority: locdoc.PriorityFooter, Source: "footer"},
+		// Version switchers link to the same page in other doc versions
+		{Selector: "a[href][data-version], [data-version] a[href]", Priority: locdoc.PriorityContent, Source: "version-link"},
+		{Selector: "select.version-selector option[value]", Priority: locdoc.PriorityContent, Source: "version-link", Attr: "value"},
 	}
-	// Use the fallback variant to also extract links matching the base URL path prefix
-	return ExtractLinksWithConfigsAndFallback(html, baseURL, configs)
 }
@@ -34,7 +34,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -58,7 +58,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -82,7 +82,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -106,7 +106,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -130,7 +130,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -154,7 +154,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -181,7 +181,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -211,7 +211,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -237,7 +237,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -251,7 +251,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><nav><a href="/docs">Docs</a></nav></body></html>`
 
 		s := goquery.NewGenericSelector()
-		_, err := s.ExtractLinks(html, "://invalid-url")
+		_, err := s.ExtractLinks(html, "://invalid-url", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
@@ -261,7 +261,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -281,7 +281,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -306,7 +306,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -337,7 +337,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 
 		s := goquery.NewGenericSelector()
 		// Base URL includes path - fallback should only include links under this path
-		links, err := s.ExtractLinks(html, "https://tanstack.com/query/v5/docs")
+		links, err := s.ExtractLinks(html, "https://tanstack.com/query/v5/docs", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3) // Only /query/v5/docs/* links
@@ -379,7 +379,7 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGenericSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2) // Both links found
@@ -403,4 +403,59 @@ func TestGenericSelector_ExtractLinks(t *testing.T) {
 		assert.Equal(t, locdoc.PriorityFallback, divLink.Priority)
 		assert.Equal(t, "fallback", divLink.Source)
 	})
+	t.Run("extracts version switcher links with version-link source", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<div class="versions">
+	<a href="/docs/v1/" data-version="v1">v1</a>
+</div>
+<div data-version="v2"><a href="/docs/v2/">v2</a></div>
+<select class="version-selector">
+	<option value="/docs/v3/">v3</option>
+</select>
+</body>
+</html>`
+
+		s := goquery.NewGenericSelector()
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 3)
+		for _, link := range links {
+			assert.Equal(t, locdoc.PriorityContent, link.Priority)
+			assert.Equal(t, "version-link", link.Source)
+		}
+		assert.Equal(t, "https://example.com/docs/v1/", links[0].URL)
+		assert.Equal(t, "https://example.com/docs/v2/", links[1].URL)
+		assert.Equal(t, "https://example.com/docs/v3/", links[2].URL)
+	})
+
+	t.Run("skips links in hidden tabs unless hidden content is included", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<main>
+	<div class="tab"><a href="/docs/python">Python</a></div>
+	<div class="tab" hidden><a href="/docs/go">Go</a></div>
+	<div class="tab" style="display: none"><a href="/docs/rust">Rust</a></div>
+</main>
+</body>
+</html>`
+
+		s := goquery.NewGenericSelector()
+
+		visible, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
+		require.NoError(t, err)
+		require.Len(t, visible, 1)
+		assert.Equal(t, "https://example.com/docs/python", visible[0].URL)
+
+		all, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{IncludeHiddenContent: true})
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
 }
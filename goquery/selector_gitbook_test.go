@@ -38,7 +38,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGitBookSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -73,7 +73,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGitBookSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/docs/page")
+		links, err := s.ExtractLinks(html, "https://example.com/docs/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3)
@@ -105,7 +105,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGitBookSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -131,7 +131,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGitBookSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -155,7 +155,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewGitBookSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -166,7 +166,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewGitBookSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -178,7 +178,7 @@ func TestGitBookSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><div data-testid="space.sidebar"><a href="/docs">Docs</a></div></body></html>`
 
 		s := goquery.NewGitBookSelector()
-		_, err := s.ExtractLinks(html, "://invalid")
+		_, err := s.ExtractLinks(html, "://invalid", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
@@ -28,7 +28,7 @@ func (s *MkDocsSelector) Name() string {
 // ExtractLinks parses HTML and returns discovered links with priority.
 // Links are deduplicated by URL, keeping the highest priority version.
 // External links (different host than baseURL) are filtered out.
-func (s *MkDocsSelector) ExtractLinks(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
+func (s *MkDocsSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
 	configs := []SelectorConfig{
 		// TOC has highest priority (PriorityTOC = 110)
 		{Selector: ".md-sidebar--secondary a[href]", Priority: locdoc.PriorityTOC, Source: "toc"},
@@ -42,5 +42,5 @@ func (s *MkDocsSelector) ExtractLinks(html string, baseURL string) ([]locdoc.Dis
 		// Footer (PriorityFooter = 20)
 		{Selector: "footer a[href]", Priority: locdoc.PriorityFooter, Source: "footer"},
 	}
-	return ExtractLinksWithConfigs(html, baseURL, configs)
+	return ExtractLinksWithConfigs(html, baseURL, configs, opts)
 }
@@ -36,7 +36,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -68,7 +68,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -101,7 +101,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/docs/page")
+		links, err := s.ExtractLinks(html, "https://example.com/docs/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		// Should have primary nav link + TOC links to other pages
@@ -138,7 +138,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/page")
+		links, err := s.ExtractLinks(html, "https://example.com/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -166,7 +166,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/page")
+		links, err := s.ExtractLinks(html, "https://example.com/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links, "anchor-only links should be filtered as self-referential")
@@ -189,7 +189,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -213,7 +213,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -224,7 +224,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewMkDocsSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -236,7 +236,7 @@ func TestMkDocsSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><nav class="md-nav"><a href="/docs">Docs</a></nav></body></html>`
 
 		s := goquery.NewMkDocsSelector()
-		_, err := s.ExtractLinks(html, "://invalid")
+		_, err := s.ExtractLinks(html, "://invalid", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
@@ -36,7 +36,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewNextraSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -71,7 +71,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewNextraSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/docs/page")
+		links, err := s.ExtractLinks(html, "https://example.com/docs/page", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3)
@@ -103,7 +103,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewNextraSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -127,7 +127,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewNextraSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -151,7 +151,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewNextraSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -162,7 +162,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewNextraSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -174,7 +174,7 @@ func TestNextraSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><nav class="nextra-sidebar"><a href="/docs">Docs</a></nav></body></html>`
 
 		s := goquery.NewNextraSelector()
-		_, err := s.ExtractLinks(html, "://invalid")
+		_, err := s.ExtractLinks(html, "://invalid", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
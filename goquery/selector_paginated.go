@@ -0,0 +1,64 @@
+package goquery
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.LinkSelector = (*PaginatedSelector)(nil)
+
+// paginationPathPattern matches path-based pagination like /docs/guide/page/3.
+var paginationPathPattern = regexp.MustCompile(`/page/\d+/?$`)
+
+// PaginatedSelector extracts links from documentation sites that split
+// content across numbered pages (?page=2, /docs/guide/page/3). In addition
+// to the generic selectors, it finds "next page" links and gives them
+// navigation priority so the crawler follows the whole sequence.
+type PaginatedSelector struct{}
+
+// NewPaginatedSelector creates a new PaginatedSelector.
+func NewPaginatedSelector() *PaginatedSelector {
+	return &PaginatedSelector{}
+}
+
+// Name returns the selector's identifier.
+func (s *PaginatedSelector) Name() string {
+	return "paginated"
+}
+
+// ExtractLinks parses HTML and returns discovered links with priority.
+// Next-page links are returned with PriorityNavigation and Source "pagination".
+// Links are deduplicated by URL, keeping the highest priority version.
+// External links (different host than baseURL) are filtered out.
+func (s *PaginatedSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	// Pagination configs come first so next-page links keep the "pagination"
+	// source when the same URL also appears in the navigation.
+	configs := []SelectorConfig{
+		{Selector: "a[rel~=\"next\"][href], link[rel~=\"next\"][href]", Priority: locdoc.PriorityNavigation, Source: "pagination"},
+		{Selector: "a[aria-label=\"Next\"][href], a[aria-label=\"Next page\"][href]", Priority: locdoc.PriorityNavigation, Source: "pagination"},
+		{Selector: ".pagination .next a[href], .pagination a.next[href], .pager .next a[href]", Priority: locdoc.PriorityNavigation, Source: "pagination"},
+	}
+	configs = append(configs, genericConfigs()...)
+	return ExtractLinksWithConfigsAndFallback(html, baseURL, configs, opts)
+}
+
+// IsPaginatedURL reports whether rawURL looks like one page of a paginated
+// sequence, either through a page-number query parameter or a /page/N path.
+func IsPaginatedURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	// Query parameters commonly used for page numbers
+	query := u.Query()
+	for _, param := range []string{"page", "p", "pg", "offset", "start"} {
+		if query.Has(param) {
+			return true
+		}
+	}
+
+	return paginationPathPattern.MatchString(u.Path)
+}
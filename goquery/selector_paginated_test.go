@@ -0,0 +1,114 @@
+package goquery_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginatedSelector_Name(t *testing.T) {
+	t.Parallel()
+
+	s := goquery.NewPaginatedSelector()
+	assert.Equal(t, "paginated", s.Name())
+}
+
+func TestPaginatedSelector_ExtractLinks(t *testing.T) {
+	t.Parallel()
+
+	findLink := func(links []locdoc.DiscoveredLink, url string) *locdoc.DiscoveredLink {
+		for i := range links {
+			if links[i].URL == url {
+				return &links[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("extracts rel=next link with pagination source", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<body>
+<nav><a href="/docs/intro">Intro</a></nav>
+<main><p>Page 1 content</p></main>
+<div class="pagination">
+	<a href="/docs/guide?page=1">1</a>
+	<a href="/docs/guide?page=2" rel="next">Next</a>
+</div>
+</body>
+</html>`
+
+		s := goquery.NewPaginatedSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/guide?page=1", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		next := findLink(links, "https://example.com/docs/guide?page=2")
+		require.NotNil(t, next, "next page link should be extracted")
+		assert.Equal(t, locdoc.PriorityNavigation, next.Priority)
+		assert.Equal(t, "pagination", next.Source)
+
+		nav := findLink(links, "https://example.com/docs/intro")
+		require.NotNil(t, nav, "navigation links should still be extracted")
+		assert.Equal(t, "nav", nav.Source)
+	})
+
+	t.Run("extracts aria-labelled next link", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><body>
+<a href="/blog/page/3" aria-label="Next">&rarr;</a>
+</body></html>`
+
+		s := goquery.NewPaginatedSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/blog/page/2", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		next := findLink(links, "https://example.com/blog/page/3")
+		require.NotNil(t, next)
+		assert.Equal(t, "pagination", next.Source)
+	})
+
+	t.Run("keeps pagination source when next link is inside nav", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<html><body>
+<nav><a href="/docs/list?page=2" rel="next">Next</a></nav>
+</body></html>`
+
+		s := goquery.NewPaginatedSelector()
+		links, err := s.ExtractLinks(html, "https://example.com/docs/list", locdoc.SelectorOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "pagination", links[0].Source)
+	})
+}
+
+func TestIsPaginatedURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com/docs?page=2", true},
+		{"https://example.com/docs?p=3", true},
+		{"https://example.com/docs/guide/page/3", true},
+		{"https://example.com/docs/guide/page/3/", true},
+		{"https://example.com/docs/guide", false},
+		{"https://example.com/docs/page-layouts", false},
+		{"https://example.com/docs?lang=en", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, goquery.IsPaginatedURL(tt.url))
+		})
+	}
+}
@@ -40,7 +40,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/docs/")
+		links, err := s.ExtractLinks(html, "https://example.com/docs/", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -72,7 +72,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/")
+		links, err := s.ExtractLinks(html, "https://example.com/", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3) // Including the TOC header link
@@ -106,7 +106,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/docs/page.html")
+		links, err := s.ExtractLinks(html, "https://example.com/docs/page.html", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3)
@@ -139,7 +139,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/page.html")
+		links, err := s.ExtractLinks(html, "https://example.com/page.html", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -165,7 +165,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/")
+		links, err := s.ExtractLinks(html, "https://example.com/", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -193,7 +193,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/")
+		links, err := s.ExtractLinks(html, "https://example.com/", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -204,7 +204,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewSphinxSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -216,7 +216,7 @@ func TestSphinxSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><nav class="wy-nav-side"><a href="docs.html">Docs</a></nav></body></html>`
 
 		s := goquery.NewSphinxSelector()
-		_, err := s.ExtractLinks(html, "://invalid")
+		_, err := s.ExtractLinks(html, "://invalid", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
@@ -37,7 +37,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -64,7 +64,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -91,7 +91,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -123,7 +123,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com/guide/intro")
+		links, err := s.ExtractLinks(html, "https://example.com/guide/intro", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 3)
@@ -155,7 +155,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 2)
@@ -179,7 +179,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -205,7 +205,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 </html>`
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks(html, "https://example.com")
+		links, err := s.ExtractLinks(html, "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		require.Len(t, links, 1)
@@ -216,7 +216,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 		t.Parallel()
 
 		s := goquery.NewVuePressSelector()
-		links, err := s.ExtractLinks("", "https://example.com")
+		links, err := s.ExtractLinks("", "https://example.com", locdoc.SelectorOptions{})
 
 		require.NoError(t, err)
 		assert.Empty(t, links)
@@ -228,7 +228,7 @@ func TestVuePressSelector_ExtractLinks(t *testing.T) {
 		html := `<html><body><aside class="sidebar"><a href="/docs">Docs</a></aside></body></html>`
 
 		s := goquery.NewVuePressSelector()
-		_, err := s.ExtractLinks(html, "://invalid")
+		_, err := s.ExtractLinks(html, "://invalid", locdoc.SelectorOptions{})
 
 		require.Error(t, err)
 		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
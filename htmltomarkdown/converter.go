@@ -3,6 +3,7 @@
 package htmltomarkdown
 
 import (
+	"bytes"
 	"regexp"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/table"
 	"github.com/fwojciec/locdoc"
+	"golang.org/x/net/html"
 )
 
 // Ensure Converter implements locdoc.Converter at compile time.
@@ -21,18 +23,112 @@ type Converter struct {
 	conv *converter.Converter
 }
 
+// config holds the configuration options for a Converter.
+type config struct {
+	preserveTables bool
+	codeLanguage   bool
+}
+
+// Option configures a Converter.
+type Option func(*config)
+
+// WithPreserveTables controls how HTML tables are converted. When true (the
+// default), tables become GitHub-Flavored Markdown pipe tables. When false,
+// each row becomes a line of plain text with its cells separated by spaces.
+// The converter emitted pipe tables before this option existed, so true is
+// the default that keeps existing output unchanged.
+func WithPreserveTables(preserve bool) Option {
+	return func(c *config) {
+		c.preserveTables = preserve
+	}
+}
+
+// WithCodeLanguage controls whether fenced code blocks carry a language tag.
+// When true (the default), a language-* or lang-* class on a <pre> or <code>
+// element becomes the fence info string, e.g. ```python. When false, all
+// fences are untagged.
+func WithCodeLanguage(annotate bool) Option {
+	return func(c *config) {
+		c.codeLanguage = annotate
+	}
+}
+
 // NewConverter creates a new Converter.
-func NewConverter() *Converter {
-	conv := converter.NewConverter(
-		converter.WithPlugins(
-			base.NewBasePlugin(),
-			commonmark.NewCommonmarkPlugin(),
-			table.NewTablePlugin(),
-		),
-	)
+func NewConverter(opts ...Option) *Converter {
+	cfg := &config{
+		preserveTables: true,
+		codeLanguage:   true,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	plugins := []converter.Plugin{
+		base.NewBasePlugin(),
+		commonmark.NewCommonmarkPlugin(),
+	}
+	if cfg.preserveTables {
+		plugins = append(plugins, table.NewTablePlugin())
+	}
+
+	conv := converter.NewConverter(converter.WithPlugins(plugins...))
+	if !cfg.preserveTables {
+		conv.Register.RendererFor("tr", converter.TagTypeBlock, renderPlainRow, converter.PriorityStandard)
+	}
+	if !cfg.codeLanguage {
+		conv.Register.PreRenderer(stripCodeLanguage, converter.PriorityStandard)
+	}
 	return &Converter{conv: conv}
 }
 
+// stripCodeLanguage removes language-* and lang-* classes from <pre> and
+// <code> elements so code blocks are rendered with untagged fences.
+func stripCodeLanguage(_ converter.Context, doc *html.Node) {
+	stripLanguageClasses(doc)
+}
+
+// stripLanguageClasses removes language classes from n and its descendants.
+func stripLanguageClasses(n *html.Node) {
+	if n.Type == html.ElementNode && (n.Data == "pre" || n.Data == "code") {
+		for i, attr := range n.Attr {
+			if attr.Key != "class" {
+				continue
+			}
+			var kept []string
+			for _, class := range strings.Fields(attr.Val) {
+				if !strings.HasPrefix(class, "language-") && !strings.HasPrefix(class, "lang-") {
+					kept = append(kept, class)
+				}
+			}
+			n.Attr[i].Val = strings.Join(kept, " ")
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		stripLanguageClasses(c)
+	}
+}
+
+// renderPlainRow renders a table row as a single line of text, with the
+// content of each cell separated by a space.
+func renderPlainRow(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	var cells []string
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || (c.Data != "td" && c.Data != "th") {
+			continue
+		}
+		var buf bytes.Buffer
+		ctx.RenderChildNodes(ctx, &buf, c)
+		if text := strings.Join(strings.Fields(buf.String()), " "); text != "" {
+			cells = append(cells, text)
+		}
+	}
+
+	w.WriteString("\n\n")
+	w.WriteString(strings.Join(cells, " "))
+	w.WriteString("\n\n")
+	return converter.RenderSuccess
+}
+
 // Convert transforms HTML content into Markdown.
 func (c *Converter) Convert(html string) (string, error) {
 	if strings.TrimSpace(html) == "" {
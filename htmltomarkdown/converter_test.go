@@ -1,6 +1,7 @@
 package htmltomarkdown_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/fwojciec/locdoc"
@@ -125,6 +126,43 @@ func main() {
 		assert.Contains(t, md, "some code here")
 	})
 
+	t.Run("tags fences with the code language", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<pre><code class="lang-python">print("hi")</code></pre>`
+
+		conv := htmltomarkdown.NewConverter(htmltomarkdown.WithCodeLanguage(true))
+		md, err := conv.Convert(html)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(md, "```python\n"), md)
+		assert.True(t, strings.HasSuffix(md, "\n```"), md)
+	})
+
+	t.Run("leaves fences untagged without a language class", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<pre><code>print("hi")</code></pre>`
+
+		conv := htmltomarkdown.NewConverter(htmltomarkdown.WithCodeLanguage(true))
+		md, err := conv.Convert(html)
+
+		require.NoError(t, err)
+		assert.Equal(t, "```\nprint(\"hi\")\n```", md)
+	})
+
+	t.Run("omits the code language when disabled", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<pre class="highlight"><code class="language-python">print("hi")</code></pre>`
+
+		conv := htmltomarkdown.NewConverter(htmltomarkdown.WithCodeLanguage(false))
+		md, err := conv.Convert(html)
+
+		require.NoError(t, err)
+		assert.Equal(t, "```\nprint(\"hi\")\n```", md)
+	})
+
 	t.Run("converts tables", func(t *testing.T) {
 		t.Parallel()
 
@@ -146,6 +184,40 @@ func main() {
 		assert.Contains(t, md, "---")
 	})
 
+	t.Run("emits pipe tables when tables are preserved", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<table>
+<thead><tr><th>Parameter</th><th>Type</th></tr></thead>
+<tbody><tr><td>timeout</td><td>duration</td></tr></tbody>
+</table>`
+
+		conv := htmltomarkdown.NewConverter(htmltomarkdown.WithPreserveTables(true))
+		md, err := conv.Convert(html)
+
+		require.NoError(t, err)
+		assert.Regexp(t, `\|\s*Parameter\s*\|\s*Type\s*\|`, md)
+		assert.Regexp(t, `\|\s*timeout\s*\|\s*duration\s*\|`, md)
+		assert.Contains(t, md, "---")
+	})
+
+	t.Run("strips tables to plain text when tables are not preserved", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<p>Parameters:</p>
+<table>
+<thead><tr><th>Parameter</th><th>Type</th></tr></thead>
+<tbody><tr><td>timeout</td><td><code>duration</code></td></tr></tbody>
+</table>`
+
+		conv := htmltomarkdown.NewConverter(htmltomarkdown.WithPreserveTables(false))
+		md, err := conv.Convert(html)
+
+		require.NoError(t, err)
+		assert.NotContains(t, md, "|")
+		assert.Equal(t, "Parameters:\n\nParameter Type\n\ntimeout `duration`", md)
+	})
+
 	t.Run("converts bold and italic", func(t *testing.T) {
 		t.Parallel()
 
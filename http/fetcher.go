@@ -3,10 +3,16 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/fwojciec/locdoc"
@@ -25,11 +31,17 @@ var _ locdoc.Fetcher = (*Fetcher)(nil)
 // goroutines.
 type Fetcher struct {
 	client *http.Client
+
+	// cookies without a Domain, sent with every request since the cookie
+	// jar cannot scope them to a host.
+	cookies []*http.Cookie
 }
 
 // config holds the configuration options for a Fetcher.
 type config struct {
-	timeout time.Duration
+	timeout        time.Duration
+	connectTimeout time.Duration
+	cookies        []*http.Cookie
 }
 
 // Option configures a Fetcher.
@@ -43,6 +55,34 @@ func WithTimeout(d time.Duration) Option {
 	}
 }
 
+// WithReadTimeout sets the time limit for a request, from connecting until
+// the response body has been read. It is the same limit as WithTimeout,
+// named to pair with WithConnectTimeout.
+func WithReadTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// WithConnectTimeout sets the time limit for establishing the TCP
+// connection, so unreachable servers fail fast while slow servers still get
+// the full read timeout to deliver content. Zero keeps the default dialer.
+func WithConnectTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.connectTimeout = d
+	}
+}
+
+// WithCookies sends the given cookies with requests, e.g. a session cookie
+// for documentation behind SSO. Cookies with a Domain are stored in a cookie
+// jar and only sent to matching hosts; cookies without a Domain are sent to
+// every host. The jar also keeps cookies set by responses.
+func WithCookies(cookies []*http.Cookie) Option {
+	return func(c *config) {
+		c.cookies = append(c.cookies, cookies...)
+	}
+}
+
 // NewFetcher creates a new HTTP-based Fetcher.
 func NewFetcher(opts ...Option) *Fetcher {
 	cfg := &config{
@@ -52,19 +92,53 @@ func NewFetcher(opts ...Option) *Fetcher {
 		opt(cfg)
 	}
 
-	return &Fetcher{
+	f := &Fetcher{
 		client: &http.Client{
 			Timeout: cfg.timeout,
 		},
 	}
+
+	if cfg.connectTimeout > 0 {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		dialer := &net.Dialer{}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			// The deadline only applies to dialing; an established
+			// connection is not affected when ctx is canceled
+			ctx, cancel := context.WithTimeout(ctx, cfg.connectTimeout)
+			defer cancel()
+			return dialer.DialContext(ctx, network, addr)
+		}
+		f.client.Transport = transport
+	}
+
+	if len(cfg.cookies) > 0 {
+		// cookiejar.New only fails for a non-nil PublicSuffixList
+		jar, _ := cookiejar.New(nil)
+		for _, c := range cfg.cookies {
+			if c.Domain == "" {
+				f.cookies = append(f.cookies, c)
+				continue
+			}
+			host := strings.TrimPrefix(c.Domain, ".")
+			jar.SetCookies(&url.URL{Scheme: "https", Host: host, Path: "/"}, []*http.Cookie{c})
+		}
+		f.client.Jar = jar
+	}
+
+	return f
 }
 
 // Fetch retrieves the HTML content from the given URL.
+// The request is bound to ctx, so canceling ctx aborts it immediately,
+// even while waiting for a slow response.
 func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
 	}
+	for _, c := range f.cookies {
+		req.AddCookie(c)
+	}
 
 	resp, err := f.client.Do(req)
 	if err != nil {
@@ -86,6 +160,33 @@ func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 	return string(body), nil
 }
 
+// PostJSON sends payload as a JSON request body to url, e.g. to notify a
+// webhook. Responses other than 2xx are returned as errors.
+func (f *Fetcher) PostJSON(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d %s for %s", resp.StatusCode, http.StatusText(resp.StatusCode), url)
+	}
+	return nil
+}
+
 // Close releases resources. For HTTP fetcher this is a no-op since
 // http.Client doesn't require explicit cleanup.
 func (f *Fetcher) Close() error {
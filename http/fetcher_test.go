@@ -2,8 +2,11 @@ package http_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -50,6 +53,53 @@ func TestFetcher_Fetch(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("aborts request when context is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer server.Close()
+
+		fetcher := locdochttp.NewFetcher()
+		defer fetcher.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := fetcher.Fetch(ctx, server.URL)
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), 150*time.Millisecond, "Fetch should return within 100ms of cancellation")
+	})
+
+	t.Run("slow response hits read timeout rather than connect timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// Accepts the connection but never sends a body
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		fetcher := locdochttp.NewFetcher(
+			locdochttp.WithConnectTimeout(10*time.Millisecond),
+			locdochttp.WithReadTimeout(200*time.Millisecond),
+		)
+		defer fetcher.Close()
+
+		start := time.Now()
+		_, err := fetcher.Fetch(context.Background(), server.URL)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+		assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	})
+
 	t.Run("respects context cancellation", func(t *testing.T) {
 		t.Parallel()
 
@@ -99,3 +149,146 @@ func TestFetcher_Fetch(t *testing.T) {
 
 // Compile-time verification that Fetcher implements locdoc.Fetcher
 var _ locdoc.Fetcher = (*locdochttp.Fetcher)(nil)
+
+// sessionServer returns 403 unless the request carries session=secret.
+func sessionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("<html><body>Private docs</body></html>"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetcher_Fetch_WithCookies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns error without session cookie", func(t *testing.T) {
+		t.Parallel()
+
+		srv := sessionServer(t)
+		fetcher := locdochttp.NewFetcher()
+
+		_, err := fetcher.Fetch(context.Background(), srv.URL)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+
+	t.Run("sends cookie scoped to domain", func(t *testing.T) {
+		t.Parallel()
+
+		srv := sessionServer(t)
+		fetcher := locdochttp.NewFetcher(locdochttp.WithCookies([]*http.Cookie{
+			{Name: "session", Value: "secret", Domain: "127.0.0.1"},
+		}))
+
+		html, err := fetcher.Fetch(context.Background(), srv.URL)
+
+		require.NoError(t, err)
+		assert.Contains(t, html, "Private docs")
+	})
+
+	t.Run("does not send domain cookie to other hosts", func(t *testing.T) {
+		t.Parallel()
+
+		srv := sessionServer(t)
+		fetcher := locdochttp.NewFetcher(locdochttp.WithCookies([]*http.Cookie{
+			{Name: "session", Value: "secret", Domain: "example.com"},
+		}))
+
+		_, err := fetcher.Fetch(context.Background(), srv.URL)
+
+		require.Error(t, err)
+	})
+
+	t.Run("sends cookie without domain to every host", func(t *testing.T) {
+		t.Parallel()
+
+		srv := sessionServer(t)
+		fetcher := locdochttp.NewFetcher(locdochttp.WithCookies([]*http.Cookie{
+			{Name: "session", Value: "secret"},
+		}))
+
+		html, err := fetcher.Fetch(context.Background(), srv.URL)
+
+		require.NoError(t, err)
+		assert.Contains(t, html, "Private docs")
+	})
+}
+
+func TestFetcher_PostJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends payload as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var contentType string
+		var got map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&got) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		err := locdochttp.NewFetcher().PostJSON(context.Background(), srv.URL, map[string]int{"saved": 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+		assert.Equal(t, map[string]any{"saved": float64(3)}, got)
+	})
+
+	t.Run("returns error for non-2xx status", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		err := locdochttp.NewFetcher().PostJSON(context.Background(), srv.URL, struct{}{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+	})
+}
+
+func TestFetcher_Fetch_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "<html><body>%s</body></html>", r.URL.Path)
+	}))
+	defer server.Close()
+
+	fetcher := locdochttp.NewFetcher(locdochttp.WithCookies([]*http.Cookie{{Name: "session", Value: "abc"}}))
+	defer fetcher.Close()
+
+	const numFetches = 50
+	var wg sync.WaitGroup
+	results := make([]string, numFetches)
+	errs := make([]error, numFetches)
+
+	for i := 0; i < numFetches; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = fetcher.Fetch(context.Background(), fmt.Sprintf("%s/page%d", server.URL, i))
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < numFetches; i++ {
+		require.NoError(t, errs[i], "fetch %d failed", i)
+		assert.Equal(t, fmt.Sprintf("<html><body>/page%d</body></html>", i), results[i])
+	}
+}
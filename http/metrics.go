@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets returns the upper bounds (in seconds) of the crawl duration
+// histogram. Crawls range from seconds for small sites to tens of minutes.
+func durationBuckets() []float64 {
+	return []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600}
+}
+
+// pageKey identifies a locdoc_crawl_pages_total series.
+type pageKey struct {
+	project string
+	status  string
+}
+
+// histogram is a cumulative Prometheus-style histogram.
+type histogram struct {
+	counts []uint64 // One per bucket in Metrics.buckets
+	count  uint64
+	sum    float64
+}
+
+// Metrics collects crawl metrics and exposes them in the Prometheus text
+// exposition format. It implements http.Handler so it can be mounted at
+// /metrics. Metrics is safe for concurrent use.
+type Metrics struct {
+	mu        sync.Mutex
+	pages     map[pageKey]float64
+	bytes     map[string]float64
+	durations map[string]*histogram
+	documents map[string]float64
+	buckets   []float64
+}
+
+// NewMetrics creates an empty Metrics collector.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		pages:     make(map[pageKey]float64),
+		bytes:     make(map[string]float64),
+		durations: make(map[string]*histogram),
+		documents: make(map[string]float64),
+		buckets:   durationBuckets(),
+	}
+}
+
+// AddPages increments the page counter for a project and status.
+func (m *Metrics) AddPages(project, status string, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pages[pageKey{project: project, status: status}] += float64(n)
+}
+
+// AddBytes increments the byte counter for a project.
+func (m *Metrics) AddBytes(project string, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytes[project] += float64(n)
+}
+
+// ObserveDuration records the duration of a crawl for a project.
+func (m *Metrics) ObserveDuration(project string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.durations[project]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[project] = h
+	}
+
+	seconds := d.Seconds()
+	for i, le := range m.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// SetDocuments sets the number of stored documents for a project.
+func (m *Metrics) SetDocuments(project string, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.documents[project] = float64(n)
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.write(w)
+}
+
+// write writes all metrics in the Prometheus text exposition format.
+// Series are sorted by label values so output is deterministic.
+func (m *Metrics) write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP locdoc_crawl_pages_total Pages processed during crawls by outcome.\n")
+	b.WriteString("# TYPE locdoc_crawl_pages_total counter\n")
+	pageKeys := make([]pageKey, 0, len(m.pages))
+	for k := range m.pages {
+		pageKeys = append(pageKeys, k)
+	}
+	sort.Slice(pageKeys, func(i, j int) bool {
+		if pageKeys[i].project != pageKeys[j].project {
+			return pageKeys[i].project < pageKeys[j].project
+		}
+		return pageKeys[i].status < pageKeys[j].status
+	})
+	for _, k := range pageKeys {
+		fmt.Fprintf(&b, "locdoc_crawl_pages_total{project=%s,status=%s} %s\n",
+			quoteLabel(k.project), quoteLabel(k.status), formatFloat(m.pages[k]))
+	}
+
+	b.WriteString("# HELP locdoc_crawl_bytes_total Bytes of markdown content saved during crawls.\n")
+	b.WriteString("# TYPE locdoc_crawl_bytes_total counter\n")
+	for _, project := range sortedKeys(m.bytes) {
+		fmt.Fprintf(&b, "locdoc_crawl_bytes_total{project=%s} %s\n",
+			quoteLabel(project), formatFloat(m.bytes[project]))
+	}
+
+	b.WriteString("# HELP locdoc_crawl_duration_seconds Duration of crawls.\n")
+	b.WriteString("# TYPE locdoc_crawl_duration_seconds histogram\n")
+	for _, project := range sortedKeys(m.durations) {
+		h := m.durations[project]
+		label := quoteLabel(project)
+		for i, le := range m.buckets {
+			fmt.Fprintf(&b, "locdoc_crawl_duration_seconds_bucket{project=%s,le=\"%s\"} %d\n",
+				label, formatFloat(le), h.counts[i])
+		}
+		fmt.Fprintf(&b, "locdoc_crawl_duration_seconds_bucket{project=%s,le=\"+Inf\"} %d\n", label, h.count)
+		fmt.Fprintf(&b, "locdoc_crawl_duration_seconds_sum{project=%s} %s\n", label, formatFloat(h.sum))
+		fmt.Fprintf(&b, "locdoc_crawl_duration_seconds_count{project=%s} %d\n", label, h.count)
+	}
+
+	b.WriteString("# HELP locdoc_documents_total Documents stored for a project.\n")
+	b.WriteString("# TYPE locdoc_documents_total gauge\n")
+	for _, project := range sortedKeys(m.documents) {
+		fmt.Fprintf(&b, "locdoc_documents_total{project=%s} %s\n",
+			quoteLabel(project), formatFloat(m.documents[project]))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sortedKeys returns the keys of a project-keyed map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quoteLabel quotes a label value, escaping backslashes, quotes and newlines
+// as required by the Prometheus text format.
+func quoteLabel(v string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(v) + `"`
+}
+
+// formatFloat formats a sample value using the shortest representation.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// MetricsServer serves Metrics on /metrics.
+type MetricsServer struct {
+	addr   string
+	server *http.Server
+	ln     net.Listener
+}
+
+// NewMetricsServer creates a new MetricsServer that will listen on addr.
+// Call Open to start serving and Close to shut down.
+func NewMetricsServer(addr string, metrics *Metrics) *MetricsServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+
+	return &MetricsServer{
+		addr:   addr,
+		server: &http.Server{Handler: mux, ReadHeaderTimeout: DefaultFetchTimeout},
+	}
+}
+
+// Open starts listening and serves requests in the background.
+func (s *MetricsServer) Open() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("metrics server: %w", err)
+	}
+	s.ln = ln
+
+	go func() { _ = s.server.Serve(ln) }()
+	return nil
+}
+
+// Addr returns the address the server is listening on.
+// Useful when the server was opened with port 0.
+func (s *MetricsServer) Addr() string {
+	if s.ln == nil {
+		return s.addr
+	}
+	return s.ln.Addr().String()
+}
+
+// Close gracefully shuts down the server, waiting up to 5 seconds for
+// in-flight scrapes to finish.
+func (s *MetricsServer) Close() error {
+	if s.ln == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,77 @@
+package http_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	locdochttp "github.com/fwojciec/locdoc/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes metrics in Prometheus text format", func(t *testing.T) {
+		t.Parallel()
+
+		m := locdochttp.NewMetrics()
+		m.AddPages("docs", "saved", 3)
+		m.AddPages("docs", "failed", 1)
+		m.AddBytes("docs", 1024)
+		m.ObserveDuration("docs", 7*time.Second)
+		m.SetDocuments("docs", 3)
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		body := rec.Body.String()
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, body, "# TYPE locdoc_crawl_pages_total counter\n")
+		assert.Contains(t, body, `locdoc_crawl_pages_total{project="docs",status="failed"} 1`)
+		assert.Contains(t, body, `locdoc_crawl_pages_total{project="docs",status="saved"} 3`)
+		assert.Contains(t, body, `locdoc_crawl_bytes_total{project="docs"} 1024`)
+		assert.Contains(t, body, `locdoc_crawl_duration_seconds_bucket{project="docs",le="5"} 0`)
+		assert.Contains(t, body, `locdoc_crawl_duration_seconds_bucket{project="docs",le="10"} 1`)
+		assert.Contains(t, body, `locdoc_crawl_duration_seconds_bucket{project="docs",le="+Inf"} 1`)
+		assert.Contains(t, body, `locdoc_crawl_duration_seconds_sum{project="docs"} 7`)
+		assert.Contains(t, body, `locdoc_documents_total{project="docs"} 3`)
+	})
+
+	t.Run("escapes label values", func(t *testing.T) {
+		t.Parallel()
+
+		m := locdochttp.NewMetrics()
+		m.SetDocuments(`my "docs"`, 1)
+
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Contains(t, rec.Body.String(), `locdoc_documents_total{project="my \"docs\""} 1`)
+	})
+}
+
+func TestMetricsServer(t *testing.T) {
+	t.Parallel()
+
+	m := locdochttp.NewMetrics()
+	m.AddPages("docs", "saved", 2)
+
+	srv := locdochttp.NewMetricsServer("127.0.0.1:0", m)
+	require.NoError(t, srv.Open())
+
+	resp, err := http.Get("http://" + srv.Addr() + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `locdoc_crawl_pages_total{project="docs",status="saved"} 2`)
+
+	require.NoError(t, srv.Close())
+
+	_, err = http.Get("http://" + srv.Addr() + "/metrics")
+	assert.Error(t, err)
+}
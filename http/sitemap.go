@@ -3,11 +3,13 @@ package http
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/beevik/etree"
 	"github.com/fwojciec/locdoc"
@@ -16,9 +18,20 @@ import (
 // Ensure SitemapService implements locdoc.SitemapService.
 var _ locdoc.SitemapService = (*SitemapService)(nil)
 
+// DefaultSitemapRetryDelays returns the default delays between attempts
+// when a sitemap fetch fails transiently: 1s, 2s, 4s (4 attempts total).
+func DefaultSitemapRetryDelays() []time.Duration {
+	return []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
+}
+
 // SitemapService discovers URLs from website sitemaps via HTTP.
 type SitemapService struct {
 	client *http.Client
+
+	// SitemapRetryDelays are the delays between attempts when fetching a
+	// sitemap fails with a network error, 429 or 5xx response. A 404 is
+	// never retried. Defaults to DefaultSitemapRetryDelays.
+	SitemapRetryDelays []time.Duration
 }
 
 // NewSitemapService creates a new SitemapService with the given HTTP client.
@@ -27,14 +40,21 @@ func NewSitemapService(client *http.Client) *SitemapService {
 	if client == nil {
 		client = http.DefaultClient
 	}
-	return &SitemapService{client: client}
+	return &SitemapService{
+		client:             client,
+		SitemapRetryDelays: DefaultSitemapRetryDelays(),
+	}
 }
 
 // DiscoverURLs finds all URLs from a site's sitemap.
-// Returns an empty slice (not nil) if no sitemaps are found.
+// Returns locdoc.ErrSitemapNotFound if neither robots.txt nor /sitemap.xml
+// points to a sitemap, or every declared sitemap returns 404. A sitemap
+// that exists but lists no matching URLs yields an empty slice.
 //
 // When baseURL has a non-root path (e.g., https://example.com/docs/),
-// only URLs with paths starting with that prefix are returned.
+// only URLs with paths starting with that prefix are returned. Paths are
+// compared after percent-decoding and collapsing repeated slashes; the host
+// is not part of the comparison and path matching is case-sensitive.
 func (s *SitemapService) DiscoverURLs(ctx context.Context, baseURL string, filter *locdoc.URLFilter) ([]string, error) {
 	// Check for context cancellation early
 	if err := ctx.Err(); err != nil {
@@ -48,7 +68,7 @@ func (s *SitemapService) DiscoverURLs(ctx context.Context, baseURL string, filte
 	}
 
 	// Extract path prefix for filtering (empty or "/" means no prefix filtering)
-	pathPrefix := base.Path
+	pathPrefix := normalizePath(base.Path)
 	if pathPrefix == "/" {
 		pathPrefix = ""
 	}
@@ -63,18 +83,22 @@ func (s *SitemapService) DiscoverURLs(ctx context.Context, baseURL string, filte
 		return nil, err
 	}
 
-	// If no sitemaps found, return empty list
 	if len(sitemapURLs) == 0 {
-		return []string{}, nil
+		return nil, locdoc.ErrSitemapNotFound
 	}
 
 	// Process all sitemaps and collect URLs
-	var allURLs []string
+	allURLs := []string{}
 	seenSitemaps := make(map[string]bool)
 	seenURLs := make(map[string]bool)
+	missing := 0
 
 	for _, sitemapURL := range sitemapURLs {
 		urls, err := s.processSitemap(ctx, sitemapURL, seenSitemaps)
+		if err == locdoc.ErrSitemapNotFound {
+			missing++
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -86,10 +110,13 @@ func (s *SitemapService) DiscoverURLs(ctx context.Context, baseURL string, filte
 			}
 		}
 	}
+	if missing == len(sitemapURLs) {
+		return nil, locdoc.ErrSitemapNotFound
+	}
 
 	// Apply path prefix filter if baseURL has a non-root path
 	if pathPrefix != "" {
-		var filtered []string
+		filtered := []string{}
 		for _, u := range allURLs {
 			if matchesPathPrefix(u, pathPrefix) {
 				filtered = append(filtered, u)
@@ -100,7 +127,7 @@ func (s *SitemapService) DiscoverURLs(ctx context.Context, baseURL string, filte
 
 	// Apply user-provided filter
 	if filter != nil {
-		var filtered []string
+		filtered := []string{}
 		for _, u := range allURLs {
 			if filter.Match(u) {
 				filtered = append(filtered, u)
@@ -120,7 +147,7 @@ func matchesPathPrefix(rawURL, prefix string) bool {
 	if err != nil {
 		return false
 	}
-	path := parsed.Path
+	path := normalizePath(parsed.Path)
 
 	// Normalize prefix to end with / if non-empty and not ending with /
 	if prefix != "" && !strings.HasSuffix(prefix, "/") {
@@ -142,7 +169,18 @@ func matchesPathPrefix(rawURL, prefix string) bool {
 	return false
 }
 
+// normalizePath collapses runs of slashes so that /docs//page and /docs/page
+// compare equal. The path is expected to be percent-decoded already.
+func normalizePath(p string) string {
+	for strings.Contains(p, "//") {
+		p = strings.ReplaceAll(p, "//", "/")
+	}
+	return p
+}
+
 // findSitemapURLs discovers sitemap URLs from robots.txt or falls back to /sitemap.xml.
+// Returns no URLs if neither exists, and an error if /sitemap.xml cannot be
+// checked because the site is unreachable.
 func (s *SitemapService) findSitemapURLs(ctx context.Context, base *url.URL) ([]string, error) {
 	// Try robots.txt first
 	robotsURL := base.ResolveReference(&url.URL{Path: "/robots.txt"})
@@ -155,11 +193,10 @@ func (s *SitemapService) findSitemapURLs(ctx context.Context, base *url.URL) ([]
 	sitemapURL := base.ResolveReference(&url.URL{Path: "/sitemap.xml"})
 	exists, err := s.urlExists(ctx, sitemapURL.String())
 	if err != nil {
-		// Propagate context errors, treat other errors as "not found"
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
-		return nil, nil
+		return nil, fmt.Errorf("checking %s: %w", sitemapURL, err)
 	}
 	if exists {
 		return []string{sitemapURL.String()}, nil
@@ -196,7 +233,7 @@ func (s *SitemapService) parseSitemapsFromRobots(ctx context.Context, robotsURL
 }
 
 // processSitemap fetches and parses a sitemap, handling both urlset and sitemapindex.
-// Returns empty slice (not error) if the sitemap doesn't exist (404) to allow fallback.
+// Returns locdoc.ErrSitemapNotFound if the sitemap doesn't exist (404).
 func (s *SitemapService) processSitemap(ctx context.Context, sitemapURL string, seen map[string]bool) ([]string, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -208,16 +245,17 @@ func (s *SitemapService) processSitemap(ctx context.Context, sitemapURL string,
 	}
 	seen[sitemapURL] = true
 
-	body, err := s.fetchURL(ctx, sitemapURL)
+	body, err := s.fetchSitemap(ctx, sitemapURL)
 	if err != nil {
 		// Propagate context cancellation errors.
 		if ctxErr := ctx.Err(); ctxErr != nil {
 			return nil, ctxErr
 		}
-		// Treat HTTP 404 as "sitemap doesn't exist" (empty URLs) to enable recursive crawling fallback.
+		// Report HTTP 404 as a missing sitemap so callers can skip it.
 		// Other errors (network errors, server errors) are propagated.
-		if strings.Contains(err.Error(), "HTTP 404") {
-			return nil, nil
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+			return nil, locdoc.ErrSitemapNotFound
 		}
 		return nil, fmt.Errorf("fetching sitemap %s: %w", sitemapURL, err)
 	}
@@ -258,6 +296,10 @@ func (s *SitemapService) processSitemapIndex(ctx context.Context, root *etree.El
 		}
 
 		urls, err := s.processSitemap(ctx, sitemapURL, seen)
+		if err == locdoc.ErrSitemapNotFound {
+			// Indexes commonly outlive the sitemaps they list
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -283,6 +325,53 @@ func (s *SitemapService) parseURLSet(root *etree.Element) []string {
 	return urls
 }
 
+// fetchSitemap fetches a sitemap, retrying transient failures with the
+// delays in SitemapRetryDelays. Other errors are returned immediately.
+func (s *SitemapService) fetchSitemap(ctx context.Context, sitemapURL string) (io.ReadCloser, error) {
+	maxAttempts := len(s.SitemapRetryDelays) + 1 // 1 initial + N retries
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		body, err := s.fetchURL(ctx, sitemapURL)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || !isRetryableFetch(err) || attempt >= maxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(s.SitemapRetryDelays[attempt]):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// isRetryableFetch reports whether a fetchURL error is worth retrying:
+// network errors, 429 and 5xx responses.
+func isRetryableFetch(err error) bool {
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	return statusErr.code == http.StatusTooManyRequests || statusErr.code >= 500
+}
+
+// statusError reports a response with an unexpected HTTP status.
+type statusError struct {
+	code int
+	url  string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d for %s", e.code, e.url)
+}
+
 // fetchURL fetches a URL and returns the response body.
 func (s *SitemapService) fetchURL(ctx context.Context, targetURL string) (io.ReadCloser, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
@@ -297,7 +386,7 @@ func (s *SitemapService) fetchURL(ctx context.Context, targetURL string) (io.Rea
 
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP %d for %s", resp.StatusCode, targetURL)
+		return nil, &statusError{code: resp.StatusCode, url: targetURL}
 	}
 
 	return resp.Body, nil
@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	locdochttp "github.com/fwojciec/locdoc/http"
@@ -220,10 +223,24 @@ func TestSitemapService_DiscoverURLs_NoSitemapFound(t *testing.T) {
 	svc := locdochttp.NewSitemapService(srv.Client())
 	urls, err := svc.DiscoverURLs(context.Background(), srv.URL, nil)
 
-	require.NoError(t, err)
+	assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
 	assert.Empty(t, urls)
 }
 
+func TestSitemapService_DiscoverURLs_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	// Closing the server makes every request fail to connect
+	srv := newTestServer(t, map[string]string{})
+	srv.Close()
+
+	svc := locdochttp.NewSitemapService(srv.Client())
+	_, err := svc.DiscoverURLs(context.Background(), srv.URL, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, locdoc.EINTERNAL, locdoc.ErrorCode(err))
+}
+
 func TestSitemapService_DiscoverURLs_DeduplicatesURLsAcrossSitemaps(t *testing.T) {
 	t.Parallel()
 
@@ -424,11 +441,87 @@ func TestSitemapService_DiscoverURLs_PathPrefixRespectsBoundaries(t *testing.T)
 	assert.Contains(t, urls, srv.URL+"/api/v2/docs")
 }
 
+func TestSitemapService_DiscoverURLs_PathPrefixComparesDecodedPaths(t *testing.T) {
+	t.Parallel()
+
+	sitemapXML := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>{{BASE}}/docs%2Fpage</loc></url>
+  <url><loc>{{BASE}}/%64ocs/intro</loc></url>
+  <url><loc>{{BASE}}/blog%2Fpost</loc></url>
+</urlset>`
+
+	srv := newTestServer(t, map[string]string{
+		"/sitemap.xml": sitemapXML,
+	})
+	defer srv.Close()
+
+	// Encoded sitemap paths match the decoded prefix; returned URLs are unchanged
+	svc := locdochttp.NewSitemapService(srv.Client())
+	urls, err := svc.DiscoverURLs(context.Background(), srv.URL+"/docs/", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{srv.URL + "/docs%2Fpage", srv.URL + "/%64ocs/intro"}, urls)
+
+	// An encoded source path is decoded the same way
+	urls, err = svc.DiscoverURLs(context.Background(), srv.URL+"/%2Fdocs%2F", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{srv.URL + "/docs%2Fpage", srv.URL + "/%64ocs/intro"}, urls)
+}
+
+func TestSitemapService_DiscoverURLs_PathPrefixIsCaseSensitive(t *testing.T) {
+	t.Parallel()
+
+	sitemapXML := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>{{BASE}}/DOCS/intro</loc></url>
+  <url><loc>{{BASE}}/docs/intro</loc></url>
+</urlset>`
+
+	srv := newTestServer(t, map[string]string{
+		"/sitemap.xml": sitemapXML,
+	})
+	defer srv.Close()
+
+	// Hosts are case-insensitive, so an uppercase host still reaches the
+	// sitemap, but the path prefix only matches paths with the same case.
+	baseURL := strings.Replace(srv.URL, "127.0.0.1", "LOCALHOST", 1) + "/DOCS/"
+	svc := locdochttp.NewSitemapService(srv.Client())
+	urls, err := svc.DiscoverURLs(context.Background(), baseURL, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{srv.URL + "/DOCS/intro"}, urls)
+}
+
+func TestSitemapService_DiscoverURLs_PathPrefixNormalizesDoubleSlashes(t *testing.T) {
+	t.Parallel()
+
+	sitemapXML := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>{{BASE}}/docs/page/intro</loc></url>
+  <url><loc>{{BASE}}/docs//page/guide</loc></url>
+  <url><loc>{{BASE}}/docs/pages/api</loc></url>
+</urlset>`
+
+	srv := newTestServer(t, map[string]string{
+		"/sitemap.xml": sitemapXML,
+	})
+	defer srv.Close()
+
+	// Repeated slashes are collapsed on both the source and sitemap paths
+	svc := locdochttp.NewSitemapService(srv.Client())
+	urls, err := svc.DiscoverURLs(context.Background(), srv.URL+"/docs//page", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{srv.URL + "/docs/page/intro", srv.URL + "/docs//page/guide"}, urls)
+}
+
 func TestSitemapService_DiscoverURLs_SitemapDeclaredInRobotsBut404(t *testing.T) {
 	t.Parallel()
 
 	// robots.txt declares a sitemap, but the sitemap doesn't exist (404)
-	// This should return empty URLs (not error) to allow recursive crawling fallback
+	// This is reported as a missing sitemap to allow recursive crawling fallback
 	robotsTxt := `User-agent: *
 Sitemap: {{BASE}}/sitemap.xml
 `
@@ -442,8 +535,87 @@ Sitemap: {{BASE}}/sitemap.xml
 	svc := locdochttp.NewSitemapService(srv.Client())
 	urls, err := svc.DiscoverURLs(context.Background(), srv.URL, nil)
 
-	require.NoError(t, err, "404 on declared sitemap should not be an error")
-	assert.Empty(t, urls, "should return empty URLs when sitemap doesn't exist")
+	assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err), "404 on declared sitemap should report a missing sitemap")
+	assert.Empty(t, urls)
+}
+
+func TestSitemapService_DiscoverURLs_RetriesTransientSitemapErrors(t *testing.T) {
+	t.Parallel()
+
+	// The sitemap is unavailable twice (e.g. a cold start), then served
+	var sitemapRequests atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("Sitemap: " + srv.URL + "/sitemap.xml\n"))
+		case "/sitemap.xml":
+			if sitemapRequests.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`<?xml version="1.0"?>
+<urlset><url><loc>https://example.com/docs/a</loc></url></urlset>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc := locdochttp.NewSitemapService(srv.Client())
+	svc.SitemapRetryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	urls, err := svc.DiscoverURLs(context.Background(), srv.URL, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/docs/a"}, urls)
+	assert.Equal(t, int32(3), sitemapRequests.Load())
+}
+
+func TestSitemapService_DiscoverURLs_DoesNotRetry404(t *testing.T) {
+	t.Parallel()
+
+	var sitemapRequests atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("Sitemap: " + srv.URL + "/sitemap.xml\n"))
+			return
+		}
+		sitemapRequests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	svc := locdochttp.NewSitemapService(srv.Client())
+	svc.SitemapRetryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	_, err := svc.DiscoverURLs(context.Background(), srv.URL, nil)
+
+	assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	assert.Equal(t, int32(1), sitemapRequests.Load())
+}
+
+func TestSitemapService_DiscoverURLs_GivesUpAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	var sitemapRequests atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("Sitemap: " + srv.URL + "/sitemap.xml\n"))
+			return
+		}
+		sitemapRequests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	svc := locdochttp.NewSitemapService(srv.Client())
+	svc.SitemapRetryDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	_, err := svc.DiscoverURLs(context.Background(), srv.URL, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 502")
+	assert.Equal(t, int32(3), sitemapRequests.Load())
 }
 
 func TestSitemapService_DiscoverURLs_SkipsNonXMLSitemaps(t *testing.T) {
@@ -21,6 +21,15 @@ type DiscoveredLink struct {
 	Priority LinkPriority
 	Text     string
 	Source   string // "nav", "sidebar", "content", "footer"
+
+	// Depth is the number of links followed from the crawl's seed URL to
+	// reach this link. Set by the crawler, not by link selectors.
+	Depth int
+
+	// CanonicalURL is set on the link describing the page itself when the
+	// page declares a different canonical URL with <link rel="canonical">.
+	// Such a link has URL set to the page URL and PriorityIgnore.
+	CanonicalURL string
 }
 
 // Framework identifies a documentation framework.
@@ -37,13 +46,27 @@ const (
 	FrameworkGitBook    Framework = "gitbook"
 	FrameworkNextra     Framework = "nextra"
 	FrameworkZeroheight Framework = "zeroheight"
+	FrameworkAntora     Framework = "antora"
+
+	// FrameworkAntoraDynamic is an Antora site whose UI bundle renders
+	// content client-side instead of shipping static HTML.
+	FrameworkAntoraDynamic Framework = "antora-dynamic"
 )
 
+// SelectorOptions configures how a LinkSelector extracts links.
+type SelectorOptions struct {
+	// IncludeHiddenContent extracts links from elements hidden with the
+	// hidden attribute or an inline display:none style. Tabbed and
+	// versioned docs keep every tab in the DOM and only show one, so
+	// links in the inactive tabs are only found with this enabled.
+	IncludeHiddenContent bool
+}
+
 // LinkSelector extracts prioritized links from HTML.
 type LinkSelector interface {
 	// ExtractLinks parses HTML and returns discovered links with priority.
 	// The baseURL is used to resolve relative URLs.
-	ExtractLinks(html string, baseURL string) ([]DiscoveredLink, error)
+	ExtractLinks(html string, baseURL string, opts SelectorOptions) ([]DiscoveredLink, error)
 
 	// Name returns the selector's identifier (e.g., "docusaurus", "generic").
 	Name() string
@@ -60,17 +83,22 @@ type FrameworkDetector interface {
 type Prober interface {
 	FrameworkDetector
 
-	// RequiresJS indicates whether a framework requires JavaScript rendering.
-	// Returns (requires, known) where:
-	//   - requires: true if the framework needs JS to render content
-	//   - known: true if the framework is recognized
+	// RequiresJSForFramework indicates whether a framework requires JavaScript rendering.
+	// Returns (requiresJS, isKnown) where:
+	//   - requiresJS: true if the framework needs JS to render content
+	//   - isKnown: true if the framework is recognized
 	// Unknown frameworks return (false, false).
-	RequiresJS(framework Framework) (requires bool, known bool)
+	RequiresJSForFramework(framework Framework) (requiresJS bool, isKnown bool)
 
 	// RenderDelay returns the recommended delay after page load for a framework.
 	// Some SPA frameworks need additional time for async content to render.
 	// Returns 0 for frameworks that don't need extra delay.
 	RenderDelay(framework Framework) time.Duration
+
+	// IsUsableContent reports whether fetched HTML looks like the requested
+	// page rather than a bot challenge, access-denied or login page, or an
+	// empty shell. Probing falls back to the browser fetcher when it is not.
+	IsUsableContent(html string) bool
 }
 
 // LinkSelectorRegistry manages framework-specific selectors.
@@ -0,0 +1,3 @@
+// Package metadata provides a locdoc.Extractor wrapper that reads Open Graph
+// and schema.org JSON-LD metadata from pages.
+package metadata
@@ -0,0 +1,115 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.Extractor = (*Extractor)(nil)
+
+// Extractor reads Open Graph properties and schema.org JSON-LD markup from
+// a page, then extracts its content with another extractor. The metadata is
+// added to the inner extractor's result.
+type Extractor struct {
+	inner locdoc.Extractor
+}
+
+// NewExtractor creates an Extractor that adds page metadata to the results
+// of inner.
+func NewExtractor(inner locdoc.Extractor) *Extractor {
+	return &Extractor{inner: inner}
+}
+
+// Extract extracts html with the inner extractor and fills in the Open Graph
+// and schema.org fields of the result.
+func (e *Extractor) Extract(html string) (*locdoc.ExtractResult, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "failed to parse HTML: %v", err)
+	}
+
+	result, err := e.inner.Extract(html)
+	if err != nil {
+		return nil, err
+	}
+
+	result.OGTitle = openGraph(doc, "og:title")
+	result.OGDescription = openGraph(doc, "og:description")
+	result.OGImage = openGraph(doc, "og:image")
+	result.SchemaType, result.SchemaVersion = schemaOrg(doc)
+	return result, nil
+}
+
+// openGraph returns the trimmed content of the first meta element with the
+// given Open Graph property.
+func openGraph(doc *goquery.Document, property string) string {
+	content, _ := doc.Find(`meta[property="` + property + `"]`).First().Attr("content")
+	return strings.TrimSpace(content)
+}
+
+// schemaOrg returns the type and version of the first typed object in the
+// page's JSON-LD scripts. Scripts that are not valid JSON are ignored.
+func schemaOrg(doc *goquery.Document) (schemaType, version string) {
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		obj := firstTyped(data)
+		if obj == nil {
+			return true
+		}
+		schemaType = stringValue(obj["@type"])
+		version = stringValue(obj["version"])
+		if version == "" {
+			version = stringValue(obj["softwareVersion"])
+		}
+		return false
+	})
+	return schemaType, version
+}
+
+// firstTyped finds the first object with an @type in JSON-LD data, looking
+// inside top-level arrays and @graph collections.
+func firstTyped(data any) map[string]any {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			if obj := firstTyped(item); obj != nil {
+				return obj
+			}
+		}
+	case map[string]any:
+		if _, ok := v["@type"]; ok {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return firstTyped(graph)
+		}
+	}
+	return nil
+}
+
+// stringValue renders a JSON-LD value as a string. Arrays yield their first
+// element, since @type may list several types.
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case []any:
+		if len(v) == 0 {
+			return ""
+		}
+		return stringValue(v[0])
+	case float64, bool:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
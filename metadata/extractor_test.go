@@ -0,0 +1,111 @@
+package metadata_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/metadata"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	inner := func() *mock.Extractor {
+		return &mock.Extractor{
+			ExtractFn: func(_ string) (*locdoc.ExtractResult, error) {
+				return &locdoc.ExtractResult{Title: "Getting Started", ContentHTML: "<p>Content</p>"}, nil
+			},
+		}
+	}
+
+	t.Run("reads Open Graph properties", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head>
+<title>Getting Started</title>
+<meta property="og:title" content="Getting Started | Tool">
+<meta property="og:description" content="Quick start guide">
+<meta property="og:image" content="https://example.com/card.png">
+</head>
+<body><p>Content</p></body>
+</html>`
+
+		result, err := metadata.NewExtractor(inner()).Extract(html)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Getting Started", result.Title)
+		assert.Equal(t, "<p>Content</p>", result.ContentHTML)
+		assert.Equal(t, "Getting Started | Tool", result.OGTitle)
+		assert.Equal(t, "Quick start guide", result.OGDescription)
+		assert.Equal(t, "https://example.com/card.png", result.OGImage)
+	})
+
+	t.Run("reads schema.org type and version from JSON-LD", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@graph": [
+	{"@type": ["TechArticle", "Article"], "headline": "Getting Started", "version": "2.1"}
+]}</script>
+</head>
+<body><p>Content</p></body>
+</html>`
+
+		result, err := metadata.NewExtractor(inner()).Extract(html)
+
+		require.NoError(t, err)
+		assert.Equal(t, "TechArticle", result.SchemaType)
+		assert.Equal(t, "2.1", result.SchemaVersion)
+	})
+
+	t.Run("skips invalid JSON-LD and falls back to softwareVersion", func(t *testing.T) {
+		t.Parallel()
+
+		html := `<!DOCTYPE html>
+<html>
+<head>
+<script type="application/ld+json">{not json</script>
+<script type="application/ld+json">{"@type": "SoftwareApplication", "softwareVersion": 3}</script>
+</head>
+<body><p>Content</p></body>
+</html>`
+
+		result, err := metadata.NewExtractor(inner()).Extract(html)
+
+		require.NoError(t, err)
+		assert.Equal(t, "SoftwareApplication", result.SchemaType)
+		assert.Equal(t, "3", result.SchemaVersion)
+	})
+
+	t.Run("leaves fields empty without metadata", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := metadata.NewExtractor(inner()).Extract(`<html><body><p>Content</p></body></html>`)
+
+		require.NoError(t, err)
+		assert.Empty(t, result.OGDescription)
+		assert.Empty(t, result.SchemaType)
+	})
+
+	t.Run("returns inner extractor errors", func(t *testing.T) {
+		t.Parallel()
+
+		failing := &mock.Extractor{
+			ExtractFn: func(_ string) (*locdoc.ExtractResult, error) {
+				return nil, errors.New("extract failed")
+			},
+		}
+
+		_, err := metadata.NewExtractor(failing).Extract(`<html></html>`)
+
+		require.Error(t, err)
+	})
+}
@@ -10,9 +10,9 @@ var _ locdoc.Asker = (*Asker)(nil)
 
 // Asker is a mock implementation of locdoc.Asker.
 type Asker struct {
-	AskFn func(ctx context.Context, projectID, question string) (string, error)
+	AskFn func(ctx context.Context, projectID, question string) (*locdoc.AskResult, error)
 }
 
-func (a *Asker) Ask(ctx context.Context, projectID, question string) (string, error) {
+func (a *Asker) Ask(ctx context.Context, projectID, question string) (*locdoc.AskResult, error) {
 	return a.AskFn(ctx, projectID, question)
 }
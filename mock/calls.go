@@ -0,0 +1,27 @@
+package mock
+
+import "sync"
+
+// calls records how many times each method of a mock was invoked.
+// Embed it in a mock and call record from each method. The zero value is
+// ready to use and safe for concurrent use.
+type calls struct {
+	mu     sync.Mutex
+	called map[string]int
+}
+
+func (c *calls) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.called == nil {
+		c.called = make(map[string]int)
+	}
+	c.called[method]++
+}
+
+// CallCount returns how many times the named method has been called.
+func (c *calls) CallCount(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.called[method]
+}
@@ -0,0 +1,71 @@
+package mock_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallCount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts calls per method", func(t *testing.T) {
+		t.Parallel()
+
+		tx := &mock.DocumentTx{
+			DocumentService: mock.DocumentService{
+				CreateDocumentFn: func(_ context.Context, _ *locdoc.Document) error { return nil },
+			},
+			CommitFn: func() error { return nil },
+		}
+
+		_ = tx.CreateDocument(context.Background(), &locdoc.Document{})
+		_ = tx.CreateDocument(context.Background(), &locdoc.Document{})
+		_ = tx.Commit()
+
+		assert.Equal(t, 2, tx.CallCount("CreateDocument"))
+		assert.Equal(t, 1, tx.CallCount("Commit"))
+		assert.Equal(t, 0, tx.CallCount("Rollback"))
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		s := &mock.ContentScorer{
+			ScoreFn: func(_ string) float64 { return 1 },
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.Score("content")
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 10, s.CallCount("Score"))
+	})
+}
+
+func TestFetcher_FetchedURLs(t *testing.T) {
+	t.Parallel()
+
+	f := &mock.Fetcher{
+		FetchFn: func(_ context.Context, _ string) (string, error) { return "", nil },
+		CloseFn: func() error { return nil },
+	}
+
+	_, _ = f.Fetch(context.Background(), "https://example.com/a")
+	_, _ = f.Fetch(context.Background(), "https://example.com/b")
+	_ = f.Close()
+
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, f.FetchedURLs())
+	assert.Equal(t, 2, f.CallCount("Fetch"))
+	assert.Equal(t, 1, f.CallCount("Close"))
+}
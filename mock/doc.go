@@ -1,3 +1,7 @@
 // Package mock provides mock implementations of locdoc service interfaces
 // for testing. Mocks use function fields following Ben Johnson's pattern.
+//
+// Every locdoc interface needs a mock here; the package tests report any
+// that are missing. Mocks that embed calls also record how often each
+// method was invoked, available through CallCount.
 package mock
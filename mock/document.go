@@ -6,33 +6,99 @@ import (
 	"github.com/fwojciec/locdoc"
 )
 
-var _ locdoc.DocumentService = (*DocumentService)(nil)
+var (
+	_ locdoc.DocumentService = (*DocumentService)(nil)
+	_ locdoc.DocumentTx      = (*DocumentTx)(nil)
+)
 
 // DocumentService is a mock implementation of locdoc.DocumentService.
 type DocumentService struct {
 	CreateDocumentFn           func(ctx context.Context, doc *locdoc.Document) error
+	UpsertDocumentFn           func(ctx context.Context, doc *locdoc.Document) error
 	FindDocumentByIDFn         func(ctx context.Context, id string) (*locdoc.Document, error)
 	FindDocumentsFn            func(ctx context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error)
 	DeleteDocumentFn           func(ctx context.Context, id string) error
 	DeleteDocumentsByProjectFn func(ctx context.Context, projectID string) error
+	UpdateDocumentPositionFn   func(ctx context.Context, id string, position int) error
+	SwapDocumentPositionsFn    func(ctx context.Context, id1, id2 string) error
+	BulkCreateDocumentsFn      func(ctx context.Context, docs []*locdoc.Document) error
+	BulkDeleteDocumentsFn      func(ctx context.Context, ids []string) error
+	BeginTxFn                  func(ctx context.Context) (locdoc.DocumentTx, error)
+
+	calls
 }
 
 func (s *DocumentService) CreateDocument(ctx context.Context, doc *locdoc.Document) error {
+	s.record("CreateDocument")
 	return s.CreateDocumentFn(ctx, doc)
 }
 
+func (s *DocumentService) UpsertDocument(ctx context.Context, doc *locdoc.Document) error {
+	s.record("UpsertDocument")
+	return s.UpsertDocumentFn(ctx, doc)
+}
+
 func (s *DocumentService) FindDocumentByID(ctx context.Context, id string) (*locdoc.Document, error) {
+	s.record("FindDocumentByID")
 	return s.FindDocumentByIDFn(ctx, id)
 }
 
 func (s *DocumentService) FindDocuments(ctx context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+	s.record("FindDocuments")
 	return s.FindDocumentsFn(ctx, filter)
 }
 
 func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
+	s.record("DeleteDocument")
 	return s.DeleteDocumentFn(ctx, id)
 }
 
 func (s *DocumentService) DeleteDocumentsByProject(ctx context.Context, projectID string) error {
+	s.record("DeleteDocumentsByProject")
 	return s.DeleteDocumentsByProjectFn(ctx, projectID)
 }
+
+func (s *DocumentService) UpdateDocumentPosition(ctx context.Context, id string, position int) error {
+	s.record("UpdateDocumentPosition")
+	return s.UpdateDocumentPositionFn(ctx, id, position)
+}
+
+func (s *DocumentService) SwapDocumentPositions(ctx context.Context, id1, id2 string) error {
+	s.record("SwapDocumentPositions")
+	return s.SwapDocumentPositionsFn(ctx, id1, id2)
+}
+
+func (s *DocumentService) BulkCreateDocuments(ctx context.Context, docs []*locdoc.Document) error {
+	s.record("BulkCreateDocuments")
+	return s.BulkCreateDocumentsFn(ctx, docs)
+}
+
+func (s *DocumentService) BulkDeleteDocuments(ctx context.Context, ids []string) error {
+	s.record("BulkDeleteDocuments")
+	return s.BulkDeleteDocumentsFn(ctx, ids)
+}
+
+func (s *DocumentService) BeginTx(ctx context.Context) (locdoc.DocumentTx, error) {
+	s.record("BeginTx")
+	return s.BeginTxFn(ctx)
+}
+
+// DocumentTx is a mock implementation of locdoc.DocumentTx. Calls are
+// recorded on the embedded DocumentService, so CallCount covers both the
+// document methods and Commit/Rollback.
+type DocumentTx struct {
+	DocumentService
+
+	CommitFn   func() error
+	RollbackFn func() error
+}
+
+func (t *DocumentTx) Commit() error {
+	t.record("Commit")
+	return t.CommitFn()
+}
+
+func (t *DocumentTx) Rollback() error {
+	t.record("Rollback")
+	return t.RollbackFn()
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"sync"
 
 	"github.com/fwojciec/locdoc"
 )
@@ -9,15 +10,33 @@ import (
 var _ locdoc.Fetcher = (*Fetcher)(nil)
 
 // Fetcher is a mock implementation of locdoc.Fetcher.
+// It records every fetched URL, see FetchedURLs.
 type Fetcher struct {
 	FetchFn func(ctx context.Context, url string) (string, error)
 	CloseFn func() error
+
+	calls
+
+	mu   sync.Mutex
+	urls []string
 }
 
 func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
+	f.record("Fetch")
+	f.mu.Lock()
+	f.urls = append(f.urls, url)
+	f.mu.Unlock()
 	return f.FetchFn(ctx, url)
 }
 
 func (f *Fetcher) Close() error {
+	f.record("Close")
 	return f.CloseFn()
 }
+
+// FetchedURLs returns the URLs passed to Fetch, in call order.
+func (f *Fetcher) FetchedURLs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.urls...)
+}
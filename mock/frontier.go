@@ -2,17 +2,85 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 )
 
-var _ locdoc.DomainLimiter = (*DomainLimiter)(nil)
+var (
+	_ locdoc.URLFrontier   = (*URLFrontier)(nil)
+	_ locdoc.DomainLimiter = (*DomainLimiter)(nil)
+)
+
+// URLFrontier is a mock implementation of locdoc.URLFrontier.
+type URLFrontier struct {
+	PushFn func(link locdoc.DiscoveredLink) bool
+	PopFn  func() (locdoc.DiscoveredLink, bool)
+	LenFn  func() int
+	SeenFn func(url string) bool
+
+	calls
+}
+
+func (f *URLFrontier) Push(link locdoc.DiscoveredLink) bool {
+	f.record("Push")
+	return f.PushFn(link)
+}
+
+func (f *URLFrontier) Pop() (locdoc.DiscoveredLink, bool) {
+	f.record("Pop")
+	return f.PopFn()
+}
+
+func (f *URLFrontier) Len() int {
+	f.record("Len")
+	return f.LenFn()
+}
+
+func (f *URLFrontier) Seen(url string) bool {
+	f.record("Seen")
+	return f.SeenFn(url)
+}
 
 // DomainLimiter is a mock implementation of locdoc.DomainLimiter.
+//
+// Unlike most mocks, WaitFn is optional: WaitDuration and
+// SimulateContextError cover the common rate limiter behaviors without a
+// custom function.
 type DomainLimiter struct {
 	WaitFn func(ctx context.Context, domain string) error
+
+	// WaitDuration makes each Wait block for this long before returning,
+	// simulating a slow rate limiter.
+	WaitDuration time.Duration
+
+	// SimulateContextError makes Wait return ctx.Err() when the context is
+	// canceled before Wait returns, like a real rate limiter. WaitFn is not
+	// called in that case.
+	SimulateContextError bool
+
+	calls
 }
 
 func (l *DomainLimiter) Wait(ctx context.Context, domain string) error {
+	l.record("Wait")
+	if l.WaitDuration > 0 {
+		if l.SimulateContextError {
+			timer := time.NewTimer(l.WaitDuration)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+			case <-timer.C:
+			}
+		} else {
+			time.Sleep(l.WaitDuration)
+		}
+	}
+	if l.SimulateContextError && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if l.WaitFn == nil {
+		return nil
+	}
 	return l.WaitFn(ctx, domain)
 }
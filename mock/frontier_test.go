@@ -0,0 +1,70 @@
+package mock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns nil without WaitFn", func(t *testing.T) {
+		t.Parallel()
+
+		l := &mock.DomainLimiter{}
+
+		require.NoError(t, l.Wait(context.Background(), "example.com"))
+		assert.Equal(t, 1, l.CallCount("Wait"))
+	})
+
+	t.Run("returns context error when SimulateContextError is set", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		l := &mock.DomainLimiter{SimulateContextError: true}
+
+		err := l.Wait(ctx, "example.com")
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("ignores canceled context without SimulateContextError", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		l := &mock.DomainLimiter{}
+
+		require.NoError(t, l.Wait(ctx, "example.com"))
+	})
+
+	t.Run("blocks for WaitDuration", func(t *testing.T) {
+		t.Parallel()
+
+		l := &mock.DomainLimiter{WaitDuration: 20 * time.Millisecond}
+
+		start := time.Now()
+		err := l.Wait(context.Background(), "example.com")
+
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("stops waiting when the context is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		l := &mock.DomainLimiter{WaitDuration: time.Hour, SimulateContextError: true}
+
+		err := l.Wait(ctx, "example.com")
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -10,12 +10,12 @@ var _ locdoc.LinkSelector = (*LinkSelector)(nil)
 
 // LinkSelector is a mock implementation of locdoc.LinkSelector.
 type LinkSelector struct {
-	ExtractLinksFn func(html string, baseURL string) ([]locdoc.DiscoveredLink, error)
+	ExtractLinksFn func(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error)
 	NameFn         func() string
 }
 
-func (s *LinkSelector) ExtractLinks(html string, baseURL string) ([]locdoc.DiscoveredLink, error) {
-	return s.ExtractLinksFn(html, baseURL)
+func (s *LinkSelector) ExtractLinks(html string, baseURL string, opts locdoc.SelectorOptions) ([]locdoc.DiscoveredLink, error) {
+	return s.ExtractLinksFn(html, baseURL, opts)
 }
 
 func (s *LinkSelector) Name() string {
@@ -37,17 +37,18 @@ var _ locdoc.Prober = (*Prober)(nil)
 
 // Prober is a mock implementation of locdoc.Prober.
 type Prober struct {
-	DetectFn      func(html string) locdoc.Framework
-	RequiresJSFn  func(framework locdoc.Framework) (requires bool, known bool)
-	RenderDelayFn func(framework locdoc.Framework) time.Duration
+	DetectFn                 func(html string) locdoc.Framework
+	RequiresJSForFrameworkFn func(framework locdoc.Framework) (requiresJS bool, isKnown bool)
+	RenderDelayFn            func(framework locdoc.Framework) time.Duration
+	IsUsableContentFn        func(html string) bool
 }
 
 func (p *Prober) Detect(html string) locdoc.Framework {
 	return p.DetectFn(html)
 }
 
-func (p *Prober) RequiresJS(framework locdoc.Framework) (requires bool, known bool) {
-	return p.RequiresJSFn(framework)
+func (p *Prober) RequiresJSForFramework(framework locdoc.Framework) (requiresJS bool, isKnown bool) {
+	return p.RequiresJSForFrameworkFn(framework)
 }
 
 func (p *Prober) RenderDelay(framework locdoc.Framework) time.Duration {
@@ -57,6 +58,13 @@ func (p *Prober) RenderDelay(framework locdoc.Framework) time.Duration {
 	return 0
 }
 
+func (p *Prober) IsUsableContent(html string) bool {
+	if p.IsUsableContentFn != nil {
+		return p.IsUsableContentFn(html)
+	}
+	return true
+}
+
 var _ locdoc.LinkSelectorRegistry = (*LinkSelectorRegistry)(nil)
 
 // LinkSelectorRegistry is a mock implementation of locdoc.LinkSelectorRegistry.
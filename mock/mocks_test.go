@@ -0,0 +1,67 @@
+package mock_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMocks_CoverInterfaces checks that every interface declared in the
+// locdoc package has a mock type of the same name in this package.
+func TestMocks_CoverInterfaces(t *testing.T) {
+	t.Parallel()
+
+	interfaces := declaredTypes(t, "..", func(spec *ast.TypeSpec) bool {
+		_, ok := spec.Type.(*ast.InterfaceType)
+		return ok
+	})
+	mocks := declaredTypes(t, ".", func(spec *ast.TypeSpec) bool {
+		_, ok := spec.Type.(*ast.StructType)
+		return ok
+	})
+	require.NotEmpty(t, interfaces, "no locdoc interfaces found")
+
+	for name := range interfaces {
+		if !mocks[name] {
+			t.Errorf("missing mock for locdoc.%s", name)
+		}
+	}
+}
+
+// declaredTypes returns the exported types declared in the non-test Go
+// files of dir for which match returns true, including types declared in
+// grouped type blocks.
+func declaredTypes(t *testing.T, dir string, match func(*ast.TypeSpec) bool) map[string]bool {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if spec.Name.IsExported() && match(spec) {
+					names[spec.Name.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
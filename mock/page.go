@@ -16,18 +16,24 @@ var (
 // URLSource is a mock implementation of locdoc.URLSource.
 type URLSource struct {
 	DiscoverFn func(ctx context.Context, sourceURL string) ([]string, error)
+
+	calls
 }
 
 func (s *URLSource) Discover(ctx context.Context, sourceURL string) ([]string, error) {
+	s.record("Discover")
 	return s.DiscoverFn(ctx, sourceURL)
 }
 
 // PageFetcher is a mock implementation of locdoc.PageFetcher.
 type PageFetcher struct {
 	FetchAllFn func(ctx context.Context, urls []string, progress locdoc.FetchProgressFunc) ([]*locdoc.Page, error)
+
+	calls
 }
 
 func (f *PageFetcher) FetchAll(ctx context.Context, urls []string, progress locdoc.FetchProgressFunc) ([]*locdoc.Page, error) {
+	f.record("FetchAll")
 	return f.FetchAllFn(ctx, urls, progress)
 }
 
@@ -36,16 +42,21 @@ type PageStore struct {
 	SaveFn   func(ctx context.Context, page *locdoc.Page) error
 	CommitFn func() error
 	AbortFn  func() error
+
+	calls
 }
 
 func (s *PageStore) Save(ctx context.Context, page *locdoc.Page) error {
+	s.record("Save")
 	return s.SaveFn(ctx, page)
 }
 
 func (s *PageStore) Commit() error {
+	s.record("Commit")
 	return s.CommitFn()
 }
 
 func (s *PageStore) Abort() error {
+	s.record("Abort")
 	return s.AbortFn()
 }
@@ -0,0 +1,17 @@
+package mock
+
+import "github.com/fwojciec/locdoc"
+
+var _ locdoc.ContentScorer = (*ContentScorer)(nil)
+
+// ContentScorer is a mock implementation of locdoc.ContentScorer.
+type ContentScorer struct {
+	ScoreFn func(content string) float64
+
+	calls
+}
+
+func (s *ContentScorer) Score(content string) float64 {
+	s.record("Score")
+	return s.ScoreFn(content)
+}
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.SessionService = (*SessionService)(nil)
+
+// SessionService is a mock implementation of locdoc.SessionService.
+type SessionService struct {
+	CreateSessionFn   func(ctx context.Context, session *locdoc.Session) error
+	FindSessionByIDFn func(ctx context.Context, id string) (*locdoc.Session, error)
+	FindSessionsFn    func(ctx context.Context, filter locdoc.SessionFilter) ([]*locdoc.Session, error)
+	AppendTurnFn      func(ctx context.Context, id string, turn locdoc.Turn) error
+	DeleteSessionFn   func(ctx context.Context, id string) error
+}
+
+func (s *SessionService) CreateSession(ctx context.Context, session *locdoc.Session) error {
+	return s.CreateSessionFn(ctx, session)
+}
+
+func (s *SessionService) FindSessionByID(ctx context.Context, id string) (*locdoc.Session, error) {
+	return s.FindSessionByIDFn(ctx, id)
+}
+
+func (s *SessionService) FindSessions(ctx context.Context, filter locdoc.SessionFilter) ([]*locdoc.Session, error) {
+	return s.FindSessionsFn(ctx, filter)
+}
+
+func (s *SessionService) AppendTurn(ctx context.Context, id string, turn locdoc.Turn) error {
+	return s.AppendTurnFn(ctx, id, turn)
+}
+
+func (s *SessionService) DeleteSession(ctx context.Context, id string) error {
+	return s.DeleteSessionFn(ctx, id)
+}
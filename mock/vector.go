@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/fwojciec/locdoc"
+)
+
+var _ locdoc.VectorEncoder = (*VectorEncoder)(nil)
+
+// VectorEncoder is a mock implementation of locdoc.VectorEncoder.
+type VectorEncoder struct {
+	EncodeTextFn func(ctx context.Context, text string) ([]float32, error)
+
+	calls
+}
+
+func (e *VectorEncoder) EncodeText(ctx context.Context, text string) ([]float32, error) {
+	e.record("EncodeText")
+	return e.EncodeTextFn(ctx, text)
+}
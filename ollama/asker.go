@@ -0,0 +1,189 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/gemini"
+)
+
+// DefaultBaseURL is the address of a local Ollama server.
+const DefaultBaseURL = "http://localhost:11434"
+
+// Ensure Asker implements locdoc.Asker at compile time.
+var _ locdoc.Asker = (*Asker)(nil)
+
+// Asker implements locdoc.Asker using models served by Ollama. It sends the
+// same system instruction and prompt as the Gemini asker so answers follow
+// the same structure regardless of provider.
+type Asker struct {
+	model  string
+	docs   locdoc.DocumentService
+	client *http.Client
+
+	// BaseURL is the Ollama server address. Defaults to DefaultBaseURL.
+	BaseURL string
+
+	// ResponseLanguage asks the model to answer in this language. See
+	// gemini.Asker.ResponseLanguage.
+	ResponseLanguage string
+
+	// ExcludeURLs lists documents to leave out of the prompt. See
+	// gemini.Asker.ExcludeURLs.
+	ExcludeURLs []string
+
+	// Encoder and NearestDocuments select the documents most similar to the
+	// question. See gemini.Asker.Encoder.
+	Encoder          locdoc.VectorEncoder
+	NearestDocuments int
+}
+
+// NewAsker creates a new Asker.
+func NewAsker(model string, docs locdoc.DocumentService) *Asker {
+	return &Asker{
+		model:   model,
+		docs:    docs,
+		client:  &http.Client{},
+		BaseURL: DefaultBaseURL,
+	}
+}
+
+// chatMessage is a message in a chat request or response.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatOptions holds the model parameters of a chat request.
+type chatOptions struct {
+	Temperature *float32 `json:"temperature,omitempty"`
+}
+
+// chatRequest is the body of a /api/chat request.
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  chatOptions   `json:"options"`
+}
+
+// chatResponse is the subset of a non-streaming /api/chat response used by
+// Asker.
+type chatResponse struct {
+	Message chatMessage `json:"message"`
+}
+
+// Ask answers a natural language question about a project's documentation.
+func (a *Asker) Ask(ctx context.Context, projectID, question string) (*locdoc.AskResult, error) {
+	if projectID == "" {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "project ID required")
+	}
+	if question == "" {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "question required")
+	}
+
+	filter, err := locdoc.NearTextFilter(ctx, a.Encoder, question, a.NearestDocuments, locdoc.DocumentFilter{
+		ProjectID:   &projectID,
+		ExcludeURLs: a.ExcludeURLs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	docs, err := a.docs.FindDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		if filter.NearVector != nil {
+			return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents with embeddings found for project %q", projectID)
+		}
+		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents found for project %q", projectID)
+	}
+
+	config := gemini.BuildConfig()
+	req := chatRequest{
+		Model: a.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: config.SystemInstruction.Parts[0].Text},
+			{Role: "user", Content: gemini.BuildUserPrompt(docs, question, a.ResponseLanguage)},
+		},
+		Options: chatOptions{Temperature: config.Temperature},
+	}
+
+	resp, err := a.chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := resp.Message.Content
+	sources := locdoc.SourceRefs(answer, docs)
+	return &locdoc.AskResult{
+		Answer:         answer,
+		CitedDocuments: locdoc.CitedURLs(sources),
+		Sources:        sources,
+	}, nil
+}
+
+// chat sends a non-streaming chat request.
+func (a *Asker) chat(ctx context.Context, body chatRequest) (*chatResponse, error) {
+	var result chatResponse
+	if err := post(ctx, a.client, a.BaseURL, "/api/chat", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// errorResponse is the error field Ollama includes in failed responses.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// post sends body as JSON to path on the Ollama server at baseURL and
+// decodes a successful response into result. Failures are reported with the
+// server's error message when it sends one.
+func post(ctx context.Context, client *http.Client, baseURL, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return locdoc.Errorf(locdoc.EINTERNAL, "ollama: %v (is the server running at %s?)", err, baseURL)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := http.StatusText(resp.StatusCode)
+		var errResp errorResponse
+		if json.Unmarshal(raw, &errResp) == nil && errResp.Error != "" {
+			msg = errResp.Error
+		}
+		code := locdoc.EINTERNAL
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+			code = locdoc.EINVALID
+		}
+		return locdoc.Errorf(code, "ollama: %s (HTTP %d)", msg, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(raw, result); err != nil {
+		return fmt.Errorf("decode %s response: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package ollama_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/gemini"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/fwojciec/locdoc/ollama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chatRequest mirrors the fields of a chat request checked by tests.
+type chatRequest struct {
+	Model    string `json:"model"`
+	Stream   bool   `json:"stream"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+}
+
+func testDocs() *mock.DocumentService {
+	return &mock.DocumentService{
+		FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{
+				{Title: "Intro", SourceURL: "https://example.com/intro", Content: "# Intro\n\nHello."},
+			}, nil
+		},
+	}
+}
+
+func TestAsker_Ask(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends system instruction and documentation prompt", func(t *testing.T) {
+		t.Parallel()
+
+		var got chatRequest
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/chat", r.URL.Path)
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"The documentation states hello.\n\nSources:\n- https://example.com/intro#intro"},"done":true}`))
+		}))
+		defer srv.Close()
+
+		asker := ollama.NewAsker("llama3.2", testDocs())
+		asker.BaseURL = srv.URL
+
+		result, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.NoError(t, err)
+		assert.Contains(t, result.Answer, "The documentation states hello.")
+		assert.Equal(t, []string{"https://example.com/intro"}, result.CitedDocuments)
+		assert.Equal(t, "llama3.2", got.Model)
+		assert.False(t, got.Stream)
+		require.Len(t, got.Messages, 2)
+		assert.Equal(t, "system", got.Messages[0].Role)
+		assert.Equal(t, gemini.BuildConfig().SystemInstruction.Parts[0].Text, got.Messages[0].Content)
+		assert.Equal(t, "user", got.Messages[1].Role)
+		assert.Contains(t, got.Messages[1].Content, "<question>What does it say?</question>")
+	})
+
+	t.Run("returns server error message", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"model \"missing\" not found, try pulling it first"}`))
+		}))
+		defer srv.Close()
+
+		asker := ollama.NewAsker("missing", testDocs())
+		asker.BaseURL = srv.URL
+
+		_, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "try pulling it first")
+	})
+
+	t.Run("searches nearest documents when Encoder is set", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"Hello."},"done":true}`))
+		}))
+		defer srv.Close()
+
+		var filter locdoc.DocumentFilter
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, f locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				filter = f
+				return []*locdoc.Document{{Title: "Intro", SourceURL: "https://example.com/intro", Content: "Hello."}}, nil
+			},
+		}
+		asker := ollama.NewAsker("llama3.2", docs)
+		asker.BaseURL = srv.URL
+		asker.NearestDocuments = 4
+		asker.Encoder = &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, text string) ([]float32, error) {
+				assert.Equal(t, "What does it say?", text)
+				return []float32{1, 0}, nil
+			},
+		}
+
+		_, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.NoError(t, err)
+		assert.Equal(t, []float32{1, 0}, filter.NearVector)
+		assert.Equal(t, 4, filter.Limit)
+	})
+
+	t.Run("returns ENOTFOUND when project has no documents", func(t *testing.T) {
+		t.Parallel()
+
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return nil, nil
+			},
+		}
+
+		_, err := ollama.NewAsker("llama3.2", docs).Ask(context.Background(), "proj-1", "q")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+
+	t.Run("reports missing embeddings when vector search finds nothing", func(t *testing.T) {
+		t.Parallel()
+
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return nil, nil
+			},
+		}
+		asker := ollama.NewAsker("llama3.2", docs)
+		asker.Encoder = &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, _ string) ([]float32, error) {
+				return []float32{1, 0}, nil
+			},
+		}
+
+		_, err := asker.Ask(context.Background(), "proj-1", "q")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "no documents with embeddings")
+	})
+}
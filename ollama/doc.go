@@ -0,0 +1,3 @@
+// Package ollama provides implementations of locdoc.Asker and
+// locdoc.VectorEncoder using a local Ollama server's chat and embedding APIs.
+package ollama
@@ -0,0 +1,53 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// Ensure Encoder implements locdoc.VectorEncoder at compile time.
+var _ locdoc.VectorEncoder = (*Encoder)(nil)
+
+// Encoder implements locdoc.VectorEncoder using an embedding model served by
+// Ollama, such as nomic-embed-text.
+type Encoder struct {
+	model  string
+	client *http.Client
+
+	// BaseURL is the Ollama server address. Defaults to DefaultBaseURL.
+	BaseURL string
+}
+
+// NewEncoder creates a new Encoder.
+func NewEncoder(model string) *Encoder {
+	return &Encoder{
+		model:   model,
+		client:  &http.Client{},
+		BaseURL: DefaultBaseURL,
+	}
+}
+
+// embedRequest is the body of a /api/embed request.
+type embedRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+// embedResponse is the subset of an /api/embed response used by Encoder.
+type embedResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// EncodeText returns the embedding of text.
+func (e *Encoder) EncodeText(ctx context.Context, text string) ([]float32, error) {
+	var resp embedResponse
+	if err := post(ctx, e.client, e.BaseURL, "/api/embed", embedRequest{Model: e.model, Input: text}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Embeddings) == 0 || len(resp.Embeddings[0]) == 0 {
+		return nil, locdoc.Errorf(locdoc.EINTERNAL, "ollama: no embedding returned by model %q", e.model)
+	}
+	return resp.Embeddings[0], nil
+}
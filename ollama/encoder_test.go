@@ -0,0 +1,79 @@
+package ollama_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/ollama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_EncodeText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns embedding from embed API", func(t *testing.T) {
+		t.Parallel()
+
+		var got struct {
+			Model string `json:"model"`
+			Input string `json:"input"`
+		}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/embed", r.URL.Path)
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"model":"nomic-embed-text","embeddings":[[0.25,-0.5,1]]}`))
+		}))
+		defer srv.Close()
+
+		encoder := ollama.NewEncoder("nomic-embed-text")
+		encoder.BaseURL = srv.URL
+
+		vector, err := encoder.EncodeText(context.Background(), "hello")
+
+		require.NoError(t, err)
+		assert.Equal(t, []float32{0.25, -0.5, 1}, vector)
+		assert.Equal(t, "nomic-embed-text", got.Model)
+		assert.Equal(t, "hello", got.Input)
+	})
+
+	t.Run("returns server error message", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"model \"missing\" not found, try pulling it first"}`))
+		}))
+		defer srv.Close()
+
+		encoder := ollama.NewEncoder("missing")
+		encoder.BaseURL = srv.URL
+
+		_, err := encoder.EncodeText(context.Background(), "hello")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "try pulling it first")
+	})
+
+	t.Run("returns EINTERNAL when no embedding is returned", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"embeddings":[]}`))
+		}))
+		defer srv.Close()
+
+		encoder := ollama.NewEncoder("nomic-embed-text")
+		encoder.BaseURL = srv.URL
+
+		_, err := encoder.EncodeText(context.Background(), "hello")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINTERNAL, locdoc.ErrorCode(err))
+	})
+}
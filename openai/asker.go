@@ -0,0 +1,179 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/gemini"
+)
+
+// DefaultBaseURL is the base URL of the OpenAI API.
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+// Ensure Asker implements locdoc.Asker at compile time.
+var _ locdoc.Asker = (*Asker)(nil)
+
+// Asker implements locdoc.Asker using OpenAI chat models. It sends the same
+// system instruction and prompt as the Gemini asker so answers follow the
+// same structure regardless of provider.
+type Asker struct {
+	apiKey string
+	model  string
+	docs   locdoc.DocumentService
+	client *http.Client
+
+	// BaseURL is the API base URL. Defaults to DefaultBaseURL; override to
+	// use an OpenAI-compatible server.
+	BaseURL string
+
+	// ResponseLanguage asks the model to answer in this language. See
+	// gemini.Asker.ResponseLanguage.
+	ResponseLanguage string
+
+	// ExcludeURLs lists documents to leave out of the prompt. See
+	// gemini.Asker.ExcludeURLs.
+	ExcludeURLs []string
+
+	// Encoder and NearestDocuments select the documents most similar to the
+	// question. See gemini.Asker.Encoder.
+	Encoder          locdoc.VectorEncoder
+	NearestDocuments int
+}
+
+// NewAsker creates a new Asker.
+func NewAsker(apiKey, model string, docs locdoc.DocumentService) *Asker {
+	return &Asker{
+		apiKey:  apiKey,
+		model:   model,
+		docs:    docs,
+		client:  &http.Client{},
+		BaseURL: DefaultBaseURL,
+	}
+}
+
+// chatMessage is a message in a chat completion request or response.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest is the body of a chat completion request.
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature *float32      `json:"temperature,omitempty"`
+}
+
+// chatResponse is the subset of a chat completion response used by Asker.
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Ask answers a natural language question about a project's documentation.
+func (a *Asker) Ask(ctx context.Context, projectID, question string) (*locdoc.AskResult, error) {
+	if projectID == "" {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "project ID required")
+	}
+	if question == "" {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "question required")
+	}
+
+	filter, err := locdoc.NearTextFilter(ctx, a.Encoder, question, a.NearestDocuments, locdoc.DocumentFilter{
+		ProjectID:   &projectID,
+		ExcludeURLs: a.ExcludeURLs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	docs, err := a.docs.FindDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		if filter.NearVector != nil {
+			return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents with embeddings found for project %q", projectID)
+		}
+		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "no documents found for project %q", projectID)
+	}
+
+	config := gemini.BuildConfig()
+	req := chatRequest{
+		Model: a.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: config.SystemInstruction.Parts[0].Text},
+			{Role: "user", Content: gemini.BuildUserPrompt(docs, question, a.ResponseLanguage)},
+		},
+		Temperature: config.Temperature,
+	}
+
+	resp, err := a.complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, locdoc.Errorf(locdoc.EINTERNAL, "openai returned no choices")
+	}
+
+	answer := resp.Choices[0].Message.Content
+	sources := locdoc.SourceRefs(answer, docs)
+	return &locdoc.AskResult{
+		Answer:         answer,
+		CitedDocuments: locdoc.CitedURLs(sources),
+		Sources:        sources,
+	}, nil
+}
+
+// complete sends a chat completion request.
+func (a *Asker) complete(ctx context.Context, body chatRequest) (*chatResponse, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.BaseURL+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+a.apiKey)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result chatResponse
+	if err := json.Unmarshal(raw, &result); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("decode chat completion: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := http.StatusText(resp.StatusCode)
+		if result.Error != nil && result.Error.Message != "" {
+			msg = result.Error.Message
+		}
+		code := locdoc.EINTERNAL
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusBadRequest {
+			code = locdoc.EINVALID
+		}
+		return nil, locdoc.Errorf(code, "openai: %s (HTTP %d)", msg, resp.StatusCode)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,178 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/gemini"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/fwojciec/locdoc/openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chatRequest mirrors the fields of a chat completion request checked by tests.
+type chatRequest struct {
+	Model    string `json:"model"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+}
+
+func testDocs() *mock.DocumentService {
+	return &mock.DocumentService{
+		FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			return []*locdoc.Document{
+				{Title: "Intro", SourceURL: "https://example.com/intro", Content: "# Intro\n\nHello."},
+			}, nil
+		},
+	}
+}
+
+func TestAsker_Ask(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends system instruction and documentation prompt", func(t *testing.T) {
+		t.Parallel()
+
+		var got chatRequest
+		var auth string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/chat/completions", r.URL.Path)
+			auth = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"The documentation states hello."}}]}`))
+		}))
+		defer srv.Close()
+
+		asker := openai.NewAsker("sk-test", "gpt-4o", testDocs())
+		asker.BaseURL = srv.URL
+
+		result, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.NoError(t, err)
+		assert.Equal(t, "The documentation states hello.", result.Answer)
+		assert.Equal(t, "Bearer sk-test", auth)
+		assert.Equal(t, "gpt-4o", got.Model)
+		require.Len(t, got.Messages, 2)
+		assert.Equal(t, "system", got.Messages[0].Role)
+		assert.Equal(t, gemini.BuildConfig().SystemInstruction.Parts[0].Text, got.Messages[0].Content)
+		assert.Equal(t, "user", got.Messages[1].Role)
+		assert.Contains(t, got.Messages[1].Content, "<documents>")
+		assert.Contains(t, got.Messages[1].Content, "<source>https://example.com/intro</source>")
+		assert.Contains(t, got.Messages[1].Content, "<question>What does it say?</question>")
+	})
+
+	t.Run("tracks documents cited in the answer", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"It says hello.\n\nSources:\n- https://example.com/intro#intro"}}]}`))
+		}))
+		defer srv.Close()
+
+		asker := openai.NewAsker("sk-test", "gpt-4o", testDocs())
+		asker.BaseURL = srv.URL
+
+		result, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.com/intro"}, result.CitedDocuments)
+	})
+
+	t.Run("excludes documents by URL", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello."}}]}`))
+		}))
+		defer srv.Close()
+
+		var filter locdoc.DocumentFilter
+		docs := testDocs()
+		findDocuments := docs.FindDocumentsFn
+		docs.FindDocumentsFn = func(ctx context.Context, f locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+			filter = f
+			return findDocuments(ctx, f)
+		}
+
+		asker := openai.NewAsker("sk-test", "gpt-4o", docs)
+		asker.BaseURL = srv.URL
+		asker.ExcludeURLs = []string{"https://example.com/changelog"}
+
+		_, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://example.com/changelog"}, filter.ExcludeURLs)
+	})
+
+	t.Run("returns API error message", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided"}}`))
+		}))
+		defer srv.Close()
+
+		asker := openai.NewAsker("bad", "gpt-4o", testDocs())
+		asker.BaseURL = srv.URL
+
+		_, err := asker.Ask(context.Background(), "proj-1", "What does it say?")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "Incorrect API key")
+	})
+
+	t.Run("returns ENOTFOUND when project has no documents", func(t *testing.T) {
+		t.Parallel()
+
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return nil, nil
+			},
+		}
+
+		_, err := openai.NewAsker("sk-test", "gpt-4o", docs).Ask(context.Background(), "proj-1", "q")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+
+	t.Run("reports missing embeddings when vector search finds nothing", func(t *testing.T) {
+		t.Parallel()
+
+		docs := &mock.DocumentService{
+			FindDocumentsFn: func(_ context.Context, _ locdoc.DocumentFilter) ([]*locdoc.Document, error) {
+				return nil, nil
+			},
+		}
+		asker := openai.NewAsker("sk-test", "gpt-4o", docs)
+		asker.Encoder = &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, _ string) ([]float32, error) {
+				return []float32{1, 0}, nil
+			},
+		}
+
+		_, err := asker.Ask(context.Background(), "proj-1", "q")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "no documents with embeddings")
+	})
+
+	t.Run("returns EINVALID when question is empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := openai.NewAsker("sk-test", "gpt-4o", nil).Ask(context.Background(), "proj-1", "")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+}
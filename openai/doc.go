@@ -0,0 +1,3 @@
+// Package openai provides an implementation of locdoc.Asker using the OpenAI
+// Chat Completions API.
+package openai
@@ -2,20 +2,50 @@ package locdoc
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Project represents a documentation source to be crawled and indexed.
 type Project struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	SourceURL string    `json:"sourceUrl"`
-	LocalPath string    `json:"localPath"`
-	Filter    string    `json:"filter"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	SourceURL   string      `json:"sourceUrl"`
+	LocalPath   string      `json:"localPath"`
+	Filter      string      `json:"filter"`
+	FetcherType FetcherType `json:"fetcherType"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+
+	// LastCrawledAt is when the last successful crawl completed.
+	// Nil if the project has never been crawled.
+	LastCrawledAt *time.Time `json:"lastCrawledAt"`
+
+	// CrawlDepth limits how many links away from SourceURL recursive
+	// crawls go. Zero means unlimited.
+	CrawlDepth int `json:"crawlDepth"`
+
+	// WebhookURL receives a JSON summary of each completed crawl.
+	// Empty disables the notification.
+	WebhookURL string `json:"webhookUrl"`
+
+	// Tags are free-form labels used to group and filter projects.
+	Tags []string `json:"tags"`
 }
 
+// FetcherType records which fetcher a project's pages need, as determined
+// by probing the site. Cached so later crawls can skip the probe. The empty
+// FetcherType means the site has not been probed yet.
+type FetcherType string
+
+// FetcherType constants.
+const (
+	FetcherTypeHTTP FetcherType = "http"
+	FetcherTypeRod  FetcherType = "rod"
+)
+
 // Validate returns an error if the project contains invalid fields.
 func (p *Project) Validate() error {
 	if p.Name == "" {
@@ -24,9 +54,22 @@ func (p *Project) Validate() error {
 	if p.SourceURL == "" {
 		return Errorf(EINVALID, "project source URL required")
 	}
+	if p.CrawlDepth < 0 {
+		return Errorf(EINVALID, "project crawl depth must not be negative")
+	}
+	for _, tag := range p.Tags {
+		if tag == "" || strings.ContainsFunc(tag, unicode.IsSpace) {
+			return Errorf(EINVALID, "invalid project tag %q: tags must be non-empty and contain no whitespace", tag)
+		}
+	}
 	return nil
 }
 
+// HasTag reports whether the project carries the given tag.
+func (p *Project) HasTag(tag string) bool {
+	return slices.Contains(p.Tags, tag)
+}
+
 // ProjectService represents a service for managing projects.
 type ProjectService interface {
 	// CreateProject creates a new project.
@@ -50,17 +93,38 @@ type ProjectService interface {
 
 // ProjectFilter represents a filter for FindProjects.
 type ProjectFilter struct {
-	ID   *string `json:"id"`
-	Name *string `json:"name"`
+	ID        *string `json:"id"`
+	Name      *string `json:"name"`
+	SourceURL *string `json:"sourceUrl"`
+
+	// Tag restricts the results to projects carrying this tag.
+	Tag *string `json:"tag"`
 
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
+
+	// SortBy orders the results. The default lists the most recently
+	// created projects first.
+	SortBy SortOrder `json:"sortBy"`
 }
 
+// SortOrder constants for ProjectFilter.
+const (
+	SortByCreatedAt     SortOrder = "created"
+	SortByName          SortOrder = "name"
+	SortByDocumentCount SortOrder = "docs"
+)
+
 // ProjectUpdate represents fields that can be updated on a project.
 type ProjectUpdate struct {
-	Name      *string `json:"name"`
-	SourceURL *string `json:"sourceUrl"`
-	LocalPath *string `json:"localPath"`
-	Filter    *string `json:"filter"`
+	Name        *string      `json:"name"`
+	SourceURL   *string      `json:"sourceUrl"`
+	LocalPath   *string      `json:"localPath"`
+	Filter      *string      `json:"filter"`
+	FetcherType *FetcherType `json:"fetcherType"`
+	CrawlDepth  *int         `json:"crawlDepth"`
+	WebhookURL  *string      `json:"webhookUrl"`
+	Tags        *[]string    `json:"tags"`
+
+	LastCrawledAt *time.Time `json:"lastCrawledAt"`
 }
@@ -32,5 +32,6 @@ func (e *Extractor) Extract(rawHTML string) (*locdoc.ExtractResult, error) {
 	return &locdoc.ExtractResult{
 		Title:       article.Title,
 		ContentHTML: article.Content,
+		Language:    article.Language,
 	}, nil
 }
@@ -35,6 +35,22 @@ func TestExtractor_ExtractsTitle(t *testing.T) {
 	assert.Equal(t, "Page Title", result.Title)
 }
 
+func TestExtractor_ExtractsLanguage(t *testing.T) {
+	t.Parallel()
+
+	html := `<!DOCTYPE html>
+<html lang="fr">
+<head><title>Guide</title></head>
+<body><article><p>Bienvenue dans la documentation.</p></article></body>
+</html>`
+
+	ext := readability.NewExtractor()
+	result, err := ext.Extract(html)
+
+	require.NoError(t, err)
+	assert.Equal(t, "fr", result.Language)
+}
+
 func TestExtractor_RemovesNavigation(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,8 @@ package rod
 
 import (
 	"context"
+	_ "embed"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -62,18 +64,31 @@ const shadowDOMSerializer = `() => {
 	return '<!DOCTYPE html>' + serializeNode(document.documentElement);
 }`
 
+// stealthScript patches properties that headless Chrome exposes to bot
+// detection scripts (navigator.webdriver, window.chrome, plugins, languages,
+// permissions and WebGL vendor). Injected only when WithStealth is used.
+//
+//go:embed stealth.min.js
+var stealthScript string
+
 // Ensure Fetcher implements locdoc.Fetcher at compile time.
 var _ locdoc.Fetcher = (*Fetcher)(nil)
 
 // Fetcher retrieves rendered HTML from URLs using Chrome browser automation.
-// The browser is automatically recycled after processing a configurable number
-// of pages (default 75) to prevent memory accumulation.
+// Browsers are drawn from a BrowserPool (one browser by default) and each is
+// automatically recycled after processing a configurable number of pages
+// (default 75) to prevent memory accumulation.
 // Fetcher is safe for concurrent use by multiple goroutines.
 type Fetcher struct {
-	manager      *BrowserManager
+	pool         *BrowserPool
+	poolSize     int
 	fetchTimeout time.Duration
-	renderDelay  time.Duration
+	navTimeout   time.Duration
+	loadTimeout  time.Duration
+	renderDelay  atomic.Int64 // time.Duration; changed by SetRenderDelay during fetches
 	maxPages     int64
+	cookies      []*http.Cookie
+	stealth      bool
 	closed       atomic.Bool
 	closeOnce    sync.Once
 	closeErr     error
@@ -90,6 +105,23 @@ func WithFetchTimeout(d time.Duration) Option {
 	}
 }
 
+// WithNavigationTimeout sets the time limit for navigating to a URL, i.e.
+// until the server starts responding. Unreachable servers then fail without
+// waiting for the full fetch timeout. Zero means only the fetch timeout applies.
+func WithNavigationTimeout(d time.Duration) Option {
+	return func(f *Fetcher) {
+		f.navTimeout = d
+	}
+}
+
+// WithPageLoadTimeout sets the time limit for the page to finish loading
+// once navigation has succeeded. Zero means only the fetch timeout applies.
+func WithPageLoadTimeout(d time.Duration) Option {
+	return func(f *Fetcher) {
+		f.loadTimeout = d
+	}
+}
+
 // WithRecycleAfter sets the number of pages after which the browser is recycled.
 // Defaults to 75 if not specified. Chrome accumulates memory over time, and
 // recycling the browser periodically prevents unbounded memory growth.
@@ -105,7 +137,36 @@ func WithRecycleAfter(n int64) Option {
 // Defaults to 0 (no extra delay) if not specified.
 func WithRenderDelay(d time.Duration) Option {
 	return func(f *Fetcher) {
-		f.renderDelay = d
+		f.renderDelay.Store(int64(d))
+	}
+}
+
+// WithBrowserPoolSize sets the maximum number of browser instances used for
+// parallel fetching. Each fetch holds a browser exclusively, so concurrent
+// fetches beyond the pool size wait for a browser to be released.
+// Additional browsers are launched lazily as concurrency demands.
+// Defaults to DefaultBrowserPoolSize (1) if not specified.
+func WithBrowserPoolSize(n int) Option {
+	return func(f *Fetcher) {
+		f.poolSize = n
+	}
+}
+
+// WithCookies sets cookies in each page's browser context before navigating,
+// e.g. a session cookie for documentation behind SSO. Cookies without a
+// Domain are scoped to the URL being fetched.
+func WithCookies(cookies []*http.Cookie) Option {
+	return func(f *Fetcher) {
+		f.cookies = append(f.cookies, cookies...)
+	}
+}
+
+// WithStealth injects a script into each page before navigation that hides
+// common headless Chrome fingerprints such as navigator.webdriver. Useful for
+// documentation sites behind anti-bot protection. Disabled by default.
+func WithStealth() Option {
+	return func(f *Fetcher) {
+		f.stealth = true
 	}
 }
 
@@ -119,16 +180,17 @@ func NewFetcher(opts ...Option) (*Fetcher, error) {
 	f := &Fetcher{
 		fetchTimeout: DefaultFetchTimeout,
 		maxPages:     DefaultMaxPages,
+		poolSize:     DefaultBrowserPoolSize,
 	}
 	for _, opt := range opts {
 		opt(f)
 	}
 
-	manager, err := NewBrowserManager(WithMaxPages(f.maxPages))
+	pool, err := NewBrowserPool(f.poolSize, WithMaxPages(f.maxPages))
 	if err != nil {
 		return nil, err
 	}
-	f.manager = manager
+	f.pool = pool
 
 	return f, nil
 }
@@ -145,8 +207,18 @@ func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 		return "", err
 	}
 
+	// Acquire a browser from the pool (blocks while all browsers are busy)
+	manager, err := f.pool.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer f.pool.Release(manager)
+
 	// Get browser from manager (may trigger recycling if page limit reached)
-	browser := f.manager.Browser()
+	browser := manager.Browser()
+	if browser == nil {
+		return "", locdoc.Errorf(locdoc.EINVALID, "fetcher is closed")
+	}
 
 	// Use incognito context for isolation. Each fetch gets isolated cookies, cache,
 	// and localStorage, preventing cross-contamination between concurrent requests.
@@ -155,6 +227,14 @@ func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 		return "", err
 	}
 
+	// Cookies must be set per incognito context since each starts empty
+	if len(f.cookies) > 0 {
+		if err := incognito.SetCookies(cookieParams(f.cookies, url)); err != nil {
+			_ = incognito.Close()
+			return "", err
+		}
+	}
+
 	page, err := incognito.Page(proto.TargetCreateTarget{})
 	if err != nil {
 		_ = incognito.Close()
@@ -168,8 +248,20 @@ func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 	// Set context for all subsequent operations
 	page = page.Context(fetchCtx)
 
+	// Stealth patches must be registered before navigation so they run
+	// ahead of any page scripts
+	if f.stealth {
+		if _, err := page.EvalOnNewDocument(stealthScript); err != nil {
+			f.closePageAndContext(page, incognito)
+			return "", err
+		}
+	}
+
 	// Navigate to URL
-	if err := page.Navigate(url); err != nil {
+	navPage, cancelNav := withTimeout(page, f.navTimeout)
+	err = navPage.Navigate(url)
+	cancelNav()
+	if err != nil {
 		f.closePageAndContext(page, incognito)
 		return "", err
 	}
@@ -177,15 +269,18 @@ func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 	// Wait for page to load. We use WaitLoad instead of WaitStable because WaitStable
 	// requires the DOM to be unchanged for the specified duration, which never happens
 	// on React/JS-heavy sites with continuous animations or state updates.
-	if err := page.WaitLoad(); err != nil {
+	loadPage, cancelLoad := withTimeout(page, f.loadTimeout)
+	err = loadPage.WaitLoad()
+	cancelLoad()
+	if err != nil {
 		f.closePageAndContext(page, incognito)
 		return "", err
 	}
 
 	// Apply render delay for SPA frameworks that load content asynchronously.
 	// Also scroll to trigger lazy-loaded content that only appears on scroll.
-	if f.renderDelay > 0 {
-		time.Sleep(f.renderDelay)
+	if renderDelay := time.Duration(f.renderDelay.Load()); renderDelay > 0 {
+		time.Sleep(renderDelay)
 		_ = page.Mouse.Scroll(0, 500, 1)
 		time.Sleep(time.Second)
 	}
@@ -205,11 +300,44 @@ func (f *Fetcher) Fetch(ctx context.Context, url string) (string, error) {
 	_ = incognito.Close()
 
 	// Track page count for browser recycling
-	f.manager.IncrementPageCount()
+	manager.IncrementPageCount()
 
 	return html, nil
 }
 
+// withTimeout returns page bounded by an additional timeout, or page itself
+// when d is zero. The timeout nests inside the page's fetch context. Call
+// the returned function once the bounded operation is done to release the
+// timer.
+func withTimeout(page *rod.Page, d time.Duration) (*rod.Page, func()) {
+	if d <= 0 {
+		return page, func() {}
+	}
+	bounded := page.Timeout(d)
+	return bounded, func() { bounded.CancelTimeout() }
+}
+
+// cookieParams converts cookies to CDP parameters. Chrome requires either a
+// domain or a URL, so cookies without a Domain are bound to pageURL.
+func cookieParams(cookies []*http.Cookie, pageURL string) []*proto.NetworkCookieParam {
+	params := make([]*proto.NetworkCookieParam, 0, len(cookies))
+	for _, c := range cookies {
+		p := &proto.NetworkCookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Secure:   c.Secure,
+			HTTPOnly: c.HttpOnly,
+		}
+		if p.Domain == "" {
+			p.URL = pageURL
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
 // closePageAndContext closes a page and its incognito context using a fresh context.
 // When a page's context is cancelled due to timeout, page.Close() with that context
 // will also fail. This method uses a fresh context for cleanup operations.
@@ -227,19 +355,20 @@ func (f *Fetcher) closePageAndContext(page *rod.Page, incognito *rod.Browser) {
 func (f *Fetcher) Close() error {
 	f.closeOnce.Do(func() {
 		f.closed.Store(true)
-		f.closeErr = f.manager.Close()
+		f.closeErr = f.pool.Close()
 	})
 	return f.closeErr
 }
 
 // SetRenderDelay configures the additional wait time for SPA content rendering.
-// This can be called after creation to adjust the delay based on detected framework.
+// This can be called after creation to adjust the delay based on detected framework,
+// including while fetches are in progress.
 func (f *Fetcher) SetRenderDelay(d time.Duration) {
-	f.renderDelay = d
+	f.renderDelay.Store(int64(d))
 }
 
 // LauncherPID returns the process ID of the browser launcher.
 // This method exists for testing purposes to verify proper cleanup.
 func (f *Fetcher) LauncherPID() int {
-	return f.manager.LauncherPID()
+	return f.pool.launcherPID()
 }
@@ -95,6 +95,54 @@ func TestFetcher_Fetch_TimeoutTriggersOnSlowPage(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestFetcher_Fetch_NavigationTimeoutTriggersBeforeFetchTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Server that accepts the connection but never responds
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	fetcher, err := rod.NewFetcher(
+		rod.WithFetchTimeout(5*time.Second),
+		rod.WithNavigationTimeout(100*time.Millisecond),
+	)
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	start := time.Now()
+	_, err = fetcher.Fetch(context.Background(), srv.URL)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestFetcher_Fetch_CancellationAbortsNavigation(t *testing.T) {
+	t.Parallel()
+
+	// Server that accepts the connection but never responds
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	fetcher, err := rod.NewFetcher(rod.WithFetchTimeout(5 * time.Second))
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = fetcher.Fetch(ctx, srv.URL)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
 func TestFetcher_Close_Idempotent(t *testing.T) {
 	t.Parallel()
 
@@ -168,3 +216,67 @@ customElements.define('nav-menu', NavMenu);
 	markerCount := strings.Count(html, `data-shadow-content="true"`)
 	assert.Greater(t, markerCount, 2, "shadow DOM content not serialized: marker found %d times (expected >2)", markerCount)
 }
+
+func TestFetcher_Fetch_WithCookies(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<html><body>Forbidden</body></html>"))
+			return
+		}
+		_, _ = w.Write([]byte("<html><body>Private docs</body></html>"))
+	}))
+	defer srv.Close()
+
+	fetcher, err := rod.NewFetcher(rod.WithCookies([]*http.Cookie{
+		{Name: "session", Value: "secret"},
+	}))
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	html, err := fetcher.Fetch(context.Background(), srv.URL)
+
+	require.NoError(t, err)
+	assert.Contains(t, html, "Private docs")
+}
+
+func TestFetcher_Fetch_WithStealth(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><div id="result"></div><script>
+			document.getElementById('result').textContent = navigator.webdriver ? 'bot detected' : 'ok';
+		</script></body></html>`))
+	}))
+	defer srv.Close()
+
+	t.Run("without stealth headless Chrome is detected", func(t *testing.T) {
+		t.Parallel()
+
+		fetcher, err := rod.NewFetcher()
+		require.NoError(t, err)
+		defer fetcher.Close()
+
+		html, err := fetcher.Fetch(context.Background(), srv.URL)
+
+		require.NoError(t, err)
+		assert.Contains(t, html, "bot detected")
+	})
+
+	t.Run("with stealth navigator.webdriver is hidden", func(t *testing.T) {
+		t.Parallel()
+
+		fetcher, err := rod.NewFetcher(rod.WithStealth())
+		require.NoError(t, err)
+		defer fetcher.Close()
+
+		html, err := fetcher.Fetch(context.Background(), srv.URL)
+
+		require.NoError(t, err)
+		assert.Contains(t, html, ">ok<")
+	})
+}
@@ -0,0 +1,153 @@
+package rod
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/fwojciec/locdoc"
+)
+
+// DefaultBrowserPoolSize is the default number of browser instances in a pool.
+const DefaultBrowserPoolSize = 1
+
+// BrowserPool maintains up to N browser instances for parallel fetching.
+// Each instance is managed by a BrowserManager, so recycling still applies
+// per browser. Browsers are launched lazily: the first is started by
+// NewBrowserPool, additional ones only when all existing browsers are busy.
+//
+// A browser is held exclusively between Acquire and Release, which keeps the
+// number of tabs per Chrome process bounded. BrowserPool is safe for
+// concurrent use.
+type BrowserPool struct {
+	size      int
+	managers  []*BrowserManager
+	launching int // Browsers being launched, counted against size
+	idle      chan *BrowserManager
+	opts      []ManagerOption
+	mu        sync.Mutex
+	closed    bool
+}
+
+// NewBrowserPool creates a new BrowserPool that holds at most size browsers.
+// A size below 1 is treated as 1. The first browser is launched immediately
+// so that a missing Chrome installation is reported up front.
+// Close must be called when the BrowserPool is no longer needed.
+func NewBrowserPool(size int, opts ...ManagerOption) (*BrowserPool, error) {
+	if size < 1 {
+		size = 1
+	}
+
+	manager, err := NewBrowserManager(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &BrowserPool{
+		size:     size,
+		managers: []*BrowserManager{manager},
+		idle:     make(chan *BrowserManager, size),
+		opts:     opts,
+	}
+	p.idle <- manager
+
+	return p, nil
+}
+
+// Acquire returns a browser manager for exclusive use, launching a new browser
+// if all existing ones are busy and the pool has not reached its size.
+// Blocks until a browser is released or the context is canceled.
+// Callers must return the manager with Release.
+func (p *BrowserPool) Acquire(ctx context.Context) (*BrowserManager, error) {
+	// Prefer an idle browser without blocking
+	select {
+	case bm := <-p.idle:
+		return bm, nil
+	default:
+	}
+
+	bm, err := p.grow()
+	if err != nil {
+		return nil, err
+	}
+	if bm != nil {
+		return bm, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case bm := <-p.idle:
+		return bm, nil
+	}
+}
+
+// Release returns a browser manager to the pool.
+func (p *BrowserPool) Release(bm *BrowserManager) {
+	p.idle <- bm
+}
+
+// Size returns the maximum number of browsers in the pool.
+func (p *BrowserPool) Size() int {
+	return p.size
+}
+
+// Close shuts down all browsers in the pool. Close is safe to call multiple times.
+func (p *BrowserPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	var errs []error
+	for _, bm := range p.managers {
+		if err := bm.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// grow launches an additional browser if the pool has capacity.
+// Returns nil without error when the pool is already full. The browser is
+// launched without holding the lock, so other callers can still reach idle
+// browsers and Close while Chrome starts.
+func (p *BrowserPool) grow() (*BrowserManager, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, locdoc.Errorf(locdoc.EINVALID, "browser pool is closed")
+	}
+	if len(p.managers)+p.launching >= p.size {
+		p.mu.Unlock()
+		return nil, nil
+	}
+	p.launching++
+	p.mu.Unlock()
+
+	bm, err := NewBrowserManager(p.opts...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.launching--
+	if err != nil {
+		return nil, err
+	}
+	// Close ran while the browser was starting and did not see it
+	if p.closed {
+		_ = bm.Close()
+		return nil, locdoc.Errorf(locdoc.EINVALID, "browser pool is closed")
+	}
+	p.managers = append(p.managers, bm)
+	return bm, nil
+}
+
+// launcherPID returns the launcher PID of the first browser in the pool.
+func (p *BrowserPool) launcherPID() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.managers[0].LauncherPID()
+}
@@ -0,0 +1,127 @@
+//go:build integration
+
+package rod_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/fwojciec/locdoc/rod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetcher_Fetch_WithBrowserPool(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprintf(w, `<html><body><p>page %s</p></body></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	fetcher, err := rod.NewFetcher(rod.WithBrowserPoolSize(2))
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	const numFetches = 10
+	var wg sync.WaitGroup
+	results := make([]string, numFetches)
+	errs := make([]error, numFetches)
+
+	for i := 0; i < numFetches; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = fetcher.Fetch(context.Background(), fmt.Sprintf("%s/page%d", srv.URL, i))
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < numFetches; i++ {
+		require.NoError(t, errs[i], "fetch %d failed", i)
+		assert.Contains(t, results[i], fmt.Sprintf("page /page%d", i))
+	}
+}
+
+func TestFetcher_Fetch_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprintf(w, `<html><body><p>page %s</p></body></html>`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	fetcher, err := rod.NewFetcher(rod.WithBrowserPoolSize(2))
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	const numFetches = 50
+	var wg sync.WaitGroup
+	results := make([]string, numFetches)
+	errs := make([]error, numFetches)
+
+	for i := 0; i < numFetches; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = fetcher.Fetch(context.Background(), fmt.Sprintf("%s/page%d", srv.URL, i))
+		}(i)
+		// Probing adjusts the render delay while other fetches are running
+		go func() {
+			defer wg.Done()
+			fetcher.SetRenderDelay(0)
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < numFetches; i++ {
+		require.NoError(t, errs[i], "fetch %d failed", i)
+		assert.Contains(t, results[i], fmt.Sprintf("page /page%d", i))
+	}
+}
+
+func TestBrowserPool_Acquire(t *testing.T) {
+	t.Parallel()
+
+	t.Run("launches browsers lazily up to pool size", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := rod.NewBrowserPool(2)
+		require.NoError(t, err)
+		defer pool.Close()
+
+		first, err := pool.Acquire(context.Background())
+		require.NoError(t, err)
+		second, err := pool.Acquire(context.Background())
+		require.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+
+		pool.Release(first)
+		pool.Release(second)
+	})
+
+	t.Run("blocks until context is canceled when all browsers are busy", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := rod.NewBrowserPool(1)
+		require.NoError(t, err)
+		defer pool.Close()
+
+		held, err := pool.Acquire(context.Background())
+		require.NoError(t, err)
+		defer pool.Release(held)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = pool.Acquire(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
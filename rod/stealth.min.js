@@ -0,0 +1 @@
+(()=>{const d=(o,p,g)=>{try{Object.defineProperty(o,p,{get:g,configurable:!0})}catch(e){}};d(Object.getPrototypeOf(navigator),"webdriver",()=>!1);window.chrome||(window.chrome={});window.chrome.runtime||(window.chrome.runtime={});window.chrome.app||(window.chrome.app={isInstalled:!1,InstallState:{DISABLED:"disabled",INSTALLED:"installed",NOT_INSTALLED:"not_installed"},RunningState:{CANNOT_RUN:"cannot_run",READY_TO_RUN:"ready_to_run",RUNNING:"running"}});window.chrome.csi||(window.chrome.csi=()=>({}));window.chrome.loadTimes||(window.chrome.loadTimes=()=>({}));navigator.languages&&navigator.languages.length||d(Object.getPrototypeOf(navigator),"languages",()=>["en-US","en"]);navigator.plugins&&navigator.plugins.length||d(Object.getPrototypeOf(navigator),"plugins",()=>[{name:"Chrome PDF Plugin",filename:"internal-pdf-viewer",description:"Portable Document Format"},{name:"Chrome PDF Viewer",filename:"mhjfbmdgcfjbbpaeojofohoefgiehjai",description:""},{name:"Native Client",filename:"internal-nacl-plugin",description:""}]);const q=window.navigator.permissions&&window.navigator.permissions.query;q&&(window.navigator.permissions.query=p=>p&&p.name==="notifications"?Promise.resolve({state:Notification.permission}):q.call(window.navigator.permissions,p));const w=WebGLRenderingContext&&WebGLRenderingContext.prototype.getParameter;w&&(WebGLRenderingContext.prototype.getParameter=function(p){return p===37445?"Intel Inc.":p===37446?"Intel Iris OpenGL Engine":w.call(this,p)})})();
@@ -0,0 +1,9 @@
+package locdoc
+
+// ContentScorer rates how much useful documentation content a page contains.
+type ContentScorer interface {
+	// Score returns a quality score between 0.0 (pure boilerplate, such as
+	// navigation menus or error pages) and 1.0 (rich documentation).
+	// The input is the page content as Markdown.
+	Score(content string) float64
+}
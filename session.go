@@ -0,0 +1,83 @@
+package locdoc
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Session is a conversation with a project's documentation. Its turns are
+// passed along with each new question so follow-up questions can refer to
+// earlier answers.
+type Session struct {
+	ID        string    `json:"id"`
+	ProjectID string    `json:"projectId"`
+	Turns     []Turn    `json:"turns"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Turn is one question and its answer within a Session.
+type Turn struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// Validate returns an error if the session contains invalid fields.
+func (s *Session) Validate() error {
+	if s.ProjectID == "" {
+		return Errorf(EINVALID, "session project ID required")
+	}
+	return nil
+}
+
+// SessionService represents a service for managing ask sessions.
+type SessionService interface {
+	// CreateSession creates a new session.
+	CreateSession(ctx context.Context, session *Session) error
+
+	// FindSessionByID retrieves a session and its turns by ID.
+	// Returns ENOTFOUND if session does not exist.
+	FindSessionByID(ctx context.Context, id string) (*Session, error)
+
+	// FindSessions retrieves sessions matching the filter, most recently
+	// created first.
+	FindSessions(ctx context.Context, filter SessionFilter) ([]*Session, error)
+
+	// AppendTurn adds a turn to the end of a session.
+	// Returns ENOTFOUND if session does not exist.
+	AppendTurn(ctx context.Context, id string, turn Turn) error
+
+	// DeleteSession permanently removes a session.
+	// Returns ENOTFOUND if session does not exist.
+	DeleteSession(ctx context.Context, id string) error
+}
+
+// SessionFilter represents a filter for FindSessions.
+type SessionFilter struct {
+	ProjectID *string `json:"projectId"`
+
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// QuestionWithHistory prefixes question with the earlier turns of a
+// conversation so that an Asker can resolve references to them. Returns
+// question unchanged when there are no turns.
+func QuestionWithHistory(turns []Turn, question string) string {
+	if len(turns) == 0 {
+		return question
+	}
+
+	var b strings.Builder
+	b.WriteString("Earlier in this conversation:\n\n")
+	for _, turn := range turns {
+		b.WriteString("Question: ")
+		b.WriteString(turn.Question)
+		b.WriteString("\nAnswer: ")
+		b.WriteString(turn.Answer)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Follow-up question: ")
+	b.WriteString(question)
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package locdoc_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuestionWithHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns question unchanged without turns", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "What is X?", locdoc.QuestionWithHistory(nil, "What is X?"))
+	})
+
+	t.Run("prefixes earlier turns in order", func(t *testing.T) {
+		t.Parallel()
+
+		got := locdoc.QuestionWithHistory([]locdoc.Turn{
+			{Question: "What is X?", Answer: "X is a thing."},
+			{Question: "Is it fast?", Answer: "Yes."},
+		}, "How do I use it?")
+
+		assert.Equal(t, "Earlier in this conversation:\n\n"+
+			"Question: What is X?\nAnswer: X is a thing.\n\n"+
+			"Question: Is it fast?\nAnswer: Yes.\n\n"+
+			"Follow-up question: How do I use it?", got)
+	})
+}
@@ -2,9 +2,18 @@ package locdoc
 
 import (
 	"context"
+	"net/url"
+	"os"
 	"regexp"
+	"sort"
+	"strings"
 )
 
+// ErrSitemapNotFound is returned by SitemapService.DiscoverURLs when a site
+// has no sitemap. Callers check for it with ErrorCode(err) == ENOTFOUND to
+// tell a missing sitemap apart from a failure to reach the site.
+var ErrSitemapNotFound = Errorf(ENOTFOUND, "no sitemap found")
+
 // SitemapService discovers URLs from website sitemaps.
 type SitemapService interface {
 	// DiscoverURLs finds all URLs from a site's sitemap.
@@ -13,6 +22,9 @@ type SitemapService interface {
 	//
 	// The filter can be used to include/exclude URLs by pattern.
 	// If filter is nil, all URLs are returned.
+	//
+	// Returns ErrSitemapNotFound if the site has no sitemap. Network
+	// failures are returned as other errors.
 	DiscoverURLs(ctx context.Context, baseURL string, filter *URLFilter) ([]string, error)
 }
 
@@ -24,6 +36,14 @@ type URLFilter struct {
 	// Exclude patterns - URLs matching any pattern are excluded.
 	// Exclude is applied after Include.
 	Exclude []*regexp.Regexp
+
+	// IncludeQueryParams - if set, only URLs whose query string has every
+	// key with exactly the given value are included.
+	IncludeQueryParams map[string]string
+
+	// ExcludeQueryParams - URLs whose query string has any key with exactly
+	// the given value are excluded.
+	ExcludeQueryParams map[string]string
 }
 
 // Match returns true if the URL passes the filter.
@@ -33,6 +53,10 @@ func (f *URLFilter) Match(url string) bool {
 		return true
 	}
 
+	if !f.matchQueryParams(url) {
+		return false
+	}
+
 	// If include patterns exist, URL must match at least one
 	if len(f.Include) > 0 {
 		matched := false
@@ -56,3 +80,182 @@ func (f *URLFilter) Match(url string) bool {
 
 	return true
 }
+
+// matchQueryParams checks the URL's query string against IncludeQueryParams
+// and ExcludeQueryParams. A URL that cannot be parsed has no query parameters.
+func (f *URLFilter) matchQueryParams(rawURL string) bool {
+	if len(f.IncludeQueryParams) == 0 && len(f.ExcludeQueryParams) == 0 {
+		return true
+	}
+
+	var query url.Values
+	if u, err := url.Parse(rawURL); err == nil {
+		query = u.Query()
+	}
+	hasParam := func(key, value string) bool {
+		for _, v := range query[key] {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	for key, value := range f.IncludeQueryParams {
+		if !hasParam(key, value) {
+			return false
+		}
+	}
+	for key, value := range f.ExcludeQueryParams {
+		if hasParam(key, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseQueryParam parses a "key=value" query parameter filter.
+func ParseQueryParam(s string) (key, value string, err error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return "", "", Errorf(EINVALID, "invalid query parameter filter %q: expected key=value", s)
+	}
+	return key, value, nil
+}
+
+// ParseURLFilter parses filter patterns in the filter file format: one regex
+// per line, where lines starting with "+" are include patterns and lines
+// starting with "-" are exclude patterns. Lines without a prefix are include
+// patterns, which keeps newline-joined pattern lists compatible. Blank lines
+// and lines starting with "#" are ignored.
+//
+// Lines of the form "+?key=value" and "-?key=value" are query parameter
+// filters. A leading "?" is never a valid regex, so they cannot be mistaken
+// for patterns.
+func ParseURLFilter(text string) (*URLFilter, error) {
+	filter := &URLFilter{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "+?") || strings.HasPrefix(line, "-?") {
+			key, value, err := ParseQueryParam(line[2:])
+			if err != nil {
+				return nil, Errorf(EINVALID, "line %d: %s", i+1, ErrorMessage(err))
+			}
+			params := &filter.IncludeQueryParams
+			if line[0] == '-' {
+				params = &filter.ExcludeQueryParams
+			}
+			if *params == nil {
+				*params = make(map[string]string)
+			}
+			(*params)[key] = value
+			continue
+		}
+
+		patterns := &filter.Include
+		switch line[0] {
+		case '+':
+			line = line[1:]
+		case '-':
+			line = line[1:]
+			patterns = &filter.Exclude
+		}
+
+		re, err := regexp.Compile(strings.TrimSpace(line))
+		if err != nil {
+			return nil, Errorf(EINVALID, "invalid filter pattern on line %d: %v", i+1, err)
+		}
+		*patterns = append(*patterns, re)
+	}
+	return filter, nil
+}
+
+// String returns the filter in the format read by ParseURLFilter.
+func (f *URLFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	var lines []string
+	for _, re := range f.Include {
+		lines = append(lines, "+"+re.String())
+	}
+	for _, re := range f.Exclude {
+		lines = append(lines, "-"+re.String())
+	}
+	for _, key := range sortedKeys(f.IncludeQueryParams) {
+		lines = append(lines, "+?"+key+"="+f.IncludeQueryParams[key])
+	}
+	for _, key := range sortedKeys(f.ExcludeQueryParams) {
+		lines = append(lines, "-?"+key+"="+f.ExcludeQueryParams[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// storedFilterHeader starts a Project.Filter written in the filter file
+// format. Filters stored without it predate the format and hold one include
+// regex per line, where a leading "-" or "#" is part of the pattern.
+const storedFilterHeader = "# locdoc filter v2"
+
+// StoredString returns the filter in the format read by
+// ParseStoredURLFilter, for saving as Project.Filter.
+func (f *URLFilter) StoredString() string {
+	text := f.String()
+	if text == "" {
+		return ""
+	}
+	return storedFilterHeader + "\n" + text
+}
+
+// ParseStoredURLFilter parses a Project.Filter. Filters written by
+// StoredString use the ParseURLFilter format; older filters are read as
+// newline-joined include patterns, as they were when stored.
+func ParseStoredURLFilter(text string) (*URLFilter, error) {
+	if strings.HasPrefix(text, storedFilterHeader+"\n") {
+		return ParseURLFilter(text)
+	}
+
+	filter := &URLFilter{}
+	for _, pattern := range strings.Split(text, "\n") {
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, Errorf(EINVALID, "invalid filter pattern %q: %v", pattern, err)
+		}
+		filter.Include = append(filter.Include, re)
+	}
+	return filter, nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// URLFilterFromFile reads a filter file. See ParseURLFilter for the format.
+func URLFilterFromFile(path string) (*URLFilter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseURLFilter(string(data))
+}
+
+// URLFilterToFile writes filter to path in the format read by URLFilterFromFile.
+func URLFilterToFile(filter *URLFilter, path string) error {
+	text := filter.String()
+	if text != "" {
+		text += "\n"
+	}
+	return os.WriteFile(path, []byte(text), 0o644)
+}
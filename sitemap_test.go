@@ -0,0 +1,205 @@
+package locdoc_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLFilterFromFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loads include and exclude patterns", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "filters.txt")
+		content := "# docs only\n+/docs/\n+/guide/\n\n-/docs/v1/\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		filter, err := locdoc.URLFilterFromFile(path)
+
+		require.NoError(t, err)
+		require.Len(t, filter.Include, 2)
+		require.Len(t, filter.Exclude, 1)
+		assert.Equal(t, "/docs/", filter.Include[0].String())
+		assert.Equal(t, "/guide/", filter.Include[1].String())
+		assert.Equal(t, "/docs/v1/", filter.Exclude[0].String())
+		assert.True(t, filter.Match("https://example.com/docs/intro"))
+		assert.False(t, filter.Match("https://example.com/docs/v1/intro"))
+	})
+
+	t.Run("treats unprefixed lines as include patterns", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := locdoc.ParseURLFilter("/docs/\n/api/")
+
+		require.NoError(t, err)
+		require.Len(t, filter.Include, 2)
+		assert.Empty(t, filter.Exclude)
+	})
+
+	t.Run("returns EINVALID for invalid pattern", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "filters.txt")
+		require.NoError(t, os.WriteFile(path, []byte("+/docs/\n-[invalid\n"), 0o644))
+
+		_, err := locdoc.URLFilterFromFile(path)
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "line 2")
+	})
+}
+
+func TestURLFilter_Match_QueryParams(t *testing.T) {
+	t.Parallel()
+
+	filter := &locdoc.URLFilter{
+		IncludeQueryParams: map[string]string{"v": "stable"},
+		ExcludeQueryParams: map[string]string{"lang": "python"},
+	}
+
+	urls := []string{
+		"https://example.com/docs/intro?v=stable",
+		"https://example.com/docs/intro?v=latest",
+		"https://example.com/docs/intro",
+		"https://example.com/docs/api?v=stable&lang=go",
+		"https://example.com/docs/api?v=stable&lang=python",
+		"https://example.com/docs/api?lang=python&v=stable&v=latest",
+	}
+
+	var matched []string
+	for _, u := range urls {
+		if filter.Match(u) {
+			matched = append(matched, u)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"https://example.com/docs/intro?v=stable",
+		"https://example.com/docs/api?v=stable&lang=go",
+	}, matched)
+}
+
+func TestParseURLFilter_QueryParams(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses query parameter lines", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := locdoc.ParseURLFilter("+/docs/\n+?v=stable\n-?lang=python")
+
+		require.NoError(t, err)
+		require.Len(t, filter.Include, 1)
+		assert.Equal(t, map[string]string{"v": "stable"}, filter.IncludeQueryParams)
+		assert.Equal(t, map[string]string{"lang": "python"}, filter.ExcludeQueryParams)
+		assert.Equal(t, "+/docs/\n+?v=stable\n-?lang=python", filter.String())
+	})
+
+	t.Run("returns EINVALID for a parameter without value separator", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := locdoc.ParseURLFilter("+/docs/\n+?stable")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+		assert.Contains(t, locdoc.ErrorMessage(err), "line 2")
+	})
+}
+
+func TestURLFilterToFile(t *testing.T) {
+	t.Parallel()
+
+	filter, err := locdoc.ParseURLFilter("+/docs/\n+/guide/\n-/docs/v1/")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "filters.txt")
+	require.NoError(t, locdoc.URLFilterToFile(filter, path))
+
+	loaded, err := locdoc.URLFilterFromFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, filter.String(), loaded.String())
+}
+
+func TestParseStoredURLFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads filters stored before the filter file format as includes", func(t *testing.T) {
+		t.Parallel()
+
+		// Written by add as strings.Join(--filter patterns, "\n")
+		filter, err := locdoc.ParseStoredURLFilter("-beta/\n/docs/\n#section")
+
+		require.NoError(t, err)
+		require.Len(t, filter.Include, 3)
+		assert.Empty(t, filter.Exclude)
+		assert.Equal(t, "-beta/", filter.Include[0].String())
+		assert.Equal(t, "/docs/", filter.Include[1].String())
+		assert.Equal(t, "#section", filter.Include[2].String())
+	})
+
+	t.Run("round-trips filters written by StoredString", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := locdoc.ParseURLFilter("+/docs/\n-/docs/v1/\n+?v=stable")
+		require.NoError(t, err)
+
+		loaded, err := locdoc.ParseStoredURLFilter(filter.StoredString())
+
+		require.NoError(t, err)
+		assert.Equal(t, filter.String(), loaded.String())
+	})
+
+	t.Run("stores an empty filter as an empty string", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, (&locdoc.URLFilter{}).StoredString())
+	})
+
+	t.Run("rejects invalid legacy pattern", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := locdoc.ParseStoredURLFilter("/docs/\n[")
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+}
+
+func FuzzParseURLFilter(f *testing.F) {
+	for _, seed := range []string{
+		"docs/",
+		"+/docs/\n-/docs/changelog",
+		"+?v=stable\n-?lang=python",
+		"# comment\n\n  +^https://example\\.com/(a|b)+$  ",
+		"(a+)+$",
+		"(?i)DOCS",
+		"[",
+		"+",
+		"-?",
+		"+?=value",
+	} {
+		f.Add(seed, "https://example.com/docs/intro?v=stable#top")
+	}
+
+	f.Fuzz(func(t *testing.T, text, rawURL string) {
+		filter, err := locdoc.ParseURLFilter(text)
+		if err != nil {
+			require.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+			return
+		}
+
+		filter.Match(rawURL)
+
+		// The file format round-trips
+		reparsed, err := locdoc.ParseURLFilter(filter.String())
+		require.NoError(t, err)
+		require.Equal(t, filter.String(), reparsed.String())
+	})
+}
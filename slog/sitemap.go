@@ -3,6 +3,7 @@ package slog
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/fwojciec/locdoc"
@@ -22,11 +23,19 @@ func NewLoggingSitemapService(next locdoc.SitemapService, logger *slog.Logger) *
 	return &LoggingSitemapService{next: next, logger: logger}
 }
 
-// DiscoverURLs delegates to the wrapped service and logs the operation.
+// DiscoverURLs logs the start of discovery, delegates to the wrapped service
+// and logs the outcome. The filter is logged as its patterns separated by
+// spaces, and is empty when no filter is set.
 func (s *LoggingSitemapService) DiscoverURLs(ctx context.Context, baseURL string, filter *locdoc.URLFilter) (urls []string, err error) {
+	patterns := strings.ReplaceAll(filter.String(), "\n", " ")
+	s.logger.Debug("sitemap discovery started",
+		"url", baseURL,
+		"filter", patterns,
+	)
 	defer func(begin time.Time) {
 		s.logger.Info("sitemap discovery",
 			"url", baseURL,
+			"filter", patterns,
 			"count", len(urls),
 			"duration", time.Since(begin),
 			"err", err,
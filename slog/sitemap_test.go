@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/fwojciec/locdoc"
@@ -40,6 +41,33 @@ func TestLoggingSitemapService_DiscoverURLs(t *testing.T) {
 		assert.Contains(t, output, "duration=")
 	})
 
+	t.Run("logs the filter before and after discovery", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		inner := &mock.SitemapService{
+			DiscoverURLsFn: func(ctx context.Context, baseURL string, filter *locdoc.URLFilter) ([]string, error) {
+				return []string{"https://example.com/docs/a"}, nil
+			},
+		}
+		filter, err := locdoc.ParseURLFilter("+/docs/\n-/blog/")
+		require.NoError(t, err)
+
+		svc := locslog.NewLoggingSitemapService(inner, logger)
+		_, err = svc.DiscoverURLs(context.Background(), "https://example.com", filter)
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "sitemap discovery started")
+		assert.Contains(t, lines[0], "url=https://example.com")
+		assert.Contains(t, lines[0], `filter="+/docs/ -/blog/"`)
+		assert.Contains(t, lines[1], `filter="+/docs/ -/blog/"`)
+		assert.Contains(t, lines[1], "count=1")
+		assert.Contains(t, lines[1], "duration=")
+	})
+
 	t.Run("logs error on failure", func(t *testing.T) {
 		t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/hex"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,11 +14,15 @@ import (
 )
 
 // Compile-time interface verification.
-var _ locdoc.DocumentService = (*DocumentService)(nil)
+var (
+	_ locdoc.DocumentService = (*DocumentService)(nil)
+	_ locdoc.DocumentTx      = (*DocumentTx)(nil)
+)
 
 // DocumentService implements locdoc.DocumentService using SQLite.
 type DocumentService struct {
 	db *DB
+	tx *sql.Tx // Set when the service is scoped to a transaction
 }
 
 // NewDocumentService creates a new DocumentService.
@@ -25,7 +30,45 @@ func NewDocumentService(db *DB) *DocumentService {
 	return &DocumentService{db: db}
 }
 
-// hashContent computes xxHash of content and returns hex string.
+// conn returns the transaction if one is active, otherwise the database.
+func (s *DocumentService) conn() querier {
+	if s.tx != nil {
+		return s.tx
+	}
+	return s.db
+}
+
+// DocumentTx implements locdoc.DocumentTx using a SQLite transaction.
+type DocumentTx struct {
+	*DocumentService
+}
+
+// BeginTx starts a transaction. Nested transactions are not supported.
+func (s *DocumentService) BeginTx(ctx context.Context) (locdoc.DocumentTx, error) {
+	if s.tx != nil {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "nested transactions are not supported")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocumentTx{DocumentService: &DocumentService{db: s.db, tx: tx}}, nil
+}
+
+// Commit commits the transaction.
+func (t *DocumentTx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback aborts the transaction.
+func (t *DocumentTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// hashContent computes xxHash of content and returns hex string. It is used
+// for documents saved without a ContentHash.
 func hashContent(content string) string {
 	h := xxhash.Sum64String(content)
 	b := make([]byte, 8)
@@ -48,28 +91,171 @@ func (s *DocumentService) CreateDocument(ctx context.Context, doc *locdoc.Docume
 
 	doc.ID = uuid.New().String()
 	doc.FetchedAt = time.Now().UTC()
-	doc.ContentHash = hashContent(doc.Content)
+	if doc.ContentHash == "" {
+		doc.ContentHash = hashContent(doc.Content)
+	}
 
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO documents (id, project_id, file_path, source_url, title, content, content_hash, position, fetched_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	_, err := s.conn().ExecContext(ctx, `
+		INSERT INTO documents (id, project_id, file_path, source_url, title, content, content_hash, position, fetched_at, readability_score, language, summary, extracted_html, embedding)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, doc.ID, doc.ProjectID, doc.FilePath, doc.SourceURL, doc.Title, doc.Content, doc.ContentHash,
-		doc.Position, doc.FetchedAt.Format(time.RFC3339))
+		doc.Position, doc.FetchedAt.Format(time.RFC3339), doc.ReadabilityScore, doc.Language, doc.Summary, doc.ExtractedHTML, formatEmbedding(doc.Embedding))
 
 	return err
 }
 
+// UpsertDocument stores doc, updating the document with the same project and
+// source URL in place if one exists, so it keeps its ID. Otherwise doc is
+// created. Earlier duplicates of the URL, which CreateDocument allows, are
+// removed so that exactly one document remains.
+func (s *DocumentService) UpsertDocument(ctx context.Context, doc *locdoc.Document) error {
+	if s.tx == nil {
+		tx, err := s.BeginTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+		if err := tx.UpsertDocument(ctx, doc); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+
+	rows, err := s.conn().QueryContext(ctx,
+		"SELECT id FROM documents WHERE project_id = ? AND source_url = ? ORDER BY rowid",
+		doc.ProjectID, doc.SourceURL)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return s.CreateDocument(ctx, doc)
+	}
+
+	doc.ID = ids[0]
+	doc.FetchedAt = time.Now().UTC()
+	if doc.ContentHash == "" {
+		doc.ContentHash = hashContent(doc.Content)
+	}
+
+	if _, err := s.conn().ExecContext(ctx, `
+		UPDATE documents
+		SET file_path = ?, title = ?, content = ?, content_hash = ?, position = ?, fetched_at = ?, readability_score = ?, language = ?, summary = ?, extracted_html = ?, embedding = ?
+		WHERE id = ?
+	`, doc.FilePath, doc.Title, doc.Content, doc.ContentHash, doc.Position,
+		doc.FetchedAt.Format(time.RFC3339), doc.ReadabilityScore, doc.Language, doc.Summary, doc.ExtractedHTML, formatEmbedding(doc.Embedding), doc.ID); err != nil {
+		return err
+	}
+
+	for _, id := range ids[1:] {
+		if _, err := s.conn().ExecContext(ctx, "DELETE FROM documents WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BulkCreateDocuments creates multiple documents in a single transaction.
+// If any document fails validation or insertion, no documents are stored.
+// When called on a DocumentTx, the documents join the existing transaction.
+func (s *DocumentService) BulkCreateDocuments(ctx context.Context, docs []*locdoc.Document) error {
+	if s.tx != nil {
+		for _, doc := range docs {
+			if err := s.CreateDocument(ctx, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tx, err := s.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.BulkCreateDocuments(ctx, docs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// maxDeleteBatch is the number of IDs bound per DELETE statement, kept under
+// SQLite's default limit of 999 host parameters.
+const maxDeleteBatch = 999
+
+// BulkDeleteDocuments removes the documents with the given IDs in a single
+// transaction, issuing one DELETE per batch of up to maxDeleteBatch IDs.
+func (s *DocumentService) BulkDeleteDocuments(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if s.tx != nil {
+		for start := 0; start < len(ids); start += maxDeleteBatch {
+			end := min(start+maxDeleteBatch, len(ids))
+			if err := s.deleteBatch(ctx, ids[start:end]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tx, err := s.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.BulkDeleteDocuments(ctx, ids); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// deleteBatch deletes documents by ID with a single statement.
+func (s *DocumentService) deleteBatch(ctx context.Context, ids []string) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	_, err := s.conn().ExecContext(ctx, "DELETE FROM documents WHERE id IN ("+placeholders+")", args...)
+	return err
+}
+
 // FindDocumentByID retrieves a document by ID.
 func (s *DocumentService) FindDocumentByID(ctx context.Context, id string) (*locdoc.Document, error) {
 	var doc locdoc.Document
 	var fetchedAt string
+	var embedding []byte
 
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, project_id, file_path, source_url, title, content, content_hash, position, fetched_at
+	err := s.conn().QueryRowContext(ctx, `
+		SELECT id, project_id, file_path, source_url, title, content, content_hash, position, fetched_at, readability_score, language, summary, extracted_html, embedding
 		FROM documents
 		WHERE id = ?
 	`, id).Scan(&doc.ID, &doc.ProjectID, &doc.FilePath, &doc.SourceURL, &doc.Title,
-		&doc.Content, &doc.ContentHash, &doc.Position, &fetchedAt)
+		&doc.Content, &doc.ContentHash, &doc.Position, &fetchedAt, &doc.ReadabilityScore, &doc.Language, &doc.Summary, &doc.ExtractedHTML, &embedding)
 
 	if err == sql.ErrNoRows {
 		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "document not found")
@@ -83,40 +269,125 @@ func (s *DocumentService) FindDocumentByID(ctx context.Context, id string) (*loc
 	if parseErr != nil {
 		return nil, parseErr
 	}
+	doc.Embedding, parseErr = parseEmbedding(embedding)
+	if parseErr != nil {
+		return nil, parseErr
+	}
 
 	return &doc, nil
 }
 
 // FindDocuments retrieves documents matching the filter.
+// When filter.Query is set, only documents matching all query terms are
+// returned, each document's Score holds its negated BM25 rank and its
+// Snippet holds an excerpt with the matched terms wrapped in <b> tags.
 func (s *DocumentService) FindDocuments(ctx context.Context, filter locdoc.DocumentFilter) ([]*locdoc.Document, error) {
 	var query strings.Builder
 	var args []any
 
-	query.WriteString("SELECT id, project_id, file_path, source_url, title, content, content_hash, position, fetched_at FROM documents WHERE 1=1")
+	// Embeddings are only needed to rank a vector search; skip reading
+	// them for every other listing
+	embeddingColumn := "NULL"
+	if filter.NearVector != nil {
+		embeddingColumn = "d.embedding"
+	}
+	query.WriteString("SELECT d.id, d.project_id, d.file_path, d.source_url, d.title, d.content, d.content_hash, d.position, d.fetched_at, d.readability_score, d.language, d.summary, d.extracted_html, " + embeddingColumn)
+
+	match := ftsQuery(filter.Query)
+	if match != "" && filter.NearVector != nil {
+		return nil, locdoc.Errorf(locdoc.EINVALID, "a text query cannot be combined with a vector search")
+	}
+	if match != "" {
+		query.WriteString(", -bm25(documents_fts), snippet(documents_fts, -1, '<b>', '</b>', '...', 20) FROM documents d JOIN documents_fts ON documents_fts.rowid = d.rowid WHERE documents_fts MATCH ?")
+		args = append(args, match)
+	} else {
+		query.WriteString(", 0, '' FROM documents d WHERE 1=1")
+	}
 
 	if filter.ID != nil {
-		query.WriteString(" AND id = ?")
+		query.WriteString(" AND d.id = ?")
 		args = append(args, *filter.ID)
 	}
 	if filter.ProjectID != nil {
-		query.WriteString(" AND project_id = ?")
+		query.WriteString(" AND d.project_id = ?")
 		args = append(args, *filter.ProjectID)
 	}
 	if filter.SourceURL != nil {
-		query.WriteString(" AND source_url = ?")
+		query.WriteString(" AND d.source_url = ?")
 		args = append(args, *filter.SourceURL)
 	}
+	if filter.ContentContains != nil {
+		query.WriteString(` AND d.content LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(*filter.ContentContains)+"%")
+	}
+	if filter.URLPrefix != nil {
+		// instr(x, p) = 1 is a case-sensitive prefix test that needs no escaping
+		if strings.HasPrefix(*filter.URLPrefix, "/") {
+			query.WriteString(" AND instr(" + urlPathSQL + ", ?) = 1")
+		} else {
+			query.WriteString(" AND instr(d.source_url, ?) = 1")
+		}
+		args = append(args, *filter.URLPrefix)
+	}
+	if filter.MinReadabilityScore != nil {
+		query.WriteString(" AND d.readability_score >= ?")
+		args = append(args, *filter.MinReadabilityScore)
+	}
+	if filter.FromPosition != nil {
+		query.WriteString(" AND d.position >= ?")
+		args = append(args, *filter.FromPosition)
+	}
+	if filter.ToPosition != nil {
+		query.WriteString(" AND d.position <= ?")
+		args = append(args, *filter.ToPosition)
+	}
+	if filter.Language != nil {
+		query.WriteString(" AND d.language = ?")
+		args = append(args, *filter.Language)
+	}
+	if len(filter.ExcludeURLs) > 0 {
+		query.WriteString(" AND d.source_url NOT IN (?" + strings.Repeat(", ?", len(filter.ExcludeURLs)-1) + ")")
+		for _, u := range filter.ExcludeURLs {
+			args = append(args, u)
+		}
+	}
+	if filter.NearVector != nil {
+		query.WriteString(" AND d.embedding IS NOT NULL")
+	}
+	if filter.AfterID != nil {
+		if filter.SortBy != locdoc.SortByPosition {
+			return nil, locdoc.Errorf(locdoc.EINVALID, "cursor pagination requires sorting by position")
+		}
+		var position, rowid int64
+		err := s.conn().QueryRowContext(ctx, "SELECT position, rowid FROM documents WHERE id = ?", *filter.AfterID).Scan(&position, &rowid)
+		if err == sql.ErrNoRows {
+			return nil, locdoc.Errorf(locdoc.EINVALID, "invalid cursor %q", *filter.AfterID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		query.WriteString(" AND (d.position, d.rowid) > (?, ?)")
+		args = append(args, position, rowid)
+	}
 
-	switch filter.SortBy {
-	case locdoc.SortByPosition:
-		query.WriteString(" ORDER BY position ASC")
+	switch {
+	case filter.SortBy == locdoc.SortByRelevance && match != "":
+		query.WriteString(" ORDER BY bm25(documents_fts) ASC")
+	case filter.SortBy == locdoc.SortByPosition:
+		query.WriteString(" ORDER BY d.position ASC, d.rowid ASC")
+	case filter.SortBy == locdoc.SortByFetchedAt:
+		query.WriteString(" ORDER BY d.fetched_at DESC, d.position ASC")
 	default:
-		query.WriteString(" ORDER BY fetched_at DESC")
+		query.WriteString(" ORDER BY d.fetched_at DESC")
 	}
 
-	appendPagination(&query, &args, filter.Limit, filter.Offset)
+	// Vector searches rank every candidate, so pagination is applied after
+	// scoring in nearestDocuments
+	if filter.NearVector == nil {
+		appendPagination(&query, &args, filter.Limit, filter.Offset)
+	}
 
-	rows, err := s.db.QueryContext(ctx, query.String(), args...)
+	rows, err := s.conn().QueryContext(ctx, query.String(), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,9 +397,10 @@ func (s *DocumentService) FindDocuments(ctx context.Context, filter locdoc.Docum
 	for rows.Next() {
 		var doc locdoc.Document
 		var fetchedAt string
+		var embedding []byte
 
 		if err := rows.Scan(&doc.ID, &doc.ProjectID, &doc.FilePath, &doc.SourceURL, &doc.Title,
-			&doc.Content, &doc.ContentHash, &doc.Position, &fetchedAt); err != nil {
+			&doc.Content, &doc.ContentHash, &doc.Position, &fetchedAt, &doc.ReadabilityScore, &doc.Language, &doc.Summary, &doc.ExtractedHTML, &embedding, &doc.Score, &doc.Snippet); err != nil {
 			return nil, err
 		}
 
@@ -137,16 +409,46 @@ func (s *DocumentService) FindDocuments(ctx context.Context, filter locdoc.Docum
 		if parseErr != nil {
 			return nil, parseErr
 		}
+		doc.Embedding, parseErr = parseEmbedding(embedding)
+		if parseErr != nil {
+			return nil, parseErr
+		}
 
 		docs = append(docs, &doc)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if filter.NearVector != nil {
+		docs = nearestDocuments(docs, filter.NearVector, filter.Offset, filter.Limit)
+	}
+	return docs, nil
+}
+
+// urlPathSQL extracts the path of d.source_url: everything from the first
+// slash after the scheme's "://".
+const urlPathSQL = `substr(substr(d.source_url, instr(d.source_url, '://') + 3), instr(substr(d.source_url, instr(d.source_url, '://') + 3), '/'))`
+
+// escapeLike escapes LIKE wildcards so a substring is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
 
-	return docs, rows.Err()
+// ftsQuery converts free text into an FTS5 query that matches documents
+// containing every term. Terms are quoted so punctuation in user input is
+// not interpreted as FTS5 query syntax. Returns "" for blank input.
+func ftsQuery(text string) string {
+	terms := strings.Fields(text)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
 }
 
 // DeleteDocument permanently removes a document.
 func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
-	result, err := s.db.ExecContext(ctx, "DELETE FROM documents WHERE id = ?", id)
+	result, err := s.conn().ExecContext(ctx, "DELETE FROM documents WHERE id = ?", id)
 	if err != nil {
 		return err
 	}
@@ -165,6 +467,74 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
 
 // DeleteDocumentsByProject removes all documents for a project.
 func (s *DocumentService) DeleteDocumentsByProject(ctx context.Context, projectID string) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM documents WHERE project_id = ?", projectID)
+	_, err := s.conn().ExecContext(ctx, "DELETE FROM documents WHERE project_id = ?", projectID)
 	return err
 }
+
+// UpdateDocumentPosition sets the position of a document.
+func (s *DocumentService) UpdateDocumentPosition(ctx context.Context, id string, position int) error {
+	result, err := s.conn().ExecContext(ctx, "UPDATE documents SET position = ? WHERE id = ?", position, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return locdoc.Errorf(locdoc.ENOTFOUND, "document not found")
+	}
+
+	return nil
+}
+
+// SwapDocumentPositions exchanges the positions of two documents in a single
+// transaction. When called on a DocumentTx, the swap joins the existing
+// transaction.
+func (s *DocumentService) SwapDocumentPositions(ctx context.Context, id1, id2 string) error {
+	if s.tx != nil {
+		doc1, err := s.FindDocumentByID(ctx, id1)
+		if err != nil {
+			return err
+		}
+		doc2, err := s.FindDocumentByID(ctx, id2)
+		if err != nil {
+			return err
+		}
+
+		if err := s.UpdateDocumentPosition(ctx, id1, doc2.Position); err != nil {
+			return err
+		}
+		return s.UpdateDocumentPosition(ctx, id2, doc1.Position)
+	}
+
+	tx, err := s.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.SwapDocumentPositions(ctx, id1, id2); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// nearestDocuments scores docs by cosine similarity to vector and returns
+// them most similar first, skipping offset documents and keeping at most
+// limit (all when limit is zero). Ties keep the query order.
+func nearestDocuments(docs []*locdoc.Document, vector []float32, offset, limit int) []*locdoc.Document {
+	for _, doc := range docs {
+		doc.Score = locdoc.CosineSimilarity(doc.Embedding, vector)
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Score > docs[j].Score })
+
+	docs = docs[min(offset, len(docs)):]
+	if limit > 0 && len(docs) > limit {
+		docs = docs[:limit]
+	}
+	return docs
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/sqlite"
@@ -48,6 +49,27 @@ func TestDocumentService_CreateDocument(t *testing.T) {
 		assert.False(t, doc.FetchedAt.IsZero(), "FetchedAt should be set")
 	})
 
+	t.Run("keeps a provided content hash", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		doc := &locdoc.Document{
+			ProjectID:   project.ID,
+			SourceURL:   "https://example.com/docs/page1",
+			Content:     "content",
+			ContentHash: "sha256:abc",
+		}
+		require.NoError(t, svc.CreateDocument(ctx, doc))
+
+		found, err := svc.FindDocumentByID(ctx, doc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "sha256:abc", found.ContentHash)
+	})
+
 	t.Run("returns error for invalid document", func(t *testing.T) {
 		t.Parallel()
 
@@ -273,12 +295,206 @@ func TestDocumentService_FindDocuments(t *testing.T) {
 		assert.Equal(t, 2, docs[1].Position)
 		assert.Equal(t, 3, docs[2].Position)
 	})
-}
 
-func TestDocumentService_DeleteDocument(t *testing.T) {
-	t.Parallel()
+	t.Run("sorts most recently fetched first when SortBy is fetched_at", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("deletes existing document", func(t *testing.T) {
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		fetchedAt := map[string]string{
+			"https://example.com/docs/old":    "2024-01-01T00:00:00Z",
+			"https://example.com/docs/newest": "2024-03-01T00:00:00Z",
+			"https://example.com/docs/middle": "2024-02-01T00:00:00Z",
+		}
+		for sourceURL, ts := range fetchedAt {
+			doc := &locdoc.Document{ProjectID: project.ID, SourceURL: sourceURL}
+			require.NoError(t, svc.CreateDocument(ctx, doc))
+			_, err := db.ExecContext(ctx, "UPDATE documents SET fetched_at = ? WHERE id = ?", ts, doc.ID)
+			require.NoError(t, err)
+		}
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			SortBy:    locdoc.SortByFetchedAt,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		assert.Equal(t, "https://example.com/docs/newest", docs[0].SourceURL)
+		assert.Equal(t, "https://example.com/docs/middle", docs[1].SourceURL)
+		assert.Equal(t, "https://example.com/docs/old", docs[2].SourceURL)
+		assert.True(t, docs[0].FetchedAt.After(docs[1].FetchedAt))
+	})
+
+	t.Run("ranks query matches by relevance when SortBy is relevance", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		contents := map[string]string{
+			"sparse": "Routing is configured once. The rest of this page covers deployment, logging, caching and monitoring in detail.",
+			"dense":  "Routing basics. Nested routing, dynamic routing and routing guards.",
+			"medium": "Routing overview. Define routing rules, then continue with forms and validation.",
+		}
+		for _, title := range []string{"sparse", "dense", "medium"} {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: "https://example.com/docs/" + title,
+				Title:     title,
+				Content:   contents[title],
+			}))
+		}
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/unrelated",
+			Title:     "unrelated",
+			Content:   "Nothing about the keyword here.",
+		}))
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			Query:     "routing",
+			SortBy:    locdoc.SortByRelevance,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		assert.Equal(t, "dense", docs[0].Title)
+		assert.Equal(t, "medium", docs[1].Title)
+		assert.Equal(t, "sparse", docs[2].Title)
+		assert.Greater(t, docs[0].Score, docs[1].Score)
+		assert.Greater(t, docs[1].Score, docs[2].Score)
+	})
+
+	t.Run("query requires all terms and tolerates punctuation", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/hooks",
+			Title:     "Hooks",
+			Content:   "Use the use-effect hook for side effects.",
+		}))
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/effects",
+			Title:     "Effects",
+			Content:   "Side effects in class components.",
+		}))
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			Query:     `use-effect "side"`,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "Hooks", docs[0].Title)
+	})
+
+	t.Run("query results include highlighted snippets", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/routing",
+			Title:     "Guide",
+			Content:   "Define the routing table before the server starts.",
+		}))
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			Query:     "routing",
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Contains(t, docs[0].Snippet, "<b>routing</b>")
+
+		docs, err = svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Empty(t, docs[0].Snippet)
+	})
+
+	t.Run("stores readability score and filters by minimum", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		for i, score := range []float32{2.5, 8, 14.25} {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID:        project.ID,
+				SourceURL:        fmt.Sprintf("https://example.com/docs/page%d", i+1),
+				Position:         i,
+				ReadabilityScore: score,
+			}))
+		}
+
+		minScore := float32(8)
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:           &project.ID,
+			MinReadabilityScore: &minScore,
+			SortBy:              locdoc.SortByPosition,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.InDelta(t, 8, docs[0].ReadabilityScore, 0.001)
+		assert.InDelta(t, 14.25, docs[1].ReadabilityScore, 0.001)
+
+		found, err := svc.FindDocumentByID(ctx, docs[1].ID)
+		require.NoError(t, err)
+		assert.InDelta(t, 14.25, found.ReadabilityScore, 0.001)
+	})
+
+	t.Run("stores language and filters by it", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		for i, lang := range []string{"en", "fr", ""} {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i+1),
+				Position:  i,
+				Language:  lang,
+			}))
+		}
+
+		fr := "fr"
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			Language:  &fr,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "https://example.com/docs/page2", docs[0].SourceURL)
+		assert.Equal(t, "fr", docs[0].Language)
+
+		found, err := svc.FindDocumentByID(ctx, docs[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "fr", found.Language)
+	})
+
+	t.Run("stores summary", func(t *testing.T) {
 		t.Parallel()
 
 		db := setupTestDB(t)
@@ -288,70 +504,917 @@ func TestDocumentService_DeleteDocument(t *testing.T) {
 
 		doc := &locdoc.Document{
 			ProjectID: project.ID,
-			SourceURL: "https://example.com/docs/page1",
+			SourceURL: "https://example.com/docs/start",
+			Summary:   "Quick start guide",
 		}
 		require.NoError(t, svc.CreateDocument(ctx, doc))
 
-		err := svc.DeleteDocument(ctx, doc.ID)
+		found, err := svc.FindDocumentByID(ctx, doc.ID)
 		require.NoError(t, err)
+		assert.Equal(t, "Quick start guide", found.Summary)
 
-		_, err = svc.FindDocumentByID(ctx, doc.ID)
-		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "Quick start guide", docs[0].Summary)
 	})
 
-	t.Run("returns ENOTFOUND when not found", func(t *testing.T) {
+	t.Run("stores extracted HTML", func(t *testing.T) {
 		t.Parallel()
 
 		db := setupTestDB(t)
+		project := createTestProject(t, db)
 		svc := sqlite.NewDocumentService(db)
 		ctx := context.Background()
 
-		err := svc.DeleteDocument(ctx, "nonexistent-id")
-		require.Error(t, err)
-		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+		doc := &locdoc.Document{
+			ProjectID:     project.ID,
+			SourceURL:     "https://example.com/docs/start",
+			ExtractedHTML: "<article><p>Extracted</p></article>",
+		}
+		require.NoError(t, svc.CreateDocument(ctx, doc))
+
+		found, err := svc.FindDocumentByID(ctx, doc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "<article><p>Extracted</p></article>", found.ExtractedHTML)
 	})
-}
 
-func TestDocumentService_DeleteDocumentsByProject(t *testing.T) {
-	t.Parallel()
+	t.Run("ranks documents by embedding similarity when NearVector is set", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("deletes all documents for a project", func(t *testing.T) {
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		embeddings := map[string][]float32{
+			"https://example.com/docs/far":     {0, 1, 0},
+			"https://example.com/docs/nearest": {1, 0.1, 0},
+			"https://example.com/docs/near":    {1, 1, 0},
+			"https://example.com/docs/none":    nil,
+		}
+		i := 0
+		for url, embedding := range embeddings {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: url,
+				Position:  i,
+				Embedding: embedding,
+			}))
+			i++
+		}
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:  &project.ID,
+			NearVector: []float32{1, 0, 0},
+			Limit:      2,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "https://example.com/docs/nearest", docs[0].SourceURL)
+		assert.Equal(t, "https://example.com/docs/near", docs[1].SourceURL)
+		assert.Greater(t, docs[0].Score, docs[1].Score)
+		assert.Equal(t, []float32{1, 0.1, 0}, docs[0].Embedding)
+
+		docs, err = svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:  &project.ID,
+			NearVector: []float32{1, 0, 0},
+			Offset:     2,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "https://example.com/docs/far", docs[0].SourceURL)
+
+		found, err := svc.FindDocumentByID(ctx, docs[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, []float32{0, 1, 0}, found.Embedding)
+	})
+
+	t.Run("loads embeddings only for NearVector searches", func(t *testing.T) {
 		t.Parallel()
 
 		db := setupTestDB(t)
+		project := createTestProject(t, db)
 		svc := sqlite.NewDocumentService(db)
 		ctx := context.Background()
 
-		// Create two projects
-		projectSvc := sqlite.NewProjectService(db)
-		p1 := &locdoc.Project{Name: "project1", SourceURL: "https://example.com/p1"}
-		p2 := &locdoc.Project{Name: "project2", SourceURL: "https://example.com/p2"}
-		require.NoError(t, projectSvc.CreateProject(ctx, p1))
-		require.NoError(t, projectSvc.CreateProject(ctx, p2))
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/intro",
+			Embedding: []float32{1, 0},
+		}))
 
-		// Create documents for each project
-		for i := 0; i < 3; i++ {
-			doc := &locdoc.Document{
-				ProjectID: p1.ID,
-				SourceURL: fmt.Sprintf("https://example.com/p1/doc%d", i+1),
-			}
-			require.NoError(t, svc.CreateDocument(ctx, doc))
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Nil(t, docs[0].Embedding)
+
+		docs, err = svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID, NearVector: []float32{1, 0}})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, []float32{1, 0}, docs[0].Embedding)
+	})
+
+	t.Run("rejects NearVector combined with a query", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+
+		_, err := svc.FindDocuments(context.Background(), locdoc.DocumentFilter{
+			ProjectID:  &project.ID,
+			Query:      "routing",
+			NearVector: []float32{1, 0},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+
+	t.Run("filters by position range", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		for i := range 20 {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i),
+				Position:  i,
+			}))
 		}
-		doc2 := &locdoc.Document{ProjectID: p2.ID, SourceURL: "https://example.com/p2/doc1"}
-		require.NoError(t, svc.CreateDocument(ctx, doc2))
 
-		// Delete documents for p1
-		err := svc.DeleteDocumentsByProject(ctx, p1.ID)
+		from, to := 5, 10
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:    &project.ID,
+			FromPosition: &from,
+			ToPosition:   &to,
+			SortBy:       locdoc.SortByPosition,
+		})
 		require.NoError(t, err)
+		require.Len(t, docs, 6)
+		for i, doc := range docs {
+			assert.Equal(t, 5+i, doc.Position)
+		}
 
-		// Verify p1 docs are gone
-		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &p1.ID})
+		docs, err = svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:    &project.ID,
+			FromPosition: &to,
+		})
 		require.NoError(t, err)
-		assert.Empty(t, docs)
+		assert.Len(t, docs, 10)
+	})
 
-		// Verify p2 doc still exists
-		docs, err = svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &p2.ID})
+	t.Run("excludes documents by source URL", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		for i, path := range []string{"intro", "changelog", "api"} {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: "https://example.com/docs/" + path,
+				Position:  i,
+			}))
+		}
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:   &project.ID,
+			ExcludeURLs: []string{"https://example.com/docs/changelog"},
+			SortBy:      locdoc.SortByPosition,
+		})
 		require.NoError(t, err)
-		assert.Len(t, docs, 1)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "https://example.com/docs/intro", docs[0].SourceURL)
+		assert.Equal(t, "https://example.com/docs/api", docs[1].SourceURL)
+	})
+
+	t.Run("filters by content substring", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		for i, content := range []string{
+			"Getting started with golang modules",
+			"Rust ownership explained",
+			"Testing in Golang",
+		} {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i+1),
+				Content:   content,
+				Position:  i,
+			}))
+		}
+
+		needle := "golang"
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID:       &project.ID,
+			ContentContains: &needle,
+			SortBy:          locdoc.SortByPosition,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "https://example.com/docs/page1", docs[0].SourceURL)
+		assert.Equal(t, "https://example.com/docs/page3", docs[1].SourceURL)
+	})
+
+	t.Run("content substring matches wildcards literally", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		for i, content := range []string{"100% coverage", "1000 tests", "snake_case", "snakeXcase"} {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i+1),
+				Content:   content,
+				Position:  i,
+			}))
+		}
+
+		for needle, want := range map[string]string{
+			"100%":   "https://example.com/docs/page1",
+			"snake_": "https://example.com/docs/page3",
+		} {
+			docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+				ProjectID:       &project.ID,
+				ContentContains: &needle,
+			})
+			require.NoError(t, err)
+			require.Len(t, docs, 1, needle)
+			assert.Equal(t, want, docs[0].SourceURL)
+		}
+	})
+
+	t.Run("filters by URL prefix", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		urls := []string{
+			"https://example.com/api/v1/users",
+			"https://example.com/api/v2/users",
+			"https://example.com/api/v2/orders",
+			"https://example.com/guide/api/v2/",
+		}
+		for i, url := range urls {
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: url,
+				Position:  i,
+			}))
+		}
+
+		for _, prefix := range []string{"/api/v2/", "https://example.com/api/v2/"} {
+			docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+				ProjectID: &project.ID,
+				URLPrefix: &prefix,
+				SortBy:    locdoc.SortByPosition,
+			})
+			require.NoError(t, err)
+			require.Len(t, docs, 2, prefix)
+			assert.Equal(t, "https://example.com/api/v2/users", docs[0].SourceURL)
+			assert.Equal(t, "https://example.com/api/v2/orders", docs[1].SourceURL)
+		}
+	})
+
+	t.Run("pages through documents with a cursor", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		var want []string
+		for i := 0; i < 100; i++ {
+			url := fmt.Sprintf("https://example.com/docs/page%d", i)
+			want = append(want, url)
+			require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: url,
+				Position:  i,
+			}))
+		}
+
+		var got []string
+		var cursor *string
+		pages := 0
+		for {
+			docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+				ProjectID: &project.ID,
+				SortBy:    locdoc.SortByPosition,
+				Limit:     15,
+				AfterID:   cursor,
+			})
+			require.NoError(t, err)
+			if len(docs) == 0 {
+				break
+			}
+			require.LessOrEqual(t, len(docs), 15)
+			for _, doc := range docs {
+				got = append(got, doc.SourceURL)
+			}
+			cursor = &docs[len(docs)-1].ID
+			pages++
+		}
+
+		assert.Equal(t, want, got)
+		assert.Equal(t, 7, pages)
+	})
+
+	t.Run("cursor breaks position ties by insertion order", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		var ids []string
+		for i := 0; i < 3; i++ {
+			doc := &locdoc.Document{
+				ProjectID: project.ID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i),
+			}
+			require.NoError(t, svc.CreateDocument(ctx, doc))
+			ids = append(ids, doc.ID)
+		}
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			SortBy:    locdoc.SortByPosition,
+			AfterID:   &ids[0],
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, ids[1], docs[0].ID)
+		assert.Equal(t, ids[2], docs[1].ID)
+	})
+
+	t.Run("rejects unknown cursor", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+
+		cursor := "missing"
+		_, err := svc.FindDocuments(context.Background(), locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			SortBy:    locdoc.SortByPosition,
+			AfterID:   &cursor,
+		})
+
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+
+	t.Run("rejects cursor without position sort", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+
+		cursor := "doc-1"
+		_, err := svc.FindDocuments(context.Background(), locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			SortBy:    locdoc.SortByFetchedAt,
+			AfterID:   &cursor,
+		})
+
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+	})
+
+	t.Run("leaves score zero without a query", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/page",
+			Content:   "routing",
+		}))
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{
+			ProjectID: &project.ID,
+			SortBy:    locdoc.SortByRelevance,
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Zero(t, docs[0].Score)
+	})
+
+	t.Run("query does not match deleted documents", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		doc := &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/page",
+			Content:   "routing",
+		}
+		require.NoError(t, svc.CreateDocument(ctx, doc))
+		require.NoError(t, svc.DeleteDocument(ctx, doc.ID))
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{Query: "routing"})
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+}
+
+func TestDocumentService_DeleteDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deletes existing document", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		doc := &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/page1",
+		}
+		require.NoError(t, svc.CreateDocument(ctx, doc))
+
+		err := svc.DeleteDocument(ctx, doc.ID)
+		require.NoError(t, err)
+
+		_, err = svc.FindDocumentByID(ctx, doc.ID)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+
+	t.Run("returns ENOTFOUND when not found", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		err := svc.DeleteDocument(ctx, "nonexistent-id")
+		require.Error(t, err)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
+
+// createPositionedDocuments creates documents at positions 0 through n-1.
+func createPositionedDocuments(t *testing.T, svc *sqlite.DocumentService, projectID string, n int) []*locdoc.Document {
+	t.Helper()
+	docs := make([]*locdoc.Document, n)
+	for i := range docs {
+		docs[i] = &locdoc.Document{
+			ProjectID: projectID,
+			SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i),
+			Position:  i,
+		}
+		require.NoError(t, svc.CreateDocument(context.Background(), docs[i]))
+	}
+	return docs
+}
+
+// documentURLs returns the source URLs of a project's documents in position order.
+func documentURLs(t *testing.T, svc *sqlite.DocumentService, projectID string) []string {
+	t.Helper()
+	found, err := svc.FindDocuments(context.Background(), locdoc.DocumentFilter{
+		ProjectID: &projectID,
+		SortBy:    locdoc.SortByPosition,
+	})
+	require.NoError(t, err)
+	urls := make([]string, len(found))
+	for i, doc := range found {
+		urls[i] = doc.SourceURL
+	}
+	return urls
+}
+
+func TestDocumentService_UpdateDocumentPosition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("moves document to new position", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		docs := createPositionedDocuments(t, svc, project.ID, 5)
+
+		err := svc.UpdateDocumentPosition(context.Background(), docs[1].ID, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"https://example.com/docs/page0",
+			"https://example.com/docs/page2",
+			"https://example.com/docs/page3",
+			"https://example.com/docs/page4",
+			"https://example.com/docs/page1",
+		}, documentURLs(t, svc, project.ID))
+	})
+
+	t.Run("returns ENOTFOUND when not found", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewDocumentService(db)
+
+		err := svc.UpdateDocumentPosition(context.Background(), "nonexistent-id", 1)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
+
+func TestDocumentService_SwapDocumentPositions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exchanges positions", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		docs := createPositionedDocuments(t, svc, project.ID, 3)
+
+		err := svc.SwapDocumentPositions(context.Background(), docs[0].ID, docs[2].ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"https://example.com/docs/page2",
+			"https://example.com/docs/page1",
+			"https://example.com/docs/page0",
+		}, documentURLs(t, svc, project.ID))
+	})
+
+	t.Run("leaves positions unchanged when a document is missing", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		docs := createPositionedDocuments(t, svc, project.ID, 2)
+
+		err := svc.SwapDocumentPositions(context.Background(), docs[0].ID, "nonexistent-id")
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+
+		assert.Equal(t, []string{
+			"https://example.com/docs/page0",
+			"https://example.com/docs/page1",
+		}, documentURLs(t, svc, project.ID))
+	})
+}
+
+func TestDocumentService_DeleteDocumentsByProject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deletes all documents for a project", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		// Create two projects
+		projectSvc := sqlite.NewProjectService(db)
+		p1 := &locdoc.Project{Name: "project1", SourceURL: "https://example.com/p1"}
+		p2 := &locdoc.Project{Name: "project2", SourceURL: "https://example.com/p2"}
+		require.NoError(t, projectSvc.CreateProject(ctx, p1))
+		require.NoError(t, projectSvc.CreateProject(ctx, p2))
+
+		// Create documents for each project
+		for i := 0; i < 3; i++ {
+			doc := &locdoc.Document{
+				ProjectID: p1.ID,
+				SourceURL: fmt.Sprintf("https://example.com/p1/doc%d", i+1),
+			}
+			require.NoError(t, svc.CreateDocument(ctx, doc))
+		}
+		doc2 := &locdoc.Document{ProjectID: p2.ID, SourceURL: "https://example.com/p2/doc1"}
+		require.NoError(t, svc.CreateDocument(ctx, doc2))
+
+		// Delete documents for p1
+		err := svc.DeleteDocumentsByProject(ctx, p1.ID)
+		require.NoError(t, err)
+
+		// Verify p1 docs are gone
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &p1.ID})
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+
+		// Verify p2 doc still exists
+		docs, err = svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &p2.ID})
+		require.NoError(t, err)
+		assert.Len(t, docs, 1)
+	})
+}
+
+func TestDocumentService_UpsertDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates a document for a new URL", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		doc := &locdoc.Document{ProjectID: project.ID, SourceURL: "https://example.com/docs/a", Content: "v1"}
+		require.NoError(t, svc.UpsertDocument(ctx, doc))
+
+		require.NotEmpty(t, doc.ID)
+		found, err := svc.FindDocumentByID(ctx, doc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", found.Content)
+	})
+
+	t.Run("updates an existing document and keeps its ID", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		original := &locdoc.Document{ProjectID: project.ID, SourceURL: "https://example.com/docs/a", Title: "Old", Content: "v1"}
+		require.NoError(t, svc.CreateDocument(ctx, original))
+		_, err := db.ExecContext(ctx, "UPDATE documents SET fetched_at = ? WHERE id = ?", "2024-01-01T00:00:00Z", original.ID)
+		require.NoError(t, err)
+
+		updated := &locdoc.Document{ProjectID: project.ID, SourceURL: "https://example.com/docs/a", Title: "New", Content: "v2"}
+		require.NoError(t, svc.UpsertDocument(ctx, updated))
+
+		assert.Equal(t, original.ID, updated.ID)
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, original.ID, docs[0].ID)
+		assert.Equal(t, "New", docs[0].Title)
+		assert.Equal(t, "v2", docs[0].Content)
+		assert.NotEqual(t, original.ContentHash, docs[0].ContentHash)
+		assert.Equal(t, updated.ContentHash, docs[0].ContentHash)
+		assert.True(t, docs[0].FetchedAt.After(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("collapses duplicate documents for the URL", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		first := &locdoc.Document{ProjectID: project.ID, SourceURL: "https://example.com/docs/a", Content: "v1"}
+		require.NoError(t, svc.CreateDocument(ctx, first))
+		require.NoError(t, svc.CreateDocument(ctx, &locdoc.Document{ProjectID: project.ID, SourceURL: "https://example.com/docs/a", Content: "v1"}))
+
+		require.NoError(t, svc.UpsertDocument(ctx, &locdoc.Document{ProjectID: project.ID, SourceURL: "https://example.com/docs/a", Content: "v2"}))
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, first.ID, docs[0].ID)
+		assert.Equal(t, "v2", docs[0].Content)
+	})
+
+	t.Run("keeps documents of other projects", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		projectA := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+		projectB := &locdoc.Project{Name: "other-project", SourceURL: "https://example.org/docs"}
+		require.NoError(t, sqlite.NewProjectService(db).CreateProject(ctx, projectB))
+
+		other := &locdoc.Document{ProjectID: projectB.ID, SourceURL: "https://example.com/docs/a", Content: "b"}
+		require.NoError(t, svc.CreateDocument(ctx, other))
+
+		doc := &locdoc.Document{ProjectID: projectA.ID, SourceURL: "https://example.com/docs/a", Content: "a"}
+		require.NoError(t, svc.UpsertDocument(ctx, doc))
+
+		assert.NotEqual(t, other.ID, doc.ID)
+		found, err := svc.FindDocumentByID(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "b", found.Content)
+	})
+}
+
+func TestDocumentService_BulkCreateDocuments(t *testing.T) {
+	t.Parallel()
+
+	newDocs := func(projectID string, n int) []*locdoc.Document {
+		docs := make([]*locdoc.Document, n)
+		for i := range docs {
+			docs[i] = &locdoc.Document{
+				ProjectID: projectID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i),
+				Content:   fmt.Sprintf("content %d", i),
+				Position:  i,
+			}
+		}
+		return docs
+	}
+
+	t.Run("creates all documents", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		err := svc.BulkCreateDocuments(ctx, newDocs(project.ID, 5))
+		require.NoError(t, err)
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Len(t, docs, 5)
+	})
+
+	t.Run("rolls back all documents when one fails validation", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		docs := newDocs(project.ID, 5)
+		docs[2].SourceURL = "" // third document is invalid
+
+		err := svc.BulkCreateDocuments(ctx, docs)
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
+
+		found, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func TestDocumentService_BulkDeleteDocuments(t *testing.T) {
+	t.Parallel()
+
+	createDocs := func(t *testing.T, svc *sqlite.DocumentService, projectID string, n int) []string {
+		t.Helper()
+		docs := make([]*locdoc.Document, n)
+		for i := range docs {
+			docs[i] = &locdoc.Document{
+				ProjectID: projectID,
+				SourceURL: fmt.Sprintf("https://example.com/docs/page%d", i),
+				Position:  i,
+			}
+		}
+		require.NoError(t, svc.BulkCreateDocuments(context.Background(), docs))
+
+		ids := make([]string, n)
+		for i, doc := range docs {
+			ids[i] = doc.ID
+		}
+		return ids
+	}
+
+	t.Run("deletes specified documents and keeps the rest", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		ids := createDocs(t, svc, project.ID, 5)
+
+		err := svc.BulkDeleteDocuments(ctx, []string{ids[0], ids[2], ids[4]})
+		require.NoError(t, err)
+
+		remaining, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, remaining, 2)
+		assert.Equal(t, ids[1], remaining[0].ID)
+		assert.Equal(t, ids[3], remaining[1].ID)
+	})
+
+	t.Run("deletes more documents than fit in one statement", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		ids := createDocs(t, svc, project.ID, 1200)
+
+		err := svc.BulkDeleteDocuments(ctx, ids[:1100])
+		require.NoError(t, err)
+
+		remaining, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Len(t, remaining, 100)
+	})
+
+	t.Run("ignores unknown IDs", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		ids := createDocs(t, svc, project.ID, 2)
+
+		err := svc.BulkDeleteDocuments(ctx, []string{"missing", ids[0]})
+		require.NoError(t, err)
+
+		remaining, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, ids[1], remaining[0].ID)
+	})
+}
+
+func TestDocumentService_BeginTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("commit persists documents", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		tx, err := svc.BeginTx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, tx.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/page1",
+		}))
+		require.NoError(t, tx.Commit())
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Len(t, docs, 1)
+	})
+
+	t.Run("rollback discards documents", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		tx, err := svc.BeginTx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, tx.CreateDocument(ctx, &locdoc.Document{
+			ProjectID: project.ID,
+			SourceURL: "https://example.com/docs/page1",
+		}))
+		require.NoError(t, tx.Rollback())
+
+		docs, err := svc.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("returns EINVALID for nested transaction", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		tx, err := svc.BeginTx(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		_, err = tx.BeginTx(ctx)
+		require.Error(t, err)
+		assert.Equal(t, locdoc.EINVALID, locdoc.ErrorCode(err))
 	})
 }
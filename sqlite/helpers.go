@@ -1,7 +1,11 @@
 package sqlite
 
 import (
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 )
@@ -16,6 +20,82 @@ func parseRFC3339(value, fieldName string) (time.Time, error) {
 	return t, nil
 }
 
+// parseNullRFC3339 parses an optional RFC3339 timestamp column.
+// Returns nil if the column is NULL.
+func parseNullRFC3339(value sql.NullString, fieldName string) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	t, err := parseRFC3339(value.String, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// formatNullRFC3339 formats an optional timestamp for storage, returning nil
+// (stored as NULL) when t is nil.
+func formatNullRFC3339(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatTags encodes tags as a JSON array for storage, so they can be
+// matched with json_each.
+func formatTags(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	b, err := json.Marshal(tags)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// parseTags decodes a JSON array of tags, returning nil when it is empty.
+func parseTags(value string) ([]string, error) {
+	var tags []string
+	if err := json.Unmarshal([]byte(value), &tags); err != nil {
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags, nil
+}
+
+// formatEmbedding encodes an embedding as little-endian float32 values for
+// storage, returning nil (stored as NULL) when there is none.
+func formatEmbedding(vector []float32) any {
+	if vector == nil {
+		return nil
+	}
+	b := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(v))
+	}
+	return b
+}
+
+// parseEmbedding decodes an embedding stored by formatEmbedding. Returns nil
+// if the column is NULL.
+func parseEmbedding(b []byte) ([]float32, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("failed to parse embedding: %d bytes is not a whole number of float32 values", len(b))
+	}
+	vector := make([]float32, len(b)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return vector, nil
+}
+
 // appendPagination appends LIMIT and OFFSET clauses to a query builder if values are > 0.
 func appendPagination(query *strings.Builder, args *[]any, limit, offset int) {
 	if limit > 0 {
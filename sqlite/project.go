@@ -34,11 +34,17 @@ func (s *ProjectService) CreateProject(ctx context.Context, project *locdoc.Proj
 	project.CreatedAt = now
 	project.UpdatedAt = now
 
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO projects (id, name, source_url, local_path, filter, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`, project.ID, project.Name, project.SourceURL, project.LocalPath, project.Filter,
-		project.CreatedAt.Format(time.RFC3339), project.UpdatedAt.Format(time.RFC3339))
+	tags, err := formatTags(project.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO projects (id, name, source_url, local_path, filter, fetcher_type, created_at, updated_at, last_crawled_at, crawl_depth, webhook_url, tags)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, project.ID, project.Name, project.SourceURL, project.LocalPath, project.Filter, project.FetcherType,
+		project.CreatedAt.Format(time.RFC3339), project.UpdatedAt.Format(time.RFC3339),
+		formatNullRFC3339(project.LastCrawledAt), project.CrawlDepth, project.WebhookURL, tags)
 
 	return err
 }
@@ -47,13 +53,15 @@ func (s *ProjectService) CreateProject(ctx context.Context, project *locdoc.Proj
 func (s *ProjectService) FindProjectByID(ctx context.Context, id string) (*locdoc.Project, error) {
 	var project locdoc.Project
 	var createdAt, updatedAt string
+	var lastCrawledAt sql.NullString
+	var tags string
 
 	err := s.db.QueryRowContext(ctx, `
-		SELECT id, name, source_url, local_path, filter, created_at, updated_at
+		SELECT id, name, source_url, local_path, filter, fetcher_type, created_at, updated_at, last_crawled_at, crawl_depth, webhook_url, tags
 		FROM projects
 		WHERE id = ?
 	`, id).Scan(&project.ID, &project.Name, &project.SourceURL, &project.LocalPath, &project.Filter,
-		&createdAt, &updatedAt)
+		&project.FetcherType, &createdAt, &updatedAt, &lastCrawledAt, &project.CrawlDepth, &project.WebhookURL, &tags)
 
 	if err == sql.ErrNoRows {
 		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "project not found")
@@ -71,6 +79,14 @@ func (s *ProjectService) FindProjectByID(ctx context.Context, id string) (*locdo
 	if parseErr != nil {
 		return nil, parseErr
 	}
+	project.LastCrawledAt, parseErr = parseNullRFC3339(lastCrawledAt, "last_crawled_at")
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	project.Tags, parseErr = parseTags(tags)
+	if parseErr != nil {
+		return nil, parseErr
+	}
 
 	return &project, nil
 }
@@ -80,7 +96,11 @@ func (s *ProjectService) FindProjects(ctx context.Context, filter locdoc.Project
 	var query strings.Builder
 	var args []any
 
-	query.WriteString("SELECT id, name, source_url, local_path, filter, created_at, updated_at FROM projects WHERE 1=1")
+	query.WriteString("SELECT id, name, source_url, local_path, filter, fetcher_type, created_at, updated_at, last_crawled_at, crawl_depth, webhook_url, tags FROM projects")
+	if filter.SortBy == locdoc.SortByDocumentCount {
+		query.WriteString(" LEFT JOIN (SELECT project_id, COUNT(*) AS doc_count FROM documents GROUP BY project_id) d ON projects.id = d.project_id")
+	}
+	query.WriteString(" WHERE 1=1")
 
 	if filter.ID != nil {
 		query.WriteString(" AND id = ?")
@@ -90,8 +110,23 @@ func (s *ProjectService) FindProjects(ctx context.Context, filter locdoc.Project
 		query.WriteString(" AND name = ?")
 		args = append(args, *filter.Name)
 	}
+	if filter.SourceURL != nil {
+		query.WriteString(" AND source_url = ?")
+		args = append(args, *filter.SourceURL)
+	}
+	if filter.Tag != nil {
+		query.WriteString(" AND EXISTS (SELECT 1 FROM json_each(projects.tags) WHERE json_each.value = ?)")
+		args = append(args, *filter.Tag)
+	}
 
-	query.WriteString(" ORDER BY created_at DESC")
+	switch filter.SortBy {
+	case locdoc.SortByName:
+		query.WriteString(" ORDER BY name ASC")
+	case locdoc.SortByDocumentCount:
+		query.WriteString(" ORDER BY COALESCE(doc_count, 0) DESC, name ASC")
+	default:
+		query.WriteString(" ORDER BY created_at DESC")
+	}
 
 	appendPagination(&query, &args, filter.Limit, filter.Offset)
 
@@ -105,9 +140,11 @@ func (s *ProjectService) FindProjects(ctx context.Context, filter locdoc.Project
 	for rows.Next() {
 		var project locdoc.Project
 		var createdAt, updatedAt string
+		var lastCrawledAt sql.NullString
+		var tags string
 
 		if err := rows.Scan(&project.ID, &project.Name, &project.SourceURL, &project.LocalPath, &project.Filter,
-			&createdAt, &updatedAt); err != nil {
+			&project.FetcherType, &createdAt, &updatedAt, &lastCrawledAt, &project.CrawlDepth, &project.WebhookURL, &tags); err != nil {
 			return nil, err
 		}
 
@@ -120,6 +157,14 @@ func (s *ProjectService) FindProjects(ctx context.Context, filter locdoc.Project
 		if parseErr != nil {
 			return nil, parseErr
 		}
+		project.LastCrawledAt, parseErr = parseNullRFC3339(lastCrawledAt, "last_crawled_at")
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		project.Tags, parseErr = parseTags(tags)
+		if parseErr != nil {
+			return nil, parseErr
+		}
 
 		projects = append(projects, &project)
 	}
@@ -148,6 +193,22 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id string, upd locdo
 	if upd.Filter != nil {
 		project.Filter = *upd.Filter
 	}
+	if upd.FetcherType != nil {
+		project.FetcherType = *upd.FetcherType
+	}
+	if upd.CrawlDepth != nil {
+		project.CrawlDepth = *upd.CrawlDepth
+	}
+	if upd.WebhookURL != nil {
+		project.WebhookURL = *upd.WebhookURL
+	}
+	if upd.Tags != nil {
+		project.Tags = *upd.Tags
+	}
+	if upd.LastCrawledAt != nil {
+		t := upd.LastCrawledAt.UTC().Truncate(time.Second)
+		project.LastCrawledAt = &t
+	}
 
 	// Validate before persisting
 	if err := project.Validate(); err != nil {
@@ -156,12 +217,17 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id string, upd locdo
 
 	project.UpdatedAt = time.Now().UTC()
 
+	tags, err := formatTags(project.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = s.db.ExecContext(ctx, `
 		UPDATE projects
-		SET name = ?, source_url = ?, local_path = ?, filter = ?, updated_at = ?
+		SET name = ?, source_url = ?, local_path = ?, filter = ?, fetcher_type = ?, updated_at = ?, last_crawled_at = ?, crawl_depth = ?, webhook_url = ?, tags = ?
 		WHERE id = ?
-	`, project.Name, project.SourceURL, project.LocalPath, project.Filter,
-		project.UpdatedAt.Format(time.RFC3339), id)
+	`, project.Name, project.SourceURL, project.LocalPath, project.Filter, project.FetcherType,
+		project.UpdatedAt.Format(time.RFC3339), formatNullRFC3339(project.LastCrawledAt), project.CrawlDepth, project.WebhookURL, tags, id)
 
 	if err != nil {
 		return nil, err
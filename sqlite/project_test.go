@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/sqlite"
@@ -64,6 +65,73 @@ func TestProjectService_CreateProject(t *testing.T) {
 		assert.Equal(t, "/api/**", found.Filter)
 	})
 
+	t.Run("persists crawl depth", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		project := &locdoc.Project{
+			Name:       "test-project",
+			SourceURL:  "https://example.com/docs",
+			CrawlDepth: 2,
+		}
+		require.NoError(t, svc.CreateProject(ctx, project))
+
+		found, err := svc.FindProjectByID(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.CrawlDepth)
+
+		depth := 5
+		updated, err := svc.UpdateProject(ctx, project.ID, locdoc.ProjectUpdate{CrawlDepth: &depth})
+		require.NoError(t, err)
+		assert.Equal(t, 5, updated.CrawlDepth)
+	})
+
+	t.Run("persists webhook URL", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		project := &locdoc.Project{
+			Name:       "test-project",
+			SourceURL:  "https://example.com/docs",
+			WebhookURL: "https://hooks.example.com/crawl",
+		}
+		require.NoError(t, svc.CreateProject(ctx, project))
+
+		found, err := svc.FindProjectByID(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://hooks.example.com/crawl", found.WebhookURL)
+
+		webhook := ""
+		updated, err := svc.UpdateProject(ctx, project.ID, locdoc.ProjectUpdate{WebhookURL: &webhook})
+		require.NoError(t, err)
+		assert.Empty(t, updated.WebhookURL)
+	})
+
+	t.Run("persists tags", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		project := &locdoc.Project{
+			Name:      "test-project",
+			SourceURL: "https://example.com/docs",
+			Tags:      []string{"frontend", "react"},
+		}
+		require.NoError(t, svc.CreateProject(ctx, project))
+
+		found, err := svc.FindProjectByID(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frontend", "react"}, found.Tags)
+	})
+
 	t.Run("defaults filter to empty string", func(t *testing.T) {
 		t.Parallel()
 
@@ -183,6 +251,94 @@ func TestProjectService_FindProjects(t *testing.T) {
 		assert.Equal(t, "alpha", projects[0].Name)
 	})
 
+	t.Run("filters by source URL", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "a", SourceURL: "https://a.example.com"}))
+		require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "b", SourceURL: "https://b.example.com"}))
+
+		sourceURL := "https://b.example.com"
+		projects, err := svc.FindProjects(ctx, locdoc.ProjectFilter{SourceURL: &sourceURL})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		assert.Equal(t, "b", projects[0].Name)
+	})
+
+	t.Run("filters by tag", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "a", SourceURL: "https://a.example.com", Tags: []string{"go", "backend"}}))
+		require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "b", SourceURL: "https://b.example.com", Tags: []string{"frontend"}}))
+		require.NoError(t, svc.CreateProject(ctx, &locdoc.Project{Name: "c", SourceURL: "https://c.example.com"}))
+
+		tag := "backend"
+		projects, err := svc.FindProjects(ctx, locdoc.ProjectFilter{Tag: &tag})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		assert.Equal(t, "a", projects[0].Name)
+
+		// Tags match whole values, not substrings
+		tag = "back"
+		projects, err = svc.FindProjects(ctx, locdoc.ProjectFilter{Tag: &tag})
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+
+	t.Run("sorts by SortBy", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		docs := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		// Created oldest first, with doc counts that follow neither order
+		projects := []struct {
+			name      string
+			createdAt string
+			docs      int
+		}{
+			{"charlie", "2024-01-01T00:00:00Z", 2},
+			{"alpha", "2024-02-01T00:00:00Z", 0},
+			{"bravo", "2024-03-01T00:00:00Z", 3},
+		}
+		for _, p := range projects {
+			project := &locdoc.Project{Name: p.name, SourceURL: "https://example.com/" + p.name}
+			require.NoError(t, svc.CreateProject(ctx, project))
+			_, err := db.ExecContext(ctx, "UPDATE projects SET created_at = ? WHERE id = ?", p.createdAt, project.ID)
+			require.NoError(t, err)
+			for i := 0; i < p.docs; i++ {
+				require.NoError(t, docs.CreateDocument(ctx, &locdoc.Document{
+					ProjectID: project.ID,
+					SourceURL: fmt.Sprintf("https://example.com/%s/%d", p.name, i),
+				}))
+			}
+		}
+
+		names := func(sortBy locdoc.SortOrder) []string {
+			t.Helper()
+			found, err := svc.FindProjects(ctx, locdoc.ProjectFilter{SortBy: sortBy})
+			require.NoError(t, err)
+			var names []string
+			for _, p := range found {
+				names = append(names, p.Name)
+			}
+			return names
+		}
+
+		assert.Equal(t, []string{"bravo", "alpha", "charlie"}, names(locdoc.SortByCreatedAt))
+		assert.Equal(t, []string{"alpha", "bravo", "charlie"}, names(locdoc.SortByName))
+		assert.Equal(t, []string{"bravo", "charlie", "alpha"}, names(locdoc.SortByDocumentCount))
+	})
+
 	t.Run("respects limit and offset", func(t *testing.T) {
 		t.Parallel()
 
@@ -237,6 +393,81 @@ func TestProjectService_UpdateProject(t *testing.T) {
 		assert.True(t, updated.UpdatedAt.After(originalUpdatedAt) || updated.UpdatedAt.Equal(originalUpdatedAt))
 	})
 
+	t.Run("persists fetcher type", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		project := &locdoc.Project{
+			Name:      "docs",
+			SourceURL: "https://example.com/docs",
+		}
+		require.NoError(t, svc.CreateProject(ctx, project))
+
+		fetcherType := locdoc.FetcherTypeHTTP
+		_, err := svc.UpdateProject(ctx, project.ID, locdoc.ProjectUpdate{FetcherType: &fetcherType})
+		require.NoError(t, err)
+
+		found, err := svc.FindProjectByID(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, locdoc.FetcherTypeHTTP, found.FetcherType)
+	})
+
+	t.Run("persists last crawled time", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		project := &locdoc.Project{
+			Name:      "docs",
+			SourceURL: "https://example.com/docs",
+		}
+		require.NoError(t, svc.CreateProject(ctx, project))
+
+		found, err := svc.FindProjectByID(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found.LastCrawledAt, "new project has never been crawled")
+
+		crawledAt := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+		_, err = svc.UpdateProject(ctx, project.ID, locdoc.ProjectUpdate{LastCrawledAt: &crawledAt})
+		require.NoError(t, err)
+
+		projects, err := svc.FindProjects(ctx, locdoc.ProjectFilter{ID: &project.ID})
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		require.NotNil(t, projects[0].LastCrawledAt)
+		assert.True(t, crawledAt.Equal(*projects[0].LastCrawledAt))
+	})
+
+	t.Run("replaces tags", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		ctx := context.Background()
+
+		project := &locdoc.Project{
+			Name:      "test-project",
+			SourceURL: "https://example.com/docs",
+			Tags:      []string{"go", "stale"},
+		}
+		require.NoError(t, svc.CreateProject(ctx, project))
+
+		tags := []string{"go"}
+		updated, err := svc.UpdateProject(ctx, project.ID, locdoc.ProjectUpdate{Tags: &tags})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go"}, updated.Tags)
+
+		stale := "stale"
+		projects, err := svc.FindProjects(ctx, locdoc.ProjectFilter{Tag: &stale})
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+
 	t.Run("returns ENOTFOUND when not found", func(t *testing.T) {
 		t.Parallel()
 
@@ -303,6 +534,39 @@ func TestProjectService_DeleteProject(t *testing.T) {
 		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
 	})
 
+	t.Run("cascades to the project's documents", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewProjectService(db)
+		docs := sqlite.NewDocumentService(db)
+		ctx := context.Background()
+
+		project := createTestProject(t, db)
+		other := createTestProject(t, db)
+		for _, p := range []*locdoc.Project{project, project, other} {
+			require.NoError(t, docs.CreateDocument(ctx, &locdoc.Document{
+				ProjectID: p.ID,
+				SourceURL: "https://example.com/docs/page",
+				Content:   "routing",
+			}))
+		}
+
+		require.NoError(t, svc.DeleteProject(ctx, project.ID))
+
+		remaining, err := docs.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		remaining, err = docs.FindDocuments(ctx, locdoc.DocumentFilter{ProjectID: &other.ID})
+		require.NoError(t, err)
+		assert.Len(t, remaining, 1)
+
+		matches, err := docs.FindDocuments(ctx, locdoc.DocumentFilter{Query: "routing"})
+		require.NoError(t, err)
+		assert.Len(t, matches, 1, "search index should drop cascaded documents")
+	})
+
 	t.Run("returns ENOTFOUND when not found", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,218 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/google/uuid"
+)
+
+// Compile-time interface verification.
+var _ locdoc.SessionService = (*SessionService)(nil)
+
+// SessionService implements locdoc.SessionService using SQLite.
+type SessionService struct {
+	db *DB
+}
+
+// NewSessionService creates a new SessionService.
+func NewSessionService(db *DB) *SessionService {
+	return &SessionService{db: db}
+}
+
+// CreateSession creates a new session. Any turns already on the session are
+// stored along with it.
+func (s *SessionService) CreateSession(ctx context.Context, session *locdoc.Session) error {
+	if err := session.Validate(); err != nil {
+		return err
+	}
+
+	session.ID = uuid.New().String()
+	session.CreatedAt = time.Now().UTC()
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO sessions (id, project_id, created_at)
+		VALUES (?, ?, ?)
+	`, session.ID, session.ProjectID, session.CreatedAt.Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	for i, turn := range session.Turns {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO session_turns (session_id, position, question, answer)
+			VALUES (?, ?, ?, ?)
+		`, session.ID, i, turn.Question, turn.Answer); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindSessionByID retrieves a session and its turns by ID.
+func (s *SessionService) FindSessionByID(ctx context.Context, id string) (*locdoc.Session, error) {
+	var session locdoc.Session
+	var createdAt string
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, project_id, created_at
+		FROM sessions
+		WHERE id = ?
+	`, id).Scan(&session.ID, &session.ProjectID, &createdAt)
+
+	if err == sql.ErrNoRows {
+		return nil, locdoc.Errorf(locdoc.ENOTFOUND, "session not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session.CreatedAt, err = parseRFC3339(createdAt, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	session.Turns, err = s.findTurns(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// FindSessions retrieves sessions matching the filter, most recently created
+// first. Turns are loaded for each returned session.
+func (s *SessionService) FindSessions(ctx context.Context, filter locdoc.SessionFilter) ([]*locdoc.Session, error) {
+	var query strings.Builder
+	var args []any
+
+	query.WriteString("SELECT id, project_id, created_at FROM sessions WHERE 1=1")
+
+	if filter.ProjectID != nil {
+		query.WriteString(" AND project_id = ?")
+		args = append(args, *filter.ProjectID)
+	}
+
+	query.WriteString(" ORDER BY created_at DESC, rowid DESC")
+	appendPagination(&query, &args, filter.Limit, filter.Offset)
+
+	rows, err := s.db.QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*locdoc.Session
+	for rows.Next() {
+		var session locdoc.Session
+		var createdAt string
+
+		if err := rows.Scan(&session.ID, &session.ProjectID, &createdAt); err != nil {
+			return nil, err
+		}
+
+		session.CreatedAt, err = parseRFC3339(createdAt, "created_at")
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Turns, err = s.findTurns(ctx, session.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
+
+// AppendTurn adds a turn to the end of a session.
+func (s *SessionService) AppendTurn(ctx context.Context, id string, turn locdoc.Turn) error {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var next int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE((SELECT MAX(position) + 1 FROM session_turns WHERE session_id = sessions.id), 0)
+		FROM sessions
+		WHERE id = ?
+	`, id).Scan(&next)
+
+	if err == sql.ErrNoRows {
+		return locdoc.Errorf(locdoc.ENOTFOUND, "session not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO session_turns (session_id, position, question, answer)
+		VALUES (?, ?, ?, ?)
+	`, id, next, turn.Question, turn.Answer); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteSession permanently removes a session and its turns.
+func (s *SessionService) DeleteSession(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return locdoc.Errorf(locdoc.ENOTFOUND, "session not found")
+	}
+
+	return nil
+}
+
+// findTurns returns the turns of a session in the order they were added.
+func (s *SessionService) findTurns(ctx context.Context, sessionID string) ([]locdoc.Turn, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT question, answer
+		FROM session_turns
+		WHERE session_id = ?
+		ORDER BY position ASC
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var turns []locdoc.Turn
+	for rows.Next() {
+		var turn locdoc.Turn
+		if err := rows.Scan(&turn.Question, &turn.Answer); err != nil {
+			return nil, err
+		}
+		turns = append(turns, turn)
+	}
+
+	return turns, rows.Err()
+}
@@ -0,0 +1,113 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionService_AppendTurn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores turns in order", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewSessionService(db)
+		ctx := context.Background()
+
+		session := &locdoc.Session{ProjectID: project.ID}
+		require.NoError(t, svc.CreateSession(ctx, session))
+		assert.NotEmpty(t, session.ID, "ID should be generated")
+		assert.False(t, session.CreatedAt.IsZero(), "CreatedAt should be set")
+
+		require.NoError(t, svc.AppendTurn(ctx, session.ID, locdoc.Turn{Question: "What is X?", Answer: "X is a thing."}))
+		require.NoError(t, svc.AppendTurn(ctx, session.ID, locdoc.Turn{Question: "How do I use it?", Answer: "Call X()."}))
+
+		found, err := svc.FindSessionByID(ctx, session.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, project.ID, found.ProjectID)
+		assert.Equal(t, []locdoc.Turn{
+			{Question: "What is X?", Answer: "X is a thing."},
+			{Question: "How do I use it?", Answer: "Call X()."},
+		}, found.Turns)
+	})
+
+	t.Run("returns ENOTFOUND for missing session", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		svc := sqlite.NewSessionService(db)
+
+		err := svc.AppendTurn(context.Background(), "missing", locdoc.Turn{Question: "q", Answer: "a"})
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
+
+func TestSessionService_FindSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("filters by project", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		other := &locdoc.Project{Name: "other", SourceURL: "https://other.com"}
+		require.NoError(t, sqlite.NewProjectService(db).CreateProject(context.Background(), other))
+		svc := sqlite.NewSessionService(db)
+		ctx := context.Background()
+
+		mine := &locdoc.Session{ProjectID: project.ID, Turns: []locdoc.Turn{{Question: "q", Answer: "a"}}}
+		require.NoError(t, svc.CreateSession(ctx, mine))
+		require.NoError(t, svc.CreateSession(ctx, &locdoc.Session{ProjectID: other.ID}))
+
+		sessions, err := svc.FindSessions(ctx, locdoc.SessionFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+
+		require.Len(t, sessions, 1)
+		assert.Equal(t, mine.ID, sessions[0].ID)
+		assert.Len(t, sessions[0].Turns, 1)
+	})
+}
+
+func TestSessionService_DeleteSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes session", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewSessionService(db)
+		ctx := context.Background()
+
+		session := &locdoc.Session{ProjectID: project.ID}
+		require.NoError(t, svc.CreateSession(ctx, session))
+		require.NoError(t, svc.DeleteSession(ctx, session.ID))
+
+		_, err := svc.FindSessionByID(ctx, session.ID)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+
+	t.Run("is removed with its project", func(t *testing.T) {
+		t.Parallel()
+
+		db := setupTestDB(t)
+		project := createTestProject(t, db)
+		svc := sqlite.NewSessionService(db)
+		ctx := context.Background()
+
+		session := &locdoc.Session{ProjectID: project.ID}
+		require.NoError(t, svc.CreateSession(ctx, session))
+		require.NoError(t, sqlite.NewProjectService(db).DeleteProject(ctx, project.ID))
+
+		_, err := svc.FindSessionByID(ctx, session.ID)
+		assert.Equal(t, locdoc.ENOTFOUND, locdoc.ErrorCode(err))
+	})
+}
@@ -10,6 +10,14 @@ import (
 	_ "github.com/ncruces/go-sqlite3/embed"
 )
 
+// querier is the subset of *sql.DB and *sql.Tx used by services, allowing
+// the same queries to run inside or outside a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // DB represents a SQLite database connection.
 type DB struct {
 	db   *sql.DB
@@ -96,6 +104,11 @@ func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.R
 	return db.db.ExecContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction.
+func (db *DB) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return db.db.BeginTx(ctx, nil)
+}
+
 // Stats returns database statistics.
 func (db *DB) Stats() sql.DBStats {
 	return db.db.Stats()
@@ -110,11 +123,96 @@ func (db *DB) createSchema() error {
 			source_url TEXT NOT NULL,
 			local_path TEXT NOT NULL DEFAULT '',
 			filter TEXT NOT NULL DEFAULT '',
+			fetcher_type TEXT NOT NULL DEFAULT '',
 			created_at TEXT NOT NULL,
-			updated_at TEXT NOT NULL
+			updated_at TEXT NOT NULL,
+			last_crawled_at TEXT,
+			crawl_depth INTEGER NOT NULL DEFAULT 0,
+			webhook_url TEXT NOT NULL DEFAULT '',
+			tags TEXT NOT NULL DEFAULT '[]'
 		);
 
-		CREATE TABLE IF NOT EXISTS documents (
+		` + documentsTable("documents") + `
+
+		CREATE INDEX IF NOT EXISTS idx_documents_project_id ON documents(project_id);
+		CREATE INDEX IF NOT EXISTS idx_documents_source_url ON documents(source_url);
+
+		CREATE TABLE IF NOT EXISTS sessions (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+			created_at TEXT NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS session_turns (
+			session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			question TEXT NOT NULL,
+			answer TEXT NOT NULL,
+			PRIMARY KEY (session_id, position)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_sessions_project_id ON sessions(project_id);
+	`
+
+	if _, err := db.db.Exec(schema); err != nil {
+		return err
+	}
+
+	if err := db.migrate(); err != nil {
+		return err
+	}
+
+	return db.createSearchIndex()
+}
+
+// createSearchIndex creates the FTS5 index over document titles and content.
+// The index is an external-content table kept in sync by triggers. When it is
+// created for an existing database, it is rebuilt from the documents table.
+func (db *DB) createSearchIndex() error {
+	var exists bool
+	if err := db.db.QueryRow(
+		"SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'documents_fts'",
+	).Scan(&exists); err != nil {
+		return err
+	}
+
+	schema := `
+		CREATE VIRTUAL TABLE IF NOT EXISTS documents_fts USING fts5(
+			title, content, content='documents', content_rowid='rowid'
+		);
+
+		CREATE TRIGGER IF NOT EXISTS documents_fts_insert AFTER INSERT ON documents BEGIN
+			INSERT INTO documents_fts(rowid, title, content) VALUES (new.rowid, new.title, new.content);
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS documents_fts_delete AFTER DELETE ON documents BEGIN
+			INSERT INTO documents_fts(documents_fts, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS documents_fts_update AFTER UPDATE ON documents BEGIN
+			INSERT INTO documents_fts(documents_fts, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+			INSERT INTO documents_fts(rowid, title, content) VALUES (new.rowid, new.title, new.content);
+		END;
+	`
+
+	if _, err := db.db.Exec(schema); err != nil {
+		return err
+	}
+
+	if !exists {
+		if _, err := db.db.Exec("INSERT INTO documents_fts(documents_fts) VALUES ('rebuild')"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// documentsTable returns the DDL for the documents table under the given name.
+// Documents are removed together with their project via ON DELETE CASCADE,
+// which SQLite enforces because Open enables foreign_keys.
+func documentsTable(name string) string {
+	return `CREATE TABLE IF NOT EXISTS ` + name + ` (
 			id TEXT PRIMARY KEY,
 			project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
 			file_path TEXT NOT NULL DEFAULT '',
@@ -123,13 +221,106 @@ func (db *DB) createSchema() error {
 			content TEXT NOT NULL DEFAULT '',
 			content_hash TEXT NOT NULL DEFAULT '',
 			position INTEGER NOT NULL DEFAULT 0,
-			fetched_at TEXT NOT NULL
-		);
+			fetched_at TEXT NOT NULL,
+			readability_score REAL NOT NULL DEFAULT 0,
+			language TEXT NOT NULL DEFAULT '',
+			summary TEXT NOT NULL DEFAULT '',
+			extracted_html TEXT NOT NULL DEFAULT '',
+			embedding BLOB
+		);`
+}
 
-		CREATE INDEX IF NOT EXISTS idx_documents_project_id ON documents(project_id);
-		CREATE INDEX IF NOT EXISTS idx_documents_source_url ON documents(source_url);
-	`
+// migrate adds columns introduced after a table was first created.
+// CREATE TABLE IF NOT EXISTS leaves existing tables untouched, so databases
+// created by older versions need these columns added explicitly.
+func (db *DB) migrate() error {
+	columns := []struct {
+		table, name, definition string
+	}{
+		{"projects", "fetcher_type", "TEXT NOT NULL DEFAULT ''"},
+		{"projects", "last_crawled_at", "TEXT"},
+		{"projects", "crawl_depth", "INTEGER NOT NULL DEFAULT 0"},
+		{"projects", "webhook_url", "TEXT NOT NULL DEFAULT ''"},
+		{"projects", "tags", "TEXT NOT NULL DEFAULT '[]'"},
+		{"documents", "readability_score", "REAL NOT NULL DEFAULT 0"},
+		{"documents", "language", "TEXT NOT NULL DEFAULT ''"},
+		{"documents", "summary", "TEXT NOT NULL DEFAULT ''"},
+		{"documents", "extracted_html", "TEXT NOT NULL DEFAULT ''"},
+		{"documents", "embedding", "BLOB"},
+	}
+
+	for _, col := range columns {
+		var exists bool
+		err := db.db.QueryRow(
+			"SELECT COUNT(*) > 0 FROM pragma_table_info(?) WHERE name = ?",
+			col.table, col.name,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s",
+			col.table, col.name, col.definition)); err != nil {
+			return err
+		}
+	}
+
+	return db.migrateDocumentsForeignKey()
+}
+
+// migrateDocumentsForeignKey recreates the documents table for databases
+// created without the project_id foreign key. SQLite cannot add a constraint
+// to an existing table, so rows are copied into a new table. Orphaned
+// documents whose project no longer exists are dropped in the process.
+// Rowids are preserved so the search index stays aligned.
+func (db *DB) migrateDocumentsForeignKey() error {
+	var hasFK bool
+	if err := db.db.QueryRow(
+		"SELECT COUNT(*) > 0 FROM pragma_foreign_key_list('documents') WHERE \"table\" = 'projects'",
+	).Scan(&hasFK); err != nil {
+		return err
+	}
+	if hasFK {
+		return nil
+	}
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const columns = "id, project_id, file_path, source_url, title, content, content_hash, position, fetched_at, readability_score, language, summary, extracted_html, embedding"
+	statements := []string{
+		documentsTable("documents_new"),
+		"INSERT INTO documents_new (rowid, " + columns + ") SELECT rowid, " + columns +
+			" FROM documents WHERE project_id IN (SELECT id FROM projects)",
+		"DROP TABLE documents",
+		"ALTER TABLE documents_new RENAME TO documents",
+		"CREATE INDEX idx_documents_project_id ON documents(project_id)",
+		"CREATE INDEX idx_documents_source_url ON documents(source_url)",
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("recreate documents table: %w", err)
+		}
+	}
+
+	// Dropping the table also dropped the search index triggers and left
+	// entries for orphaned rows; rebuild if the index already exists.
+	var hasIndex bool
+	if err := tx.QueryRow(
+		"SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'documents_fts'",
+	).Scan(&hasIndex); err != nil {
+		return err
+	}
+	if hasIndex {
+		if _, err := tx.Exec("INSERT INTO documents_fts(documents_fts) VALUES ('rebuild')"); err != nil {
+			return err
+		}
+	}
 
-	_, err := db.db.Exec(schema)
-	return err
+	return tx.Commit()
 }
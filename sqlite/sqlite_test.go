@@ -2,8 +2,11 @@ package sqlite_test
 
 import (
 	"context"
+	"database/sql"
+	"path/filepath"
 	"testing"
 
+	"github.com/fwojciec/locdoc"
 	"github.com/fwojciec/locdoc/sqlite"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +36,100 @@ func TestDB_Open(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("adds missing columns to existing tables", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "old.db")
+
+		// Simulate a database created before fetcher_type existed
+		conn, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		_, err = conn.Exec(`CREATE TABLE projects (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			source_url TEXT NOT NULL,
+			local_path TEXT NOT NULL DEFAULT '',
+			filter TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+
+		db := sqlite.NewDB(path)
+		require.NoError(t, db.Open())
+		defer db.Close()
+
+		var fetcherType string
+		err = db.QueryRowContext(context.Background(),
+			"SELECT COALESCE(MAX(fetcher_type), '') FROM projects").Scan(&fetcherType)
+		require.NoError(t, err)
+	})
+
+	t.Run("adds project foreign key to existing documents table", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "old.db")
+
+		// Simulate a database whose documents table lacks the foreign key,
+		// including an orphaned document left behind by a deleted project
+		conn, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		_, err = conn.Exec(`
+			CREATE TABLE projects (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				source_url TEXT NOT NULL,
+				local_path TEXT NOT NULL DEFAULT '',
+				filter TEXT NOT NULL DEFAULT '',
+				fetcher_type TEXT NOT NULL DEFAULT '',
+				created_at TEXT NOT NULL,
+				updated_at TEXT NOT NULL
+			);
+			CREATE TABLE documents (
+				id TEXT PRIMARY KEY,
+				project_id TEXT NOT NULL,
+				file_path TEXT NOT NULL DEFAULT '',
+				source_url TEXT NOT NULL,
+				title TEXT NOT NULL DEFAULT '',
+				content TEXT NOT NULL DEFAULT '',
+				content_hash TEXT NOT NULL DEFAULT '',
+				position INTEGER NOT NULL DEFAULT 0,
+				fetched_at TEXT NOT NULL
+			);
+			INSERT INTO projects (id, name, source_url, created_at, updated_at)
+				VALUES ('proj-1', 'kept', 'https://example.com', '2025-01-01T00:00:00Z', '2025-01-01T00:00:00Z');
+			INSERT INTO documents (id, project_id, source_url, content, fetched_at)
+				VALUES ('doc-1', 'proj-1', 'https://example.com/a', 'routing', '2025-01-01T00:00:00Z');
+			INSERT INTO documents (id, project_id, source_url, content, fetched_at)
+				VALUES ('doc-2', 'proj-gone', 'https://example.com/b', 'routing', '2025-01-01T00:00:00Z');
+		`)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+
+		db := sqlite.NewDB(path)
+		require.NoError(t, db.Open())
+		defer db.Close()
+		ctx := context.Background()
+
+		var hasFK bool
+		err = db.QueryRowContext(ctx,
+			"SELECT COUNT(*) > 0 FROM pragma_foreign_key_list('documents') WHERE \"table\" = 'projects'").Scan(&hasFK)
+		require.NoError(t, err)
+		require.True(t, hasFK)
+
+		docs, err := sqlite.NewDocumentService(db).FindDocuments(ctx, locdoc.DocumentFilter{Query: "routing"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1, "orphaned document should be dropped")
+		require.Equal(t, "doc-1", docs[0].ID)
+
+		require.NoError(t, sqlite.NewProjectService(db).DeleteProject(ctx, "proj-1"))
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM documents").Scan(&count))
+		require.Zero(t, count)
+	})
+
 	t.Run("returns error for invalid path", func(t *testing.T) {
 		t.Parallel()
 
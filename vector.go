@@ -0,0 +1,56 @@
+package locdoc
+
+import (
+	"context"
+	"math"
+)
+
+// DefaultNearestDocuments is the number of documents NearTextFilter selects
+// when no count is given.
+const DefaultNearestDocuments = 10
+
+// VectorEncoder turns text into an embedding vector for similarity search.
+// Vectors from the same encoder can be compared with CosineSimilarity.
+type VectorEncoder interface {
+	// EncodeText returns the embedding of text.
+	EncodeText(ctx context.Context, text string) ([]float32, error)
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, from -1
+// for opposite vectors to 1 for vectors pointing the same way. Vectors of
+// different lengths, or with zero magnitude, have a similarity of 0.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// NearTextFilter returns filter narrowed to the k documents closest to text,
+// by setting NearVector to the encoding of text. A k of zero or less uses
+// DefaultNearestDocuments. When encoder is nil, filter is returned unchanged,
+// so callers can apply it unconditionally.
+func NearTextFilter(ctx context.Context, encoder VectorEncoder, text string, k int, filter DocumentFilter) (DocumentFilter, error) {
+	if encoder == nil {
+		return filter, nil
+	}
+	vector, err := encoder.EncodeText(ctx, text)
+	if err != nil {
+		return filter, err
+	}
+	if k <= 0 {
+		k = DefaultNearestDocuments
+	}
+	filter.NearVector = vector
+	filter.Limit = k
+	return filter, nil
+}
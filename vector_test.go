@@ -0,0 +1,106 @@
+package locdoc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fwojciec/locdoc"
+	"github.com/fwojciec/locdoc/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns 1 for vectors pointing the same way", func(t *testing.T) {
+		t.Parallel()
+
+		assert.InDelta(t, 1.0, locdoc.CosineSimilarity([]float32{1, 2, 3}, []float32{2, 4, 6}), 1e-9)
+	})
+
+	t.Run("returns 0 for orthogonal vectors", func(t *testing.T) {
+		t.Parallel()
+
+		assert.InDelta(t, 0.0, locdoc.CosineSimilarity([]float32{1, 0}, []float32{0, 1}), 1e-9)
+	})
+
+	t.Run("returns -1 for opposite vectors", func(t *testing.T) {
+		t.Parallel()
+
+		assert.InDelta(t, -1.0, locdoc.CosineSimilarity([]float32{1, -1}, []float32{-1, 1}), 1e-9)
+	})
+
+	t.Run("returns 0 for mismatched or zero vectors", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Zero(t, locdoc.CosineSimilarity([]float32{1, 2}, []float32{1, 2, 3}))
+		assert.Zero(t, locdoc.CosineSimilarity([]float32{0, 0}, []float32{1, 2}))
+		assert.Zero(t, locdoc.CosineSimilarity(nil, nil))
+	})
+}
+
+func TestNearTextFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns filter unchanged without an encoder", func(t *testing.T) {
+		t.Parallel()
+
+		projectID := "proj-1"
+		filter := locdoc.DocumentFilter{ProjectID: &projectID, Limit: 5}
+
+		got, err := locdoc.NearTextFilter(context.Background(), nil, "question", 3, filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, filter, got)
+	})
+
+	t.Run("sets vector and limit from the encoded text", func(t *testing.T) {
+		t.Parallel()
+
+		encoder := &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, text string) ([]float32, error) {
+				assert.Equal(t, "question", text)
+				return []float32{0.5, 0.5}, nil
+			},
+		}
+		projectID := "proj-1"
+
+		got, err := locdoc.NearTextFilter(context.Background(), encoder, "question", 3, locdoc.DocumentFilter{ProjectID: &projectID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []float32{0.5, 0.5}, got.NearVector)
+		assert.Equal(t, 3, got.Limit)
+		assert.Equal(t, &projectID, got.ProjectID)
+	})
+
+	t.Run("defaults to DefaultNearestDocuments", func(t *testing.T) {
+		t.Parallel()
+
+		encoder := &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, _ string) ([]float32, error) {
+				return []float32{1}, nil
+			},
+		}
+
+		got, err := locdoc.NearTextFilter(context.Background(), encoder, "question", 0, locdoc.DocumentFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, locdoc.DefaultNearestDocuments, got.Limit)
+	})
+
+	t.Run("returns encoder error", func(t *testing.T) {
+		t.Parallel()
+
+		encoder := &mock.VectorEncoder{
+			EncodeTextFn: func(_ context.Context, _ string) ([]float32, error) {
+				return nil, errors.New("encoder down")
+			},
+		}
+
+		_, err := locdoc.NearTextFilter(context.Background(), encoder, "question", 0, locdoc.DocumentFilter{})
+
+		require.EqualError(t, err, "encoder down")
+	})
+}